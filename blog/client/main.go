@@ -5,14 +5,35 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"os"
 
 	"google.golang.org/grpc"
 
 	bpb "blog/pb"
 )
 
+// tokenCredentials sends a bearer token with every call, as the blog server
+// expects for the calls that change blogs.
+type tokenCredentials string
+
+func (t tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+// RequireTransportSecurity is false so the token can be used against a local
+// server without tls.
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
 func main() {
-	cc, err := grpc.Dial("localhost:50051", grpc.WithInsecure())
+	opts := []grpc.DialOption{grpc.WithInsecure()}
+	// create a token with `make token AUTHOR=<author id>`
+	if token := os.Getenv("BLOG_TOKEN"); token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(tokenCredentials(token)))
+	}
+
+	cc, err := grpc.Dial("localhost:50051", opts...)
 	if err != nil {
 		log.Fatalf("could not connect: %v", err)
 	}
@@ -77,19 +98,33 @@ func main() {
 	/**
 	 * List blogs
 	 */
-	stream, err := c.ListBlog(context.Background(), &bpb.ListBlogRequest{})
-	if err != nil {
-		log.Fatalf("error while calling listBlog RPC: %v", err)
-	}
-
+	pageToken := ""
 	for {
-		res, err := stream.Recv()
-		if err == io.EOF {
-			break
-		}
+		stream, err := c.ListBlog(context.Background(), &bpb.ListBlogRequest{
+			PageSize:  10,
+			PageToken: pageToken,
+		})
 		if err != nil {
-			log.Fatalf("something happened: %v", err)
+			log.Fatalf("error while calling listBlog RPC: %v", err)
+		}
+
+		pageToken = ""
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				log.Fatalf("something happened: %v", err)
+			}
+			fmt.Println(res.GetBlog())
+			if res.GetNextPageToken() != "" {
+				pageToken = res.GetNextPageToken()
+			}
+		}
+
+		if pageToken == "" {
+			break
 		}
-		fmt.Println(res.GetBlog())
 	}
 }
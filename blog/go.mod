@@ -3,7 +3,13 @@ module blog
 go 1.14
 
 require (
+	github.com/golang-jwt/jwt v3.2.2+incompatible
 	github.com/golang/protobuf v1.4.0
 	go.mongodb.org/mongo-driver v1.3.2
+	google.golang.org/genproto v0.0.0-20190819201941-24fa4b261c55
 	google.golang.org/grpc v1.28.1
+	gopkg.in/yaml.v2 v2.4.0
+	lifecycle v0.0.0
 )
+
+replace lifecycle => ../lifecycle
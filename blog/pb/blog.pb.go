@@ -7,6 +7,8 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -24,14 +26,142 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type Blog_ContentFormat int32
+
+const (
+	// read as PLAIN
+	Blog_CONTENT_FORMAT_UNSPECIFIED Blog_ContentFormat = 0
+	Blog_PLAIN                      Blog_ContentFormat = 1
+	Blog_MARKDOWN                   Blog_ContentFormat = 2
+)
+
+var Blog_ContentFormat_name = map[int32]string{
+	0: "CONTENT_FORMAT_UNSPECIFIED",
+	1: "PLAIN",
+	2: "MARKDOWN",
+}
+
+var Blog_ContentFormat_value = map[string]int32{
+	"CONTENT_FORMAT_UNSPECIFIED": 0,
+	"PLAIN":                      1,
+	"MARKDOWN":                   2,
+}
+
+func (x Blog_ContentFormat) String() string {
+	return proto.EnumName(Blog_ContentFormat_name, int32(x))
+}
+
+func (Blog_ContentFormat) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{0, 0}
+}
+
+// only PUBLISHED blogs are seen by everyone, the others only by their
+// author and admins. see PublishBlog and UnpublishBlog
+type Blog_State int32
+
+const (
+	// read as PUBLISHED when creating a blog, so clients that do not
+	// know about states keep creating blogs everyone sees
+	Blog_STATE_UNSPECIFIED Blog_State = 0
+	Blog_DRAFT             Blog_State = 1
+	// published by the server once publish_time comes
+	Blog_SCHEDULED Blog_State = 2
+	Blog_PUBLISHED Blog_State = 3
+	Blog_ARCHIVED  Blog_State = 4
+)
+
+var Blog_State_name = map[int32]string{
+	0: "STATE_UNSPECIFIED",
+	1: "DRAFT",
+	2: "SCHEDULED",
+	3: "PUBLISHED",
+	4: "ARCHIVED",
+}
+
+var Blog_State_value = map[string]int32{
+	"STATE_UNSPECIFIED": 0,
+	"DRAFT":             1,
+	"SCHEDULED":         2,
+	"PUBLISHED":         3,
+	"ARCHIVED":          4,
+}
+
+func (x Blog_State) String() string {
+	return proto.EnumName(Blog_State_name, int32(x))
+}
+
+func (Blog_State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{0, 1}
+}
+
+type WatchBlogsResponse_EventType int32
+
+const (
+	WatchBlogsResponse_EVENT_TYPE_UNSPECIFIED WatchBlogsResponse_EventType = 0
+	WatchBlogsResponse_CREATED                WatchBlogsResponse_EventType = 1
+	WatchBlogsResponse_UPDATED                WatchBlogsResponse_EventType = 2
+	// the blog was moved to the trash
+	WatchBlogsResponse_DELETED WatchBlogsResponse_EventType = 3
+	// the blog was removed for good, only blog.id is set. purge events
+	// are not sent to watches filtered by author
+	WatchBlogsResponse_PURGED WatchBlogsResponse_EventType = 4
+)
+
+var WatchBlogsResponse_EventType_name = map[int32]string{
+	0: "EVENT_TYPE_UNSPECIFIED",
+	1: "CREATED",
+	2: "UPDATED",
+	3: "DELETED",
+	4: "PURGED",
+}
+
+var WatchBlogsResponse_EventType_value = map[string]int32{
+	"EVENT_TYPE_UNSPECIFIED": 0,
+	"CREATED":                1,
+	"UPDATED":                2,
+	"DELETED":                3,
+	"PURGED":                 4,
+}
+
+func (x WatchBlogsResponse_EventType) String() string {
+	return proto.EnumName(WatchBlogsResponse_EventType_name, int32(x))
+}
+
+func (WatchBlogsResponse_EventType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{33, 0}
+}
+
 type Blog struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	AuthorId             string   `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
-	Title                string   `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
-	Content              string   `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Id       string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AuthorId string `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Title    string `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Content  string `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	// set by the server, values sent by clients are ignored
+	CreateTime *timestamp.Timestamp `protobuf:"bytes,5,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime *timestamp.Timestamp `protobuf:"bytes,6,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	// starts at 1 and goes up by one on every update
+	Revision int64 `protobuf:"varint,7,opt,name=revision,proto3" json:"revision,omitempty"`
+	// set when the blog is in the trash, see DeleteBlog and RestoreBlog
+	DeleteTime *timestamp.Timestamp `protobuf:"bytes,8,opt,name=delete_time,json=deleteTime,proto3" json:"delete_time,omitempty"`
+	// labels of the blog, stored trimmed and in lower case without duplicates
+	Tags []string `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`
+	// number of comments on the blog, see CommentService. not set on
+	// revisions and watch events
+	CommentCount int64 `protobuf:"varint,10,opt,name=comment_count,json=commentCount,proto3" json:"comment_count,omitempty"`
+	// markup language of content
+	ContentFormat Blog_ContentFormat `protobuf:"varint,11,opt,name=content_format,json=contentFormat,proto3,enum=blog.Blog_ContentFormat" json:"content_format,omitempty"`
+	// content rendered as html that is safe to embed in a page, only set
+	// when asked for with render_html. values sent by clients are ignored
+	ContentHtml string `protobuf:"bytes,12,opt,name=content_html,json=contentHtml,proto3" json:"content_html,omitempty"`
+	// DRAFT, SCHEDULED or PUBLISHED when creating a blog, use PublishBlog and
+	// UnpublishBlog to change it afterwards
+	State Blog_State `protobuf:"varint,13,opt,name=state,proto3,enum=blog.Blog_State" json:"state,omitempty"`
+	// when the blog was or will be published. must be set in the future when
+	// creating a SCHEDULED blog, the server sets it otherwise
+	PublishTime          *timestamp.Timestamp `protobuf:"bytes,14,opt,name=publish_time,json=publishTime,proto3" json:"publish_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *Blog) Reset()         { *m = Blog{} }
@@ -87,8 +217,84 @@ func (m *Blog) GetContent() string {
 	return ""
 }
 
+func (m *Blog) GetCreateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreateTime
+	}
+	return nil
+}
+
+func (m *Blog) GetUpdateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdateTime
+	}
+	return nil
+}
+
+func (m *Blog) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *Blog) GetDeleteTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.DeleteTime
+	}
+	return nil
+}
+
+func (m *Blog) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *Blog) GetCommentCount() int64 {
+	if m != nil {
+		return m.CommentCount
+	}
+	return 0
+}
+
+func (m *Blog) GetContentFormat() Blog_ContentFormat {
+	if m != nil {
+		return m.ContentFormat
+	}
+	return Blog_CONTENT_FORMAT_UNSPECIFIED
+}
+
+func (m *Blog) GetContentHtml() string {
+	if m != nil {
+		return m.ContentHtml
+	}
+	return ""
+}
+
+func (m *Blog) GetState() Blog_State {
+	if m != nil {
+		return m.State
+	}
+	return Blog_STATE_UNSPECIFIED
+}
+
+func (m *Blog) GetPublishTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.PublishTime
+	}
+	return nil
+}
+
 type CreateBlogRequest struct {
-	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	Blog *Blog `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	// makes retries safe when set: a call with the same request_id as an
+	// earlier call of the same caller returns the blog that call created,
+	// instead of creating another one. request ids are remembered for a
+	// window configured on the server, use a new random value such as a uuid
+	// for every blog
+	RequestId            string   `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -126,6 +332,13 @@ func (m *CreateBlogRequest) GetBlog() *Blog {
 	return nil
 }
 
+func (m *CreateBlogRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
 type CreateBlogResponse struct {
 	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -166,7 +379,9 @@ func (m *CreateBlogResponse) GetBlog() *Blog {
 }
 
 type ReadBlogRequest struct {
-	BlogId               string   `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	BlogId string `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// also return the content rendered as html in blog.content_html
+	RenderHtml           bool     `protobuf:"varint,2,opt,name=render_html,json=renderHtml,proto3" json:"render_html,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -204,6 +419,13 @@ func (m *ReadBlogRequest) GetBlogId() string {
 	return ""
 }
 
+func (m *ReadBlogRequest) GetRenderHtml() bool {
+	if m != nil {
+		return m.RenderHtml
+	}
+	return false
+}
+
 type ReadBlogResponse struct {
 	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -244,10 +466,16 @@ func (m *ReadBlogResponse) GetBlog() *Blog {
 }
 
 type UpdateBlogRequest struct {
-	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Blog *Blog `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	// when set, the update fails with FAILED_PRECONDITION unless the stored
+	// blog is still at this revision
+	ExpectedRevision int64 `protobuf:"varint,2,opt,name=expected_revision,json=expectedRevision,proto3" json:"expected_revision,omitempty"`
+	// fields of blog to update, one of "author_id", "title", "content",
+	// "content_format" or "tags". every field is updated when the mask is empty
+	UpdateMask           *field_mask.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *UpdateBlogRequest) Reset()         { *m = UpdateBlogRequest{} }
@@ -282,6 +510,20 @@ func (m *UpdateBlogRequest) GetBlog() *Blog {
 	return nil
 }
 
+func (m *UpdateBlogRequest) GetExpectedRevision() int64 {
+	if m != nil {
+		return m.ExpectedRevision
+	}
+	return 0
+}
+
+func (m *UpdateBlogRequest) GetUpdateMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
 type UpdateBlogResponse struct {
 	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -361,7 +603,10 @@ func (m *DeleteBlogResponse) GetBlogId() string {
 }
 
 type DeleteBlogRequest struct {
-	BlogId               string   `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	BlogId string `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// when set, the delete fails with FAILED_PRECONDITION unless the stored
+	// blog is still at this revision
+	ExpectedRevision     int64    `protobuf:"varint,2,opt,name=expected_revision,json=expectedRevision,proto3" json:"expected_revision,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -399,338 +644,2913 @@ func (m *DeleteBlogRequest) GetBlogId() string {
 	return ""
 }
 
-type ListBlogRequest struct {
+func (m *DeleteBlogRequest) GetExpectedRevision() int64 {
+	if m != nil {
+		return m.ExpectedRevision
+	}
+	return 0
+}
+
+type RestoreBlogRequest struct {
+	BlogId string `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// when set, the restore fails with FAILED_PRECONDITION unless the stored
+	// blog is still at this revision
+	ExpectedRevision     int64    `protobuf:"varint,2,opt,name=expected_revision,json=expectedRevision,proto3" json:"expected_revision,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ListBlogRequest) Reset()         { *m = ListBlogRequest{} }
-func (m *ListBlogRequest) String() string { return proto.CompactTextString(m) }
-func (*ListBlogRequest) ProtoMessage()    {}
-func (*ListBlogRequest) Descriptor() ([]byte, []int) {
+func (m *RestoreBlogRequest) Reset()         { *m = RestoreBlogRequest{} }
+func (m *RestoreBlogRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreBlogRequest) ProtoMessage()    {}
+func (*RestoreBlogRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_140fdd8b592a3fc7, []int{9}
 }
 
-func (m *ListBlogRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListBlogRequest.Unmarshal(m, b)
+func (m *RestoreBlogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreBlogRequest.Unmarshal(m, b)
 }
-func (m *ListBlogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListBlogRequest.Marshal(b, m, deterministic)
+func (m *RestoreBlogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreBlogRequest.Marshal(b, m, deterministic)
 }
-func (m *ListBlogRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListBlogRequest.Merge(m, src)
+func (m *RestoreBlogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreBlogRequest.Merge(m, src)
 }
-func (m *ListBlogRequest) XXX_Size() int {
-	return xxx_messageInfo_ListBlogRequest.Size(m)
+func (m *RestoreBlogRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreBlogRequest.Size(m)
 }
-func (m *ListBlogRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListBlogRequest.DiscardUnknown(m)
+func (m *RestoreBlogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreBlogRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListBlogRequest proto.InternalMessageInfo
+var xxx_messageInfo_RestoreBlogRequest proto.InternalMessageInfo
 
-type ListBlogResponse struct {
+func (m *RestoreBlogRequest) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *RestoreBlogRequest) GetExpectedRevision() int64 {
+	if m != nil {
+		return m.ExpectedRevision
+	}
+	return 0
+}
+
+type RestoreBlogResponse struct {
 	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ListBlogResponse) Reset()         { *m = ListBlogResponse{} }
-func (m *ListBlogResponse) String() string { return proto.CompactTextString(m) }
-func (*ListBlogResponse) ProtoMessage()    {}
-func (*ListBlogResponse) Descriptor() ([]byte, []int) {
+func (m *RestoreBlogResponse) Reset()         { *m = RestoreBlogResponse{} }
+func (m *RestoreBlogResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreBlogResponse) ProtoMessage()    {}
+func (*RestoreBlogResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_140fdd8b592a3fc7, []int{10}
 }
 
-func (m *ListBlogResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListBlogResponse.Unmarshal(m, b)
+func (m *RestoreBlogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreBlogResponse.Unmarshal(m, b)
 }
-func (m *ListBlogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListBlogResponse.Marshal(b, m, deterministic)
+func (m *RestoreBlogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreBlogResponse.Marshal(b, m, deterministic)
 }
-func (m *ListBlogResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListBlogResponse.Merge(m, src)
+func (m *RestoreBlogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreBlogResponse.Merge(m, src)
 }
-func (m *ListBlogResponse) XXX_Size() int {
-	return xxx_messageInfo_ListBlogResponse.Size(m)
+func (m *RestoreBlogResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreBlogResponse.Size(m)
 }
-func (m *ListBlogResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListBlogResponse.DiscardUnknown(m)
+func (m *RestoreBlogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreBlogResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListBlogResponse proto.InternalMessageInfo
+var xxx_messageInfo_RestoreBlogResponse proto.InternalMessageInfo
 
-func (m *ListBlogResponse) GetBlog() *Blog {
+func (m *RestoreBlogResponse) GetBlog() *Blog {
 	if m != nil {
 		return m.Blog
 	}
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*Blog)(nil), "blog.Blog")
-	proto.RegisterType((*CreateBlogRequest)(nil), "blog.CreateBlogRequest")
-	proto.RegisterType((*CreateBlogResponse)(nil), "blog.CreateBlogResponse")
-	proto.RegisterType((*ReadBlogRequest)(nil), "blog.ReadBlogRequest")
-	proto.RegisterType((*ReadBlogResponse)(nil), "blog.ReadBlogResponse")
-	proto.RegisterType((*UpdateBlogRequest)(nil), "blog.UpdateBlogRequest")
-	proto.RegisterType((*UpdateBlogResponse)(nil), "blog.UpdateBlogResponse")
-	proto.RegisterType((*DeleteBlogResponse)(nil), "blog.DeleteBlogResponse")
-	proto.RegisterType((*DeleteBlogRequest)(nil), "blog.DeleteBlogRequest")
-	proto.RegisterType((*ListBlogRequest)(nil), "blog.ListBlogRequest")
-	proto.RegisterType((*ListBlogResponse)(nil), "blog.ListBlogResponse")
+type PurgeBlogRequest struct {
+	BlogId string `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// when set, the purge fails with FAILED_PRECONDITION unless the stored
+	// blog is still at this revision
+	ExpectedRevision     int64    `protobuf:"varint,2,opt,name=expected_revision,json=expectedRevision,proto3" json:"expected_revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() {
-	proto.RegisterFile("pb/blog.proto", fileDescriptor_140fdd8b592a3fc7)
+func (m *PurgeBlogRequest) Reset()         { *m = PurgeBlogRequest{} }
+func (m *PurgeBlogRequest) String() string { return proto.CompactTextString(m) }
+func (*PurgeBlogRequest) ProtoMessage()    {}
+func (*PurgeBlogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{11}
 }
 
-var fileDescriptor_140fdd8b592a3fc7 = []byte{
-	// 345 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0xc1, 0x4e, 0xc2, 0x40,
-	0x10, 0x0d, 0x15, 0xa1, 0x3c, 0xa2, 0xd8, 0x8d, 0x4a, 0x83, 0x89, 0x31, 0x9c, 0x8c, 0x51, 0x34,
-	0xe0, 0xc5, 0x78, 0x20, 0x41, 0x2f, 0x24, 0x9e, 0x30, 0x5e, 0xbc, 0x10, 0xca, 0x4e, 0x70, 0x93,
-	0x86, 0xad, 0xed, 0xe2, 0x37, 0xfa, 0x59, 0x66, 0xb7, 0xac, 0x6d, 0x5a, 0x0c, 0x78, 0xeb, 0xcc,
-	0xbe, 0x99, 0xf7, 0xe6, 0xbd, 0x14, 0x07, 0x51, 0x70, 0x1b, 0x84, 0x72, 0xd1, 0x8b, 0x62, 0xa9,
-	0x24, 0xab, 0xea, 0xef, 0xee, 0x1c, 0xd5, 0x51, 0x28, 0x17, 0xec, 0x10, 0x8e, 0xe0, 0x7e, 0xe5,
-	0xa2, 0x72, 0xd9, 0x98, 0x38, 0x82, 0xb3, 0x33, 0x34, 0x66, 0x2b, 0xf5, 0x21, 0xe3, 0xa9, 0xe0,
-	0xbe, 0x63, 0xda, 0x6e, 0xda, 0x18, 0x73, 0x76, 0x8c, 0x7d, 0x25, 0x54, 0x48, 0xfe, 0x9e, 0x79,
-	0x48, 0x0b, 0xe6, 0xa3, 0x3e, 0x97, 0x4b, 0x45, 0x4b, 0xe5, 0x57, 0x4d, 0xdf, 0x96, 0xdd, 0x01,
-	0xbc, 0xa7, 0x98, 0x66, 0x8a, 0x34, 0xd5, 0x84, 0x3e, 0x57, 0x94, 0x28, 0x76, 0x0e, 0xa3, 0xc0,
-	0x70, 0x36, 0xfb, 0xe8, 0x19, 0x69, 0x06, 0x90, 0x2a, 0xbb, 0x07, 0xcb, 0x0f, 0x25, 0x91, 0x5c,
-	0x26, 0xb4, 0x75, 0xea, 0x0a, 0xad, 0x09, 0xcd, 0x78, 0x9e, 0xa8, 0x8d, 0xba, 0x7e, 0x9a, 0xfe,
-	0xde, 0x57, 0xd3, 0xe5, 0x98, 0x77, 0xfb, 0x38, 0xca, 0xb0, 0x3b, 0xee, 0x1f, 0xc0, 0x7b, 0x8b,
-	0xf8, 0xff, 0x4f, 0xc9, 0x0f, 0xed, 0x48, 0x75, 0x03, 0xf6, 0x4c, 0x21, 0x15, 0xa6, 0xfe, 0xbc,
-	0xe6, 0x1a, 0x5e, 0x1e, 0xbe, 0xe5, 0x76, 0x0f, 0xad, 0x17, 0x91, 0xa8, 0x1c, 0x56, 0xdb, 0x91,
-	0xb5, 0x76, 0xd3, 0xd8, 0xff, 0x76, 0xd0, 0xd4, 0xe5, 0x2b, 0xc5, 0x5f, 0x62, 0x4e, 0x6c, 0x08,
-	0x64, 0xa1, 0xb1, 0x76, 0x8a, 0x2f, 0x65, 0xdf, 0xf1, 0xcb, 0x0f, 0x6b, 0xc2, 0x07, 0xb8, 0x36,
-	0x13, 0x76, 0x92, 0xa2, 0x0a, 0x79, 0x76, 0x4e, 0x8b, 0xed, 0xf5, 0xe8, 0x10, 0xc8, 0x5c, 0xb6,
-	0xdc, 0xa5, 0xb0, 0x2c, 0xf7, 0x86, 0x40, 0x86, 0x40, 0xe6, 0xa0, 0x5d, 0x50, 0xf2, 0xd4, 0x2e,
-	0xd8, 0x90, 0xcd, 0x23, 0x5c, 0xeb, 0xa0, 0x15, 0x5f, 0x30, 0xd9, 0x8a, 0x2f, 0x1a, 0x7d, 0x57,
-	0x19, 0xb9, 0xef, 0x26, 0x9b, 0x28, 0x08, 0x6a, 0xe6, 0x07, 0x1d, 0xfc, 0x04, 0x00, 0x00, 0xff,
-	0xff, 0xe4, 0x5f, 0x39, 0x83, 0xb1, 0x03, 0x00, 0x00,
+func (m *PurgeBlogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PurgeBlogRequest.Unmarshal(m, b)
+}
+func (m *PurgeBlogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PurgeBlogRequest.Marshal(b, m, deterministic)
+}
+func (m *PurgeBlogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PurgeBlogRequest.Merge(m, src)
+}
+func (m *PurgeBlogRequest) XXX_Size() int {
+	return xxx_messageInfo_PurgeBlogRequest.Size(m)
+}
+func (m *PurgeBlogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PurgeBlogRequest.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConnInterface
+var xxx_messageInfo_PurgeBlogRequest proto.InternalMessageInfo
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
+func (m *PurgeBlogRequest) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
 
-// BlogServiceClient is the client API for BlogService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type BlogServiceClient interface {
-	CreateBlog(ctx context.Context, in *CreateBlogRequest, opts ...grpc.CallOption) (*CreateBlogResponse, error)
-	ReadBlog(ctx context.Context, in *ReadBlogRequest, opts ...grpc.CallOption) (*ReadBlogResponse, error)
-	UpdateBlog(ctx context.Context, in *UpdateBlogRequest, opts ...grpc.CallOption) (*UpdateBlogResponse, error)
-	DeleteBlog(ctx context.Context, in *DeleteBlogRequest, opts ...grpc.CallOption) (*DeleteBlogResponse, error)
-	ListBlog(ctx context.Context, in *ListBlogRequest, opts ...grpc.CallOption) (BlogService_ListBlogClient, error)
+func (m *PurgeBlogRequest) GetExpectedRevision() int64 {
+	if m != nil {
+		return m.ExpectedRevision
+	}
+	return 0
 }
 
-type blogServiceClient struct {
-	cc grpc.ClientConnInterface
+type PurgeBlogResponse struct {
+	BlogId               string   `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func NewBlogServiceClient(cc grpc.ClientConnInterface) BlogServiceClient {
-	return &blogServiceClient{cc}
+func (m *PurgeBlogResponse) Reset()         { *m = PurgeBlogResponse{} }
+func (m *PurgeBlogResponse) String() string { return proto.CompactTextString(m) }
+func (*PurgeBlogResponse) ProtoMessage()    {}
+func (*PurgeBlogResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{12}
 }
 
-func (c *blogServiceClient) CreateBlog(ctx context.Context, in *CreateBlogRequest, opts ...grpc.CallOption) (*CreateBlogResponse, error) {
-	out := new(CreateBlogResponse)
-	err := c.cc.Invoke(ctx, "/blog.BlogService/CreateBlog", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *PurgeBlogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PurgeBlogResponse.Unmarshal(m, b)
+}
+func (m *PurgeBlogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PurgeBlogResponse.Marshal(b, m, deterministic)
+}
+func (m *PurgeBlogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PurgeBlogResponse.Merge(m, src)
+}
+func (m *PurgeBlogResponse) XXX_Size() int {
+	return xxx_messageInfo_PurgeBlogResponse.Size(m)
+}
+func (m *PurgeBlogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PurgeBlogResponse.DiscardUnknown(m)
 }
 
-func (c *blogServiceClient) ReadBlog(ctx context.Context, in *ReadBlogRequest, opts ...grpc.CallOption) (*ReadBlogResponse, error) {
-	out := new(ReadBlogResponse)
-	err := c.cc.Invoke(ctx, "/blog.BlogService/ReadBlog", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_PurgeBlogResponse proto.InternalMessageInfo
+
+func (m *PurgeBlogResponse) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
 	}
-	return out, nil
+	return ""
 }
 
-func (c *blogServiceClient) UpdateBlog(ctx context.Context, in *UpdateBlogRequest, opts ...grpc.CallOption) (*UpdateBlogResponse, error) {
-	out := new(UpdateBlogResponse)
-	err := c.cc.Invoke(ctx, "/blog.BlogService/UpdateBlog", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+// BlogRevision is a snapshot of a blog taken every time it was written.
+type BlogRevision struct {
+	BlogId   string `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	Revision int64  `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	// the blog as it was at this revision
+	Blog       *Blog                `protobuf:"bytes,3,opt,name=blog,proto3" json:"blog,omitempty"`
+	CreateTime *timestamp.Timestamp `protobuf:"bytes,4,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// blog fields that differ from the previous revision, one of "author_id",
+	// "title", "content", "content_format", "tags", "state" or "delete_time".
+	// empty for the first revision
+	ChangedFields        []string `protobuf:"bytes,5,rep,name=changed_fields,json=changedFields,proto3" json:"changed_fields,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *blogServiceClient) DeleteBlog(ctx context.Context, in *DeleteBlogRequest, opts ...grpc.CallOption) (*DeleteBlogResponse, error) {
-	out := new(DeleteBlogResponse)
-	err := c.cc.Invoke(ctx, "/blog.BlogService/DeleteBlog", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *BlogRevision) Reset()         { *m = BlogRevision{} }
+func (m *BlogRevision) String() string { return proto.CompactTextString(m) }
+func (*BlogRevision) ProtoMessage()    {}
+func (*BlogRevision) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{13}
+}
+
+func (m *BlogRevision) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlogRevision.Unmarshal(m, b)
+}
+func (m *BlogRevision) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlogRevision.Marshal(b, m, deterministic)
+}
+func (m *BlogRevision) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlogRevision.Merge(m, src)
+}
+func (m *BlogRevision) XXX_Size() int {
+	return xxx_messageInfo_BlogRevision.Size(m)
+}
+func (m *BlogRevision) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlogRevision.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlogRevision proto.InternalMessageInfo
+
+func (m *BlogRevision) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
 	}
-	return out, nil
+	return ""
 }
 
-func (c *blogServiceClient) ListBlog(ctx context.Context, in *ListBlogRequest, opts ...grpc.CallOption) (BlogService_ListBlogClient, error) {
+func (m *BlogRevision) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *BlogRevision) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+func (m *BlogRevision) GetCreateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreateTime
+	}
+	return nil
+}
+
+func (m *BlogRevision) GetChangedFields() []string {
+	if m != nil {
+		return m.ChangedFields
+	}
+	return nil
+}
+
+type ListBlogRevisionsRequest struct {
+	BlogId string `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// maximum number of revisions to return, the server picks a default when 0
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from a previous ListBlogRevisions call, empty for the first page
+	PageToken            string   `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListBlogRevisionsRequest) Reset()         { *m = ListBlogRevisionsRequest{} }
+func (m *ListBlogRevisionsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListBlogRevisionsRequest) ProtoMessage()    {}
+func (*ListBlogRevisionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{14}
+}
+
+func (m *ListBlogRevisionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListBlogRevisionsRequest.Unmarshal(m, b)
+}
+func (m *ListBlogRevisionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListBlogRevisionsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListBlogRevisionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListBlogRevisionsRequest.Merge(m, src)
+}
+func (m *ListBlogRevisionsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListBlogRevisionsRequest.Size(m)
+}
+func (m *ListBlogRevisionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListBlogRevisionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListBlogRevisionsRequest proto.InternalMessageInfo
+
+func (m *ListBlogRevisionsRequest) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *ListBlogRevisionsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListBlogRevisionsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListBlogRevisionsResponse struct {
+	// newest revision first
+	Revisions []*BlogRevision `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	// set when more revisions are available
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListBlogRevisionsResponse) Reset()         { *m = ListBlogRevisionsResponse{} }
+func (m *ListBlogRevisionsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListBlogRevisionsResponse) ProtoMessage()    {}
+func (*ListBlogRevisionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{15}
+}
+
+func (m *ListBlogRevisionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListBlogRevisionsResponse.Unmarshal(m, b)
+}
+func (m *ListBlogRevisionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListBlogRevisionsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListBlogRevisionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListBlogRevisionsResponse.Merge(m, src)
+}
+func (m *ListBlogRevisionsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListBlogRevisionsResponse.Size(m)
+}
+func (m *ListBlogRevisionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListBlogRevisionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListBlogRevisionsResponse proto.InternalMessageInfo
+
+func (m *ListBlogRevisionsResponse) GetRevisions() []*BlogRevision {
+	if m != nil {
+		return m.Revisions
+	}
+	return nil
+}
+
+func (m *ListBlogRevisionsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type GetBlogRevisionRequest struct {
+	BlogId               string   `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	Revision             int64    `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlogRevisionRequest) Reset()         { *m = GetBlogRevisionRequest{} }
+func (m *GetBlogRevisionRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlogRevisionRequest) ProtoMessage()    {}
+func (*GetBlogRevisionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{16}
+}
+
+func (m *GetBlogRevisionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlogRevisionRequest.Unmarshal(m, b)
+}
+func (m *GetBlogRevisionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlogRevisionRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBlogRevisionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlogRevisionRequest.Merge(m, src)
+}
+func (m *GetBlogRevisionRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlogRevisionRequest.Size(m)
+}
+func (m *GetBlogRevisionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlogRevisionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlogRevisionRequest proto.InternalMessageInfo
+
+func (m *GetBlogRevisionRequest) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *GetBlogRevisionRequest) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+type GetBlogRevisionResponse struct {
+	Revision             *BlogRevision `protobuf:"bytes,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *GetBlogRevisionResponse) Reset()         { *m = GetBlogRevisionResponse{} }
+func (m *GetBlogRevisionResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBlogRevisionResponse) ProtoMessage()    {}
+func (*GetBlogRevisionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{17}
+}
+
+func (m *GetBlogRevisionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlogRevisionResponse.Unmarshal(m, b)
+}
+func (m *GetBlogRevisionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlogRevisionResponse.Marshal(b, m, deterministic)
+}
+func (m *GetBlogRevisionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlogRevisionResponse.Merge(m, src)
+}
+func (m *GetBlogRevisionResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBlogRevisionResponse.Size(m)
+}
+func (m *GetBlogRevisionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlogRevisionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlogRevisionResponse proto.InternalMessageInfo
+
+func (m *GetBlogRevisionResponse) GetRevision() *BlogRevision {
+	if m != nil {
+		return m.Revision
+	}
+	return nil
+}
+
+type RollbackBlogRequest struct {
+	BlogId string `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// the revision whose author, title, content and tags become the new head
+	// revision
+	Revision int64 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	// when set, the rollback fails with FAILED_PRECONDITION unless the stored
+	// blog is still at this revision
+	ExpectedRevision     int64    `protobuf:"varint,3,opt,name=expected_revision,json=expectedRevision,proto3" json:"expected_revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RollbackBlogRequest) Reset()         { *m = RollbackBlogRequest{} }
+func (m *RollbackBlogRequest) String() string { return proto.CompactTextString(m) }
+func (*RollbackBlogRequest) ProtoMessage()    {}
+func (*RollbackBlogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{18}
+}
+
+func (m *RollbackBlogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RollbackBlogRequest.Unmarshal(m, b)
+}
+func (m *RollbackBlogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RollbackBlogRequest.Marshal(b, m, deterministic)
+}
+func (m *RollbackBlogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollbackBlogRequest.Merge(m, src)
+}
+func (m *RollbackBlogRequest) XXX_Size() int {
+	return xxx_messageInfo_RollbackBlogRequest.Size(m)
+}
+func (m *RollbackBlogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollbackBlogRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RollbackBlogRequest proto.InternalMessageInfo
+
+func (m *RollbackBlogRequest) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *RollbackBlogRequest) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *RollbackBlogRequest) GetExpectedRevision() int64 {
+	if m != nil {
+		return m.ExpectedRevision
+	}
+	return 0
+}
+
+type RollbackBlogResponse struct {
+	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RollbackBlogResponse) Reset()         { *m = RollbackBlogResponse{} }
+func (m *RollbackBlogResponse) String() string { return proto.CompactTextString(m) }
+func (*RollbackBlogResponse) ProtoMessage()    {}
+func (*RollbackBlogResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{19}
+}
+
+func (m *RollbackBlogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RollbackBlogResponse.Unmarshal(m, b)
+}
+func (m *RollbackBlogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RollbackBlogResponse.Marshal(b, m, deterministic)
+}
+func (m *RollbackBlogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollbackBlogResponse.Merge(m, src)
+}
+func (m *RollbackBlogResponse) XXX_Size() int {
+	return xxx_messageInfo_RollbackBlogResponse.Size(m)
+}
+func (m *RollbackBlogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollbackBlogResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RollbackBlogResponse proto.InternalMessageInfo
+
+func (m *RollbackBlogResponse) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+type BatchGetBlogsRequest struct {
+	BlogIds              []string `protobuf:"bytes,1,rep,name=blog_ids,json=blogIds,proto3" json:"blog_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchGetBlogsRequest) Reset()         { *m = BatchGetBlogsRequest{} }
+func (m *BatchGetBlogsRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchGetBlogsRequest) ProtoMessage()    {}
+func (*BatchGetBlogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{20}
+}
+
+func (m *BatchGetBlogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchGetBlogsRequest.Unmarshal(m, b)
+}
+func (m *BatchGetBlogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchGetBlogsRequest.Marshal(b, m, deterministic)
+}
+func (m *BatchGetBlogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchGetBlogsRequest.Merge(m, src)
+}
+func (m *BatchGetBlogsRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchGetBlogsRequest.Size(m)
+}
+func (m *BatchGetBlogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchGetBlogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchGetBlogsRequest proto.InternalMessageInfo
+
+func (m *BatchGetBlogsRequest) GetBlogIds() []string {
+	if m != nil {
+		return m.BlogIds
+	}
+	return nil
+}
+
+type BatchGetBlogsResponse struct {
+	// the blogs that were found, in the order of blog_ids
+	Blogs []*Blog `protobuf:"bytes,1,rep,name=blogs,proto3" json:"blogs,omitempty"`
+	// the requested ids that have no blog, or whose blog is in the trash
+	NotFoundIds          []string `protobuf:"bytes,2,rep,name=not_found_ids,json=notFoundIds,proto3" json:"not_found_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchGetBlogsResponse) Reset()         { *m = BatchGetBlogsResponse{} }
+func (m *BatchGetBlogsResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchGetBlogsResponse) ProtoMessage()    {}
+func (*BatchGetBlogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{21}
+}
+
+func (m *BatchGetBlogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchGetBlogsResponse.Unmarshal(m, b)
+}
+func (m *BatchGetBlogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchGetBlogsResponse.Marshal(b, m, deterministic)
+}
+func (m *BatchGetBlogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchGetBlogsResponse.Merge(m, src)
+}
+func (m *BatchGetBlogsResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchGetBlogsResponse.Size(m)
+}
+func (m *BatchGetBlogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchGetBlogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchGetBlogsResponse proto.InternalMessageInfo
+
+func (m *BatchGetBlogsResponse) GetBlogs() []*Blog {
+	if m != nil {
+		return m.Blogs
+	}
+	return nil
+}
+
+func (m *BatchGetBlogsResponse) GetNotFoundIds() []string {
+	if m != nil {
+		return m.NotFoundIds
+	}
+	return nil
+}
+
+type BulkCreateBlogsRequest struct {
+	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BulkCreateBlogsRequest) Reset()         { *m = BulkCreateBlogsRequest{} }
+func (m *BulkCreateBlogsRequest) String() string { return proto.CompactTextString(m) }
+func (*BulkCreateBlogsRequest) ProtoMessage()    {}
+func (*BulkCreateBlogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{22}
+}
+
+func (m *BulkCreateBlogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkCreateBlogsRequest.Unmarshal(m, b)
+}
+func (m *BulkCreateBlogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkCreateBlogsRequest.Marshal(b, m, deterministic)
+}
+func (m *BulkCreateBlogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkCreateBlogsRequest.Merge(m, src)
+}
+func (m *BulkCreateBlogsRequest) XXX_Size() int {
+	return xxx_messageInfo_BulkCreateBlogsRequest.Size(m)
+}
+func (m *BulkCreateBlogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkCreateBlogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkCreateBlogsRequest proto.InternalMessageInfo
+
+func (m *BulkCreateBlogsRequest) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+type BulkCreateBlogsResponse struct {
+	// one result per blog sent, in the order they were sent
+	Results              []*BulkCreateBlogsResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
+	XXX_unrecognized     []byte                            `json:"-"`
+	XXX_sizecache        int32                             `json:"-"`
+}
+
+func (m *BulkCreateBlogsResponse) Reset()         { *m = BulkCreateBlogsResponse{} }
+func (m *BulkCreateBlogsResponse) String() string { return proto.CompactTextString(m) }
+func (*BulkCreateBlogsResponse) ProtoMessage()    {}
+func (*BulkCreateBlogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{23}
+}
+
+func (m *BulkCreateBlogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkCreateBlogsResponse.Unmarshal(m, b)
+}
+func (m *BulkCreateBlogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkCreateBlogsResponse.Marshal(b, m, deterministic)
+}
+func (m *BulkCreateBlogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkCreateBlogsResponse.Merge(m, src)
+}
+func (m *BulkCreateBlogsResponse) XXX_Size() int {
+	return xxx_messageInfo_BulkCreateBlogsResponse.Size(m)
+}
+func (m *BulkCreateBlogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkCreateBlogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkCreateBlogsResponse proto.InternalMessageInfo
+
+func (m *BulkCreateBlogsResponse) GetResults() []*BulkCreateBlogsResponse_Result {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type BulkCreateBlogsResponse_Result struct {
+	// position of the blog in the request stream, starting at 0
+	Index int32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// the created blog, unset when it could not be created
+	Blog *Blog `protobuf:"bytes,2,opt,name=blog,proto3" json:"blog,omitempty"`
+	// grpc status code and message telling why the blog could not be
+	// created, code is 0 (OK) when it was
+	Code                 int32    `protobuf:"varint,3,opt,name=code,proto3" json:"code,omitempty"`
+	Message              string   `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BulkCreateBlogsResponse_Result) Reset()         { *m = BulkCreateBlogsResponse_Result{} }
+func (m *BulkCreateBlogsResponse_Result) String() string { return proto.CompactTextString(m) }
+func (*BulkCreateBlogsResponse_Result) ProtoMessage()    {}
+func (*BulkCreateBlogsResponse_Result) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{23, 0}
+}
+
+func (m *BulkCreateBlogsResponse_Result) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkCreateBlogsResponse_Result.Unmarshal(m, b)
+}
+func (m *BulkCreateBlogsResponse_Result) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkCreateBlogsResponse_Result.Marshal(b, m, deterministic)
+}
+func (m *BulkCreateBlogsResponse_Result) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkCreateBlogsResponse_Result.Merge(m, src)
+}
+func (m *BulkCreateBlogsResponse_Result) XXX_Size() int {
+	return xxx_messageInfo_BulkCreateBlogsResponse_Result.Size(m)
+}
+func (m *BulkCreateBlogsResponse_Result) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkCreateBlogsResponse_Result.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkCreateBlogsResponse_Result proto.InternalMessageInfo
+
+func (m *BulkCreateBlogsResponse_Result) GetIndex() int32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *BulkCreateBlogsResponse_Result) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+func (m *BulkCreateBlogsResponse_Result) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *BulkCreateBlogsResponse_Result) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type PublishBlogRequest struct {
+	BlogId string `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// schedules the blog to be published at this time when it is in the
+	// future, the blog is published right away otherwise
+	PublishTime *timestamp.Timestamp `protobuf:"bytes,2,opt,name=publish_time,json=publishTime,proto3" json:"publish_time,omitempty"`
+	// when set, the publish fails with FAILED_PRECONDITION unless the stored
+	// blog is still at this revision
+	ExpectedRevision     int64    `protobuf:"varint,3,opt,name=expected_revision,json=expectedRevision,proto3" json:"expected_revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PublishBlogRequest) Reset()         { *m = PublishBlogRequest{} }
+func (m *PublishBlogRequest) String() string { return proto.CompactTextString(m) }
+func (*PublishBlogRequest) ProtoMessage()    {}
+func (*PublishBlogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{24}
+}
+
+func (m *PublishBlogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishBlogRequest.Unmarshal(m, b)
+}
+func (m *PublishBlogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishBlogRequest.Marshal(b, m, deterministic)
+}
+func (m *PublishBlogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishBlogRequest.Merge(m, src)
+}
+func (m *PublishBlogRequest) XXX_Size() int {
+	return xxx_messageInfo_PublishBlogRequest.Size(m)
+}
+func (m *PublishBlogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishBlogRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishBlogRequest proto.InternalMessageInfo
+
+func (m *PublishBlogRequest) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *PublishBlogRequest) GetPublishTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.PublishTime
+	}
+	return nil
+}
+
+func (m *PublishBlogRequest) GetExpectedRevision() int64 {
+	if m != nil {
+		return m.ExpectedRevision
+	}
+	return 0
+}
+
+type PublishBlogResponse struct {
+	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PublishBlogResponse) Reset()         { *m = PublishBlogResponse{} }
+func (m *PublishBlogResponse) String() string { return proto.CompactTextString(m) }
+func (*PublishBlogResponse) ProtoMessage()    {}
+func (*PublishBlogResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{25}
+}
+
+func (m *PublishBlogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishBlogResponse.Unmarshal(m, b)
+}
+func (m *PublishBlogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishBlogResponse.Marshal(b, m, deterministic)
+}
+func (m *PublishBlogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishBlogResponse.Merge(m, src)
+}
+func (m *PublishBlogResponse) XXX_Size() int {
+	return xxx_messageInfo_PublishBlogResponse.Size(m)
+}
+func (m *PublishBlogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishBlogResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishBlogResponse proto.InternalMessageInfo
+
+func (m *PublishBlogResponse) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+type UnpublishBlogRequest struct {
+	BlogId string `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// moves the blog to ARCHIVED instead of DRAFT
+	Archive bool `protobuf:"varint,2,opt,name=archive,proto3" json:"archive,omitempty"`
+	// when set, the unpublish fails with FAILED_PRECONDITION unless the stored
+	// blog is still at this revision
+	ExpectedRevision     int64    `protobuf:"varint,3,opt,name=expected_revision,json=expectedRevision,proto3" json:"expected_revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnpublishBlogRequest) Reset()         { *m = UnpublishBlogRequest{} }
+func (m *UnpublishBlogRequest) String() string { return proto.CompactTextString(m) }
+func (*UnpublishBlogRequest) ProtoMessage()    {}
+func (*UnpublishBlogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{26}
+}
+
+func (m *UnpublishBlogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnpublishBlogRequest.Unmarshal(m, b)
+}
+func (m *UnpublishBlogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnpublishBlogRequest.Marshal(b, m, deterministic)
+}
+func (m *UnpublishBlogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnpublishBlogRequest.Merge(m, src)
+}
+func (m *UnpublishBlogRequest) XXX_Size() int {
+	return xxx_messageInfo_UnpublishBlogRequest.Size(m)
+}
+func (m *UnpublishBlogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnpublishBlogRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnpublishBlogRequest proto.InternalMessageInfo
+
+func (m *UnpublishBlogRequest) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *UnpublishBlogRequest) GetArchive() bool {
+	if m != nil {
+		return m.Archive
+	}
+	return false
+}
+
+func (m *UnpublishBlogRequest) GetExpectedRevision() int64 {
+	if m != nil {
+		return m.ExpectedRevision
+	}
+	return 0
+}
+
+type UnpublishBlogResponse struct {
+	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnpublishBlogResponse) Reset()         { *m = UnpublishBlogResponse{} }
+func (m *UnpublishBlogResponse) String() string { return proto.CompactTextString(m) }
+func (*UnpublishBlogResponse) ProtoMessage()    {}
+func (*UnpublishBlogResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{27}
+}
+
+func (m *UnpublishBlogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnpublishBlogResponse.Unmarshal(m, b)
+}
+func (m *UnpublishBlogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnpublishBlogResponse.Marshal(b, m, deterministic)
+}
+func (m *UnpublishBlogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnpublishBlogResponse.Merge(m, src)
+}
+func (m *UnpublishBlogResponse) XXX_Size() int {
+	return xxx_messageInfo_UnpublishBlogResponse.Size(m)
+}
+func (m *UnpublishBlogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnpublishBlogResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnpublishBlogResponse proto.InternalMessageInfo
+
+func (m *UnpublishBlogResponse) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+type ListTagsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListTagsRequest) Reset()         { *m = ListTagsRequest{} }
+func (m *ListTagsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListTagsRequest) ProtoMessage()    {}
+func (*ListTagsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{28}
+}
+
+func (m *ListTagsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTagsRequest.Unmarshal(m, b)
+}
+func (m *ListTagsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTagsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListTagsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTagsRequest.Merge(m, src)
+}
+func (m *ListTagsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListTagsRequest.Size(m)
+}
+func (m *ListTagsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTagsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTagsRequest proto.InternalMessageInfo
+
+type ListTagsResponse struct {
+	// most used tags first
+	Tags                 []*ListTagsResponse_Tag `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *ListTagsResponse) Reset()         { *m = ListTagsResponse{} }
+func (m *ListTagsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListTagsResponse) ProtoMessage()    {}
+func (*ListTagsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{29}
+}
+
+func (m *ListTagsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTagsResponse.Unmarshal(m, b)
+}
+func (m *ListTagsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTagsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListTagsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTagsResponse.Merge(m, src)
+}
+func (m *ListTagsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListTagsResponse.Size(m)
+}
+func (m *ListTagsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTagsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTagsResponse proto.InternalMessageInfo
+
+func (m *ListTagsResponse) GetTags() []*ListTagsResponse_Tag {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type ListTagsResponse_Tag struct {
+	Tag string `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	// number of published blogs with the tag, blogs in the trash are not
+	// counted
+	BlogCount            int64    `protobuf:"varint,2,opt,name=blog_count,json=blogCount,proto3" json:"blog_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListTagsResponse_Tag) Reset()         { *m = ListTagsResponse_Tag{} }
+func (m *ListTagsResponse_Tag) String() string { return proto.CompactTextString(m) }
+func (*ListTagsResponse_Tag) ProtoMessage()    {}
+func (*ListTagsResponse_Tag) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{29, 0}
+}
+
+func (m *ListTagsResponse_Tag) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTagsResponse_Tag.Unmarshal(m, b)
+}
+func (m *ListTagsResponse_Tag) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTagsResponse_Tag.Marshal(b, m, deterministic)
+}
+func (m *ListTagsResponse_Tag) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTagsResponse_Tag.Merge(m, src)
+}
+func (m *ListTagsResponse_Tag) XXX_Size() int {
+	return xxx_messageInfo_ListTagsResponse_Tag.Size(m)
+}
+func (m *ListTagsResponse_Tag) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTagsResponse_Tag.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTagsResponse_Tag proto.InternalMessageInfo
+
+func (m *ListTagsResponse_Tag) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+func (m *ListTagsResponse_Tag) GetBlogCount() int64 {
+	if m != nil {
+		return m.BlogCount
+	}
+	return 0
+}
+
+type SearchBlogsRequest struct {
+	// words to look for in the title and content of blogs, ignoring case,
+	// plurals and common words such as "the"
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// maximum number of results to return, the server picks a default when 0
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from a previous SearchBlogs call with the same query,
+	// empty for the first page
+	PageToken            string   `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchBlogsRequest) Reset()         { *m = SearchBlogsRequest{} }
+func (m *SearchBlogsRequest) String() string { return proto.CompactTextString(m) }
+func (*SearchBlogsRequest) ProtoMessage()    {}
+func (*SearchBlogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{30}
+}
+
+func (m *SearchBlogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchBlogsRequest.Unmarshal(m, b)
+}
+func (m *SearchBlogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchBlogsRequest.Marshal(b, m, deterministic)
+}
+func (m *SearchBlogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchBlogsRequest.Merge(m, src)
+}
+func (m *SearchBlogsRequest) XXX_Size() int {
+	return xxx_messageInfo_SearchBlogsRequest.Size(m)
+}
+func (m *SearchBlogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchBlogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchBlogsRequest proto.InternalMessageInfo
+
+func (m *SearchBlogsRequest) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *SearchBlogsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *SearchBlogsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type SearchBlogsResponse struct {
+	// most relevant blog first, only published blogs that are not in the
+	// trash are searched
+	Results []*SearchBlogsResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// set when more results are available
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchBlogsResponse) Reset()         { *m = SearchBlogsResponse{} }
+func (m *SearchBlogsResponse) String() string { return proto.CompactTextString(m) }
+func (*SearchBlogsResponse) ProtoMessage()    {}
+func (*SearchBlogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{31}
+}
+
+func (m *SearchBlogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchBlogsResponse.Unmarshal(m, b)
+}
+func (m *SearchBlogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchBlogsResponse.Marshal(b, m, deterministic)
+}
+func (m *SearchBlogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchBlogsResponse.Merge(m, src)
+}
+func (m *SearchBlogsResponse) XXX_Size() int {
+	return xxx_messageInfo_SearchBlogsResponse.Size(m)
+}
+func (m *SearchBlogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchBlogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchBlogsResponse proto.InternalMessageInfo
+
+func (m *SearchBlogsResponse) GetResults() []*SearchBlogsResponse_Result {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+func (m *SearchBlogsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+// part of a snippet that matches the query, from start up to but not
+// including end, counted in unicode code points
+type SearchBlogsResponse_Highlight struct {
+	Start                int32    `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End                  int32    `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchBlogsResponse_Highlight) Reset()         { *m = SearchBlogsResponse_Highlight{} }
+func (m *SearchBlogsResponse_Highlight) String() string { return proto.CompactTextString(m) }
+func (*SearchBlogsResponse_Highlight) ProtoMessage()    {}
+func (*SearchBlogsResponse_Highlight) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{31, 0}
+}
+
+func (m *SearchBlogsResponse_Highlight) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchBlogsResponse_Highlight.Unmarshal(m, b)
+}
+func (m *SearchBlogsResponse_Highlight) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchBlogsResponse_Highlight.Marshal(b, m, deterministic)
+}
+func (m *SearchBlogsResponse_Highlight) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchBlogsResponse_Highlight.Merge(m, src)
+}
+func (m *SearchBlogsResponse_Highlight) XXX_Size() int {
+	return xxx_messageInfo_SearchBlogsResponse_Highlight.Size(m)
+}
+func (m *SearchBlogsResponse_Highlight) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchBlogsResponse_Highlight.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchBlogsResponse_Highlight proto.InternalMessageInfo
+
+func (m *SearchBlogsResponse_Highlight) GetStart() int32 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *SearchBlogsResponse_Highlight) GetEnd() int32 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+type SearchBlogsResponse_Result struct {
+	Blog *Blog `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	// higher is more relevant, scores are only comparable within a search
+	Score float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// the part of the content, or else the title, that best matches the
+	// query. it is taken from the content as is, so it must be escaped
+	// before it is shown as html
+	Snippet              string                           `protobuf:"bytes,3,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	Highlights           []*SearchBlogsResponse_Highlight `protobuf:"bytes,4,rep,name=highlights,proto3" json:"highlights,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_unrecognized     []byte                           `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
+}
+
+func (m *SearchBlogsResponse_Result) Reset()         { *m = SearchBlogsResponse_Result{} }
+func (m *SearchBlogsResponse_Result) String() string { return proto.CompactTextString(m) }
+func (*SearchBlogsResponse_Result) ProtoMessage()    {}
+func (*SearchBlogsResponse_Result) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{31, 1}
+}
+
+func (m *SearchBlogsResponse_Result) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchBlogsResponse_Result.Unmarshal(m, b)
+}
+func (m *SearchBlogsResponse_Result) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchBlogsResponse_Result.Marshal(b, m, deterministic)
+}
+func (m *SearchBlogsResponse_Result) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchBlogsResponse_Result.Merge(m, src)
+}
+func (m *SearchBlogsResponse_Result) XXX_Size() int {
+	return xxx_messageInfo_SearchBlogsResponse_Result.Size(m)
+}
+func (m *SearchBlogsResponse_Result) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchBlogsResponse_Result.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchBlogsResponse_Result proto.InternalMessageInfo
+
+func (m *SearchBlogsResponse_Result) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+func (m *SearchBlogsResponse_Result) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *SearchBlogsResponse_Result) GetSnippet() string {
+	if m != nil {
+		return m.Snippet
+	}
+	return ""
+}
+
+func (m *SearchBlogsResponse_Result) GetHighlights() []*SearchBlogsResponse_Highlight {
+	if m != nil {
+		return m.Highlights
+	}
+	return nil
+}
+
+type WatchBlogsRequest struct {
+	// only watch blogs written by this author
+	AuthorId string `protobuf:"bytes,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	// resume_token of the last event received on a previous watch, to get
+	// every event since then. empty to only get new events
+	ResumeToken          string   `protobuf:"bytes,2,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchBlogsRequest) Reset()         { *m = WatchBlogsRequest{} }
+func (m *WatchBlogsRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchBlogsRequest) ProtoMessage()    {}
+func (*WatchBlogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{32}
+}
+
+func (m *WatchBlogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchBlogsRequest.Unmarshal(m, b)
+}
+func (m *WatchBlogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchBlogsRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchBlogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchBlogsRequest.Merge(m, src)
+}
+func (m *WatchBlogsRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchBlogsRequest.Size(m)
+}
+func (m *WatchBlogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchBlogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchBlogsRequest proto.InternalMessageInfo
+
+func (m *WatchBlogsRequest) GetAuthorId() string {
+	if m != nil {
+		return m.AuthorId
+	}
+	return ""
+}
+
+func (m *WatchBlogsRequest) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
+type WatchBlogsResponse struct {
+	EventType            WatchBlogsResponse_EventType `protobuf:"varint,1,opt,name=event_type,json=eventType,proto3,enum=blog.WatchBlogsResponse_EventType" json:"event_type,omitempty"`
+	Blog                 *Blog                        `protobuf:"bytes,2,opt,name=blog,proto3" json:"blog,omitempty"`
+	ResumeToken          string                       `protobuf:"bytes,3,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *WatchBlogsResponse) Reset()         { *m = WatchBlogsResponse{} }
+func (m *WatchBlogsResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchBlogsResponse) ProtoMessage()    {}
+func (*WatchBlogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{33}
+}
+
+func (m *WatchBlogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchBlogsResponse.Unmarshal(m, b)
+}
+func (m *WatchBlogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchBlogsResponse.Marshal(b, m, deterministic)
+}
+func (m *WatchBlogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchBlogsResponse.Merge(m, src)
+}
+func (m *WatchBlogsResponse) XXX_Size() int {
+	return xxx_messageInfo_WatchBlogsResponse.Size(m)
+}
+func (m *WatchBlogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchBlogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchBlogsResponse proto.InternalMessageInfo
+
+func (m *WatchBlogsResponse) GetEventType() WatchBlogsResponse_EventType {
+	if m != nil {
+		return m.EventType
+	}
+	return WatchBlogsResponse_EVENT_TYPE_UNSPECIFIED
+}
+
+func (m *WatchBlogsResponse) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+func (m *WatchBlogsResponse) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
+type ListBlogRequest struct {
+	// maximum number of blogs to return, the server picks a default when 0
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from a previous ListBlog call, empty for the first page
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// only list blogs written by this author
+	AuthorId string `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	// only list blogs whose title or content contains this text, ignoring case
+	Query string `protobuf:"bytes,4,opt,name=query,proto3" json:"query,omitempty"`
+	// "create_time" or "title", optionally followed by "asc" or "desc",
+	// defaults to "create_time asc"
+	OrderBy string `protobuf:"bytes,5,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// also list blogs that are in the trash. listing them needs author_id to
+	// be the caller, unless an admin calls
+	ShowDeleted bool `protobuf:"varint,6,opt,name=show_deleted,json=showDeleted,proto3" json:"show_deleted,omitempty"`
+	// only list blogs that have at least one of these tags
+	AnyTags []string `protobuf:"bytes,7,rep,name=any_tags,json=anyTags,proto3" json:"any_tags,omitempty"`
+	// only list blogs that have every one of these tags
+	AllTags []string `protobuf:"bytes,8,rep,name=all_tags,json=allTags,proto3" json:"all_tags,omitempty"`
+	// also return the content rendered as html in blog.content_html
+	RenderHtml bool `protobuf:"varint,9,opt,name=render_html,json=renderHtml,proto3" json:"render_html,omitempty"`
+	// only list blogs in these states, defaults to PUBLISHED. listing blogs
+	// in other states needs author_id to be the caller, unless an admin calls
+	States               []Blog_State `protobuf:"varint,10,rep,packed,name=states,proto3,enum=blog.Blog_State" json:"states,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ListBlogRequest) Reset()         { *m = ListBlogRequest{} }
+func (m *ListBlogRequest) String() string { return proto.CompactTextString(m) }
+func (*ListBlogRequest) ProtoMessage()    {}
+func (*ListBlogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{34}
+}
+
+func (m *ListBlogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListBlogRequest.Unmarshal(m, b)
+}
+func (m *ListBlogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListBlogRequest.Marshal(b, m, deterministic)
+}
+func (m *ListBlogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListBlogRequest.Merge(m, src)
+}
+func (m *ListBlogRequest) XXX_Size() int {
+	return xxx_messageInfo_ListBlogRequest.Size(m)
+}
+func (m *ListBlogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListBlogRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListBlogRequest proto.InternalMessageInfo
+
+func (m *ListBlogRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListBlogRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ListBlogRequest) GetAuthorId() string {
+	if m != nil {
+		return m.AuthorId
+	}
+	return ""
+}
+
+func (m *ListBlogRequest) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *ListBlogRequest) GetOrderBy() string {
+	if m != nil {
+		return m.OrderBy
+	}
+	return ""
+}
+
+func (m *ListBlogRequest) GetShowDeleted() bool {
+	if m != nil {
+		return m.ShowDeleted
+	}
+	return false
+}
+
+func (m *ListBlogRequest) GetAnyTags() []string {
+	if m != nil {
+		return m.AnyTags
+	}
+	return nil
+}
+
+func (m *ListBlogRequest) GetAllTags() []string {
+	if m != nil {
+		return m.AllTags
+	}
+	return nil
+}
+
+func (m *ListBlogRequest) GetRenderHtml() bool {
+	if m != nil {
+		return m.RenderHtml
+	}
+	return false
+}
+
+func (m *ListBlogRequest) GetStates() []Blog_State {
+	if m != nil {
+		return m.States
+	}
+	return nil
+}
+
+type ListBlogResponse struct {
+	Blog *Blog `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	// set on the last blog of a page when more blogs are available
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListBlogResponse) Reset()         { *m = ListBlogResponse{} }
+func (m *ListBlogResponse) String() string { return proto.CompactTextString(m) }
+func (*ListBlogResponse) ProtoMessage()    {}
+func (*ListBlogResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{35}
+}
+
+func (m *ListBlogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListBlogResponse.Unmarshal(m, b)
+}
+func (m *ListBlogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListBlogResponse.Marshal(b, m, deterministic)
+}
+func (m *ListBlogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListBlogResponse.Merge(m, src)
+}
+func (m *ListBlogResponse) XXX_Size() int {
+	return xxx_messageInfo_ListBlogResponse.Size(m)
+}
+func (m *ListBlogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListBlogResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListBlogResponse proto.InternalMessageInfo
+
+func (m *ListBlogResponse) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+func (m *ListBlogResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+// Comment is a reader's comment on a blog.
+type Comment struct {
+	Id       string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	BlogId   string `protobuf:"bytes,2,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	AuthorId string `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Content  string `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	// set by the server, values sent by clients are ignored
+	CreateTime           *timestamp.Timestamp `protobuf:"bytes,5,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime           *timestamp.Timestamp `protobuf:"bytes,6,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Comment) Reset()         { *m = Comment{} }
+func (m *Comment) String() string { return proto.CompactTextString(m) }
+func (*Comment) ProtoMessage()    {}
+func (*Comment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{36}
+}
+
+func (m *Comment) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Comment.Unmarshal(m, b)
+}
+func (m *Comment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Comment.Marshal(b, m, deterministic)
+}
+func (m *Comment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Comment.Merge(m, src)
+}
+func (m *Comment) XXX_Size() int {
+	return xxx_messageInfo_Comment.Size(m)
+}
+func (m *Comment) XXX_DiscardUnknown() {
+	xxx_messageInfo_Comment.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Comment proto.InternalMessageInfo
+
+func (m *Comment) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Comment) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *Comment) GetAuthorId() string {
+	if m != nil {
+		return m.AuthorId
+	}
+	return ""
+}
+
+func (m *Comment) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
+func (m *Comment) GetCreateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreateTime
+	}
+	return nil
+}
+
+func (m *Comment) GetUpdateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdateTime
+	}
+	return nil
+}
+
+type CreateCommentRequest struct {
+	Comment              *Comment `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateCommentRequest) Reset()         { *m = CreateCommentRequest{} }
+func (m *CreateCommentRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateCommentRequest) ProtoMessage()    {}
+func (*CreateCommentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{37}
+}
+
+func (m *CreateCommentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateCommentRequest.Unmarshal(m, b)
+}
+func (m *CreateCommentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateCommentRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateCommentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateCommentRequest.Merge(m, src)
+}
+func (m *CreateCommentRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateCommentRequest.Size(m)
+}
+func (m *CreateCommentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateCommentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateCommentRequest proto.InternalMessageInfo
+
+func (m *CreateCommentRequest) GetComment() *Comment {
+	if m != nil {
+		return m.Comment
+	}
+	return nil
+}
+
+type CreateCommentResponse struct {
+	Comment              *Comment `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateCommentResponse) Reset()         { *m = CreateCommentResponse{} }
+func (m *CreateCommentResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateCommentResponse) ProtoMessage()    {}
+func (*CreateCommentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{38}
+}
+
+func (m *CreateCommentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateCommentResponse.Unmarshal(m, b)
+}
+func (m *CreateCommentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateCommentResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateCommentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateCommentResponse.Merge(m, src)
+}
+func (m *CreateCommentResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateCommentResponse.Size(m)
+}
+func (m *CreateCommentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateCommentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateCommentResponse proto.InternalMessageInfo
+
+func (m *CreateCommentResponse) GetComment() *Comment {
+	if m != nil {
+		return m.Comment
+	}
+	return nil
+}
+
+type ListCommentsRequest struct {
+	BlogId string `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// maximum number of comments to return, the server picks a default when 0
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from a previous ListComments call, empty for the first page
+	PageToken            string   `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListCommentsRequest) Reset()         { *m = ListCommentsRequest{} }
+func (m *ListCommentsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListCommentsRequest) ProtoMessage()    {}
+func (*ListCommentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{39}
+}
+
+func (m *ListCommentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCommentsRequest.Unmarshal(m, b)
+}
+func (m *ListCommentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCommentsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListCommentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCommentsRequest.Merge(m, src)
+}
+func (m *ListCommentsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListCommentsRequest.Size(m)
+}
+func (m *ListCommentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCommentsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCommentsRequest proto.InternalMessageInfo
+
+func (m *ListCommentsRequest) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *ListCommentsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListCommentsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListCommentsResponse struct {
+	// oldest comment first
+	Comments []*Comment `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	// set when more comments are available
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListCommentsResponse) Reset()         { *m = ListCommentsResponse{} }
+func (m *ListCommentsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListCommentsResponse) ProtoMessage()    {}
+func (*ListCommentsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{40}
+}
+
+func (m *ListCommentsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCommentsResponse.Unmarshal(m, b)
+}
+func (m *ListCommentsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCommentsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListCommentsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCommentsResponse.Merge(m, src)
+}
+func (m *ListCommentsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListCommentsResponse.Size(m)
+}
+func (m *ListCommentsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCommentsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCommentsResponse proto.InternalMessageInfo
+
+func (m *ListCommentsResponse) GetComments() []*Comment {
+	if m != nil {
+		return m.Comments
+	}
+	return nil
+}
+
+func (m *ListCommentsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type UpdateCommentRequest struct {
+	// only the content of a comment can be changed
+	Comment              *Comment `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateCommentRequest) Reset()         { *m = UpdateCommentRequest{} }
+func (m *UpdateCommentRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateCommentRequest) ProtoMessage()    {}
+func (*UpdateCommentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{41}
+}
+
+func (m *UpdateCommentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateCommentRequest.Unmarshal(m, b)
+}
+func (m *UpdateCommentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateCommentRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateCommentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateCommentRequest.Merge(m, src)
+}
+func (m *UpdateCommentRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateCommentRequest.Size(m)
+}
+func (m *UpdateCommentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateCommentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateCommentRequest proto.InternalMessageInfo
+
+func (m *UpdateCommentRequest) GetComment() *Comment {
+	if m != nil {
+		return m.Comment
+	}
+	return nil
+}
+
+type UpdateCommentResponse struct {
+	Comment              *Comment `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateCommentResponse) Reset()         { *m = UpdateCommentResponse{} }
+func (m *UpdateCommentResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateCommentResponse) ProtoMessage()    {}
+func (*UpdateCommentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{42}
+}
+
+func (m *UpdateCommentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateCommentResponse.Unmarshal(m, b)
+}
+func (m *UpdateCommentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateCommentResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateCommentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateCommentResponse.Merge(m, src)
+}
+func (m *UpdateCommentResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateCommentResponse.Size(m)
+}
+func (m *UpdateCommentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateCommentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateCommentResponse proto.InternalMessageInfo
+
+func (m *UpdateCommentResponse) GetComment() *Comment {
+	if m != nil {
+		return m.Comment
+	}
+	return nil
+}
+
+type DeleteCommentRequest struct {
+	CommentId            string   `protobuf:"bytes,1,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteCommentRequest) Reset()         { *m = DeleteCommentRequest{} }
+func (m *DeleteCommentRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteCommentRequest) ProtoMessage()    {}
+func (*DeleteCommentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{43}
+}
+
+func (m *DeleteCommentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteCommentRequest.Unmarshal(m, b)
+}
+func (m *DeleteCommentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteCommentRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteCommentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteCommentRequest.Merge(m, src)
+}
+func (m *DeleteCommentRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteCommentRequest.Size(m)
+}
+func (m *DeleteCommentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteCommentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteCommentRequest proto.InternalMessageInfo
+
+func (m *DeleteCommentRequest) GetCommentId() string {
+	if m != nil {
+		return m.CommentId
+	}
+	return ""
+}
+
+type DeleteCommentResponse struct {
+	CommentId            string   `protobuf:"bytes,1,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteCommentResponse) Reset()         { *m = DeleteCommentResponse{} }
+func (m *DeleteCommentResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteCommentResponse) ProtoMessage()    {}
+func (*DeleteCommentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_140fdd8b592a3fc7, []int{44}
+}
+
+func (m *DeleteCommentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteCommentResponse.Unmarshal(m, b)
+}
+func (m *DeleteCommentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteCommentResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteCommentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteCommentResponse.Merge(m, src)
+}
+func (m *DeleteCommentResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteCommentResponse.Size(m)
+}
+func (m *DeleteCommentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteCommentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteCommentResponse proto.InternalMessageInfo
+
+func (m *DeleteCommentResponse) GetCommentId() string {
+	if m != nil {
+		return m.CommentId
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterEnum("blog.Blog_ContentFormat", Blog_ContentFormat_name, Blog_ContentFormat_value)
+	proto.RegisterEnum("blog.Blog_State", Blog_State_name, Blog_State_value)
+	proto.RegisterEnum("blog.WatchBlogsResponse_EventType", WatchBlogsResponse_EventType_name, WatchBlogsResponse_EventType_value)
+	proto.RegisterType((*Blog)(nil), "blog.Blog")
+	proto.RegisterType((*CreateBlogRequest)(nil), "blog.CreateBlogRequest")
+	proto.RegisterType((*CreateBlogResponse)(nil), "blog.CreateBlogResponse")
+	proto.RegisterType((*ReadBlogRequest)(nil), "blog.ReadBlogRequest")
+	proto.RegisterType((*ReadBlogResponse)(nil), "blog.ReadBlogResponse")
+	proto.RegisterType((*UpdateBlogRequest)(nil), "blog.UpdateBlogRequest")
+	proto.RegisterType((*UpdateBlogResponse)(nil), "blog.UpdateBlogResponse")
+	proto.RegisterType((*DeleteBlogResponse)(nil), "blog.DeleteBlogResponse")
+	proto.RegisterType((*DeleteBlogRequest)(nil), "blog.DeleteBlogRequest")
+	proto.RegisterType((*RestoreBlogRequest)(nil), "blog.RestoreBlogRequest")
+	proto.RegisterType((*RestoreBlogResponse)(nil), "blog.RestoreBlogResponse")
+	proto.RegisterType((*PurgeBlogRequest)(nil), "blog.PurgeBlogRequest")
+	proto.RegisterType((*PurgeBlogResponse)(nil), "blog.PurgeBlogResponse")
+	proto.RegisterType((*BlogRevision)(nil), "blog.BlogRevision")
+	proto.RegisterType((*ListBlogRevisionsRequest)(nil), "blog.ListBlogRevisionsRequest")
+	proto.RegisterType((*ListBlogRevisionsResponse)(nil), "blog.ListBlogRevisionsResponse")
+	proto.RegisterType((*GetBlogRevisionRequest)(nil), "blog.GetBlogRevisionRequest")
+	proto.RegisterType((*GetBlogRevisionResponse)(nil), "blog.GetBlogRevisionResponse")
+	proto.RegisterType((*RollbackBlogRequest)(nil), "blog.RollbackBlogRequest")
+	proto.RegisterType((*RollbackBlogResponse)(nil), "blog.RollbackBlogResponse")
+	proto.RegisterType((*BatchGetBlogsRequest)(nil), "blog.BatchGetBlogsRequest")
+	proto.RegisterType((*BatchGetBlogsResponse)(nil), "blog.BatchGetBlogsResponse")
+	proto.RegisterType((*BulkCreateBlogsRequest)(nil), "blog.BulkCreateBlogsRequest")
+	proto.RegisterType((*BulkCreateBlogsResponse)(nil), "blog.BulkCreateBlogsResponse")
+	proto.RegisterType((*BulkCreateBlogsResponse_Result)(nil), "blog.BulkCreateBlogsResponse.Result")
+	proto.RegisterType((*PublishBlogRequest)(nil), "blog.PublishBlogRequest")
+	proto.RegisterType((*PublishBlogResponse)(nil), "blog.PublishBlogResponse")
+	proto.RegisterType((*UnpublishBlogRequest)(nil), "blog.UnpublishBlogRequest")
+	proto.RegisterType((*UnpublishBlogResponse)(nil), "blog.UnpublishBlogResponse")
+	proto.RegisterType((*ListTagsRequest)(nil), "blog.ListTagsRequest")
+	proto.RegisterType((*ListTagsResponse)(nil), "blog.ListTagsResponse")
+	proto.RegisterType((*ListTagsResponse_Tag)(nil), "blog.ListTagsResponse.Tag")
+	proto.RegisterType((*SearchBlogsRequest)(nil), "blog.SearchBlogsRequest")
+	proto.RegisterType((*SearchBlogsResponse)(nil), "blog.SearchBlogsResponse")
+	proto.RegisterType((*SearchBlogsResponse_Highlight)(nil), "blog.SearchBlogsResponse.Highlight")
+	proto.RegisterType((*SearchBlogsResponse_Result)(nil), "blog.SearchBlogsResponse.Result")
+	proto.RegisterType((*WatchBlogsRequest)(nil), "blog.WatchBlogsRequest")
+	proto.RegisterType((*WatchBlogsResponse)(nil), "blog.WatchBlogsResponse")
+	proto.RegisterType((*ListBlogRequest)(nil), "blog.ListBlogRequest")
+	proto.RegisterType((*ListBlogResponse)(nil), "blog.ListBlogResponse")
+	proto.RegisterType((*Comment)(nil), "blog.Comment")
+	proto.RegisterType((*CreateCommentRequest)(nil), "blog.CreateCommentRequest")
+	proto.RegisterType((*CreateCommentResponse)(nil), "blog.CreateCommentResponse")
+	proto.RegisterType((*ListCommentsRequest)(nil), "blog.ListCommentsRequest")
+	proto.RegisterType((*ListCommentsResponse)(nil), "blog.ListCommentsResponse")
+	proto.RegisterType((*UpdateCommentRequest)(nil), "blog.UpdateCommentRequest")
+	proto.RegisterType((*UpdateCommentResponse)(nil), "blog.UpdateCommentResponse")
+	proto.RegisterType((*DeleteCommentRequest)(nil), "blog.DeleteCommentRequest")
+	proto.RegisterType((*DeleteCommentResponse)(nil), "blog.DeleteCommentResponse")
+}
+
+func init() {
+	proto.RegisterFile("pb/blog.proto", fileDescriptor_140fdd8b592a3fc7)
+}
+
+var fileDescriptor_140fdd8b592a3fc7 = []byte{
+	// 2011 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x18, 0x5d, 0x73, 0x22, 0xc7,
+	0x31, 0x0b, 0x42, 0xb0, 0x8d, 0x90, 0x60, 0x84, 0xa4, 0xd5, 0x2a, 0xe7, 0xc3, 0xeb, 0xc4, 0x51,
+	0x2a, 0x09, 0xe7, 0xc8, 0xb9, 0xcb, 0x87, 0x93, 0x28, 0x12, 0x20, 0x8b, 0xb2, 0x4e, 0x47, 0x2d,
+	0xc8, 0x8e, 0xaf, 0x2a, 0x45, 0xad, 0xd8, 0x39, 0xd8, 0x68, 0xd9, 0xc5, 0xec, 0x22, 0x9f, 0xee,
+	0x9f, 0xe4, 0xc1, 0x4f, 0xf9, 0x0d, 0x79, 0xce, 0x63, 0xde, 0xf2, 0x07, 0xf2, 0x98, 0xff, 0x90,
+	0xe7, 0xd4, 0x7c, 0xb1, 0x9f, 0x1c, 0x50, 0x57, 0x57, 0xe5, 0xb7, 0x99, 0xfe, 0x9a, 0xee, 0x9e,
+	0xee, 0x9e, 0xee, 0x81, 0xd2, 0xe4, 0xf6, 0xc9, 0xad, 0xed, 0x0e, 0xeb, 0x93, 0xa9, 0xeb, 0xbb,
+	0x68, 0x83, 0xac, 0xd5, 0xda, 0xd0, 0x75, 0x87, 0x36, 0x7e, 0x42, 0x61, 0xb7, 0xb3, 0x57, 0x4f,
+	0x5e, 0x59, 0xd8, 0x36, 0xfb, 0x63, 0xc3, 0xbb, 0x63, 0x74, 0xea, 0xe3, 0x38, 0x85, 0x6f, 0x8d,
+	0xb1, 0xe7, 0x1b, 0xe3, 0x09, 0x23, 0xd0, 0xfe, 0x93, 0x83, 0x8d, 0x73, 0xdb, 0x1d, 0xa2, 0x6d,
+	0xc8, 0x58, 0xa6, 0x22, 0xd5, 0xa4, 0x63, 0x59, 0xcf, 0x58, 0x26, 0x3a, 0x02, 0xd9, 0x98, 0xf9,
+	0x23, 0x77, 0xda, 0xb7, 0x4c, 0x25, 0x43, 0xc1, 0x05, 0x06, 0x68, 0x9b, 0xa8, 0x0a, 0x39, 0xdf,
+	0xf2, 0x6d, 0xac, 0x64, 0x29, 0x82, 0x6d, 0x90, 0x02, 0xf9, 0x81, 0xeb, 0xf8, 0xd8, 0xf1, 0x95,
+	0x0d, 0x0a, 0x17, 0x5b, 0xf4, 0x19, 0x14, 0x07, 0x53, 0x6c, 0xf8, 0xb8, 0x4f, 0xce, 0x57, 0x72,
+	0x35, 0xe9, 0xb8, 0x78, 0xa2, 0xd6, 0x99, 0x72, 0x75, 0xa1, 0x5c, 0xbd, 0x27, 0x94, 0xd3, 0x81,
+	0x91, 0x13, 0x00, 0x61, 0x9e, 0x4d, 0xcc, 0x39, 0xf3, 0xe6, 0x72, 0x66, 0x46, 0x4e, 0x99, 0x55,
+	0x28, 0x4c, 0xf1, 0xbd, 0xe5, 0x59, 0xae, 0xa3, 0xe4, 0x6b, 0xd2, 0x71, 0x56, 0x9f, 0xef, 0x89,
+	0x60, 0x13, 0xdb, 0x58, 0x08, 0x2e, 0x2c, 0x17, 0xcc, 0xc8, 0xa9, 0x60, 0x04, 0x1b, 0xbe, 0x31,
+	0xf4, 0x14, 0xb9, 0x96, 0x3d, 0x96, 0x75, 0xba, 0x46, 0x1f, 0x41, 0x69, 0xe0, 0x8e, 0xc7, 0xd8,
+	0xf1, 0xfb, 0x03, 0x77, 0xe6, 0xf8, 0x0a, 0xd0, 0x13, 0xb7, 0x38, 0xb0, 0x41, 0x60, 0xe8, 0x14,
+	0xb6, 0xb9, 0x5b, 0xfa, 0xaf, 0xdc, 0xe9, 0xd8, 0xf0, 0x95, 0x62, 0x4d, 0x3a, 0xde, 0x3e, 0x51,
+	0xea, 0xf4, 0x7e, 0xc9, 0x65, 0xd4, 0x1b, 0x8c, 0xe0, 0x82, 0xe2, 0xf5, 0xd2, 0x20, 0xbc, 0x45,
+	0x1f, 0xc2, 0x96, 0x10, 0x30, 0xf2, 0xc7, 0xb6, 0xb2, 0x45, 0x7d, 0x5d, 0xe4, 0xb0, 0x4b, 0x7f,
+	0x6c, 0xa3, 0x8f, 0x21, 0xe7, 0xf9, 0x86, 0x8f, 0x95, 0x12, 0x15, 0x5d, 0x0e, 0x89, 0xee, 0x12,
+	0xb8, 0xce, 0xd0, 0xe8, 0x0f, 0xb0, 0x35, 0x99, 0xdd, 0xda, 0x96, 0x37, 0x62, 0x2e, 0xd8, 0x5e,
+	0xea, 0x82, 0x22, 0xa7, 0x27, 0x10, 0xed, 0x12, 0x4a, 0x11, 0x4d, 0xd1, 0x07, 0xa0, 0x36, 0x5e,
+	0x5c, 0xf7, 0x5a, 0xd7, 0xbd, 0xfe, 0xc5, 0x0b, 0xfd, 0xf9, 0x59, 0xaf, 0x7f, 0x73, 0xdd, 0xed,
+	0xb4, 0x1a, 0xed, 0x8b, 0x76, 0xab, 0x59, 0xfe, 0x01, 0x92, 0x21, 0xd7, 0xb9, 0x3a, 0x6b, 0x5f,
+	0x97, 0x25, 0xb4, 0x05, 0x85, 0xe7, 0x67, 0xfa, 0x17, 0xcd, 0x17, 0x5f, 0x5d, 0x97, 0x33, 0xda,
+	0x0d, 0xe4, 0xa8, 0x62, 0x68, 0x0f, 0x2a, 0xdd, 0xde, 0x59, 0xaf, 0x95, 0x64, 0x6c, 0xea, 0x67,
+	0x17, 0xbd, 0xb2, 0x84, 0x4a, 0x20, 0x77, 0x1b, 0x97, 0xad, 0xe6, 0xcd, 0x55, 0xab, 0x59, 0xce,
+	0x90, 0x6d, 0xe7, 0xe6, 0xfc, 0xaa, 0xdd, 0xbd, 0x6c, 0x35, 0xcb, 0x59, 0x22, 0xf6, 0x4c, 0x6f,
+	0x5c, 0xb6, 0xbf, 0x6c, 0x35, 0xcb, 0x1b, 0x9a, 0x0e, 0x95, 0x06, 0x0d, 0x24, 0x62, 0xba, 0x8e,
+	0xbf, 0x99, 0x61, 0x8f, 0x28, 0x49, 0xb3, 0x87, 0xc6, 0x7a, 0xf1, 0x04, 0x02, 0xdf, 0xe8, 0x14,
+	0x8e, 0x1e, 0x01, 0x4c, 0x19, 0x69, 0x10, 0xfa, 0x32, 0x87, 0xb4, 0x4d, 0xed, 0x57, 0x80, 0xc2,
+	0x32, 0xbd, 0x89, 0xeb, 0x78, 0x78, 0x99, 0x50, 0xed, 0x0b, 0xd8, 0xd1, 0xb1, 0x61, 0x86, 0xf5,
+	0x38, 0x80, 0x3c, 0x41, 0xf5, 0xe7, 0x69, 0xb7, 0x49, 0xb6, 0x6d, 0x13, 0x3d, 0x86, 0xe2, 0x14,
+	0x3b, 0x26, 0x9e, 0xb2, 0xfb, 0x25, 0x1a, 0x14, 0x74, 0x60, 0x20, 0x72, 0xbd, 0xda, 0x09, 0x94,
+	0x03, 0x61, 0x2b, 0x2a, 0xf0, 0x9d, 0x04, 0x95, 0x1b, 0x9a, 0x17, 0xeb, 0xf8, 0xe2, 0x67, 0x50,
+	0xc1, 0xaf, 0x27, 0x78, 0xe0, 0x63, 0xb3, 0x3f, 0xcf, 0xa3, 0x0c, 0x8d, 0xea, 0xb2, 0x40, 0xe8,
+	0xa1, 0x7c, 0xe2, 0x89, 0x4a, 0x2a, 0x90, 0x92, 0x5d, 0x10, 0x4c, 0x17, 0xa4, 0x48, 0x3d, 0x37,
+	0xbc, 0x3b, 0x91, 0xa8, 0x64, 0x4d, 0xdc, 0x1a, 0x56, 0x6f, 0x45, 0xab, 0x7e, 0x01, 0xa8, 0x49,
+	0x73, 0x32, 0xc2, 0xb5, 0xc8, 0xb3, 0xda, 0xd7, 0x50, 0x09, 0x93, 0x2f, 0xb9, 0x87, 0x75, 0x8c,
+	0xd7, 0x5e, 0x02, 0xd2, 0xb1, 0xe7, 0xbb, 0xd3, 0xf7, 0x20, 0xfb, 0x29, 0xec, 0x46, 0x64, 0xaf,
+	0xe8, 0x9c, 0x3f, 0x43, 0xb9, 0x33, 0x9b, 0x0e, 0xdf, 0x83, 0x42, 0x3f, 0x87, 0x4a, 0x48, 0xf2,
+	0x32, 0xaf, 0xff, 0x4b, 0x82, 0x2d, 0x46, 0xc9, 0x03, 0x65, 0xa1, 0x12, 0xe1, 0x6a, 0x9d, 0x89,
+	0x55, 0x6b, 0x61, 0x6d, 0x76, 0x41, 0xa8, 0xc6, 0xde, 0x98, 0x8d, 0xb5, 0xde, 0x98, 0x1f, 0xc3,
+	0xf6, 0x60, 0x64, 0x38, 0x43, 0x6c, 0xf6, 0xe9, 0x1b, 0xea, 0x29, 0x39, 0x5a, 0xd7, 0x4b, 0x1c,
+	0x4a, 0x63, 0xd6, 0xd3, 0x5c, 0x50, 0xae, 0x2c, 0xcf, 0x0f, 0x1b, 0xe3, 0x2d, 0xf5, 0xec, 0x11,
+	0xc8, 0x13, 0x63, 0x88, 0xfb, 0x9e, 0xf5, 0x06, 0x53, 0xab, 0x72, 0x7a, 0x81, 0x00, 0xba, 0xd6,
+	0x1b, 0x4c, 0x8a, 0x0d, 0x45, 0xfa, 0xee, 0x1d, 0x76, 0xf8, 0x73, 0x4a, 0xc9, 0x7b, 0x04, 0xa0,
+	0xcd, 0xe0, 0x30, 0xe5, 0x40, 0xee, 0xf0, 0x4f, 0x40, 0x16, 0xde, 0xf1, 0x14, 0xa9, 0x96, 0x3d,
+	0x2e, 0x9e, 0xa0, 0x90, 0x5b, 0x38, 0x4a, 0x0f, 0x88, 0xd0, 0xc7, 0xb0, 0xe3, 0xe0, 0xd7, 0x7e,
+	0x3f, 0x74, 0x24, 0xab, 0x6f, 0x25, 0x02, 0xee, 0xcc, 0x8f, 0x7d, 0x0e, 0xfb, 0x9f, 0xe3, 0xc8,
+	0xa9, 0x4b, 0xad, 0x7c, 0xcb, 0xd5, 0x69, 0x6d, 0x38, 0x48, 0x88, 0xe3, 0x36, 0xd4, 0x43, 0x6c,
+	0x2c, 0x8e, 0xd3, 0x4c, 0x08, 0x44, 0x7d, 0x0b, 0xbb, 0xba, 0x6b, 0xdb, 0xb7, 0xc6, 0xe0, 0x6e,
+	0xa5, 0xb0, 0x7e, 0x5b, 0x44, 0xa5, 0x86, 0x7c, 0x76, 0x41, 0xc8, 0x3f, 0x83, 0x6a, 0xf4, 0xe0,
+	0x15, 0x93, 0xf0, 0x97, 0x50, 0x3d, 0x37, 0xfc, 0xc1, 0x88, 0x3b, 0x60, 0x1e, 0x2e, 0x87, 0x50,
+	0xe0, 0x1a, 0xb3, 0xbb, 0x93, 0xf5, 0x3c, 0x53, 0xd9, 0xd3, 0xfe, 0x02, 0x7b, 0x31, 0x16, 0x7e,
+	0x56, 0x0d, 0x72, 0x84, 0x46, 0x5c, 0x76, 0xf8, 0x30, 0x86, 0x40, 0x1a, 0x94, 0x1c, 0x97, 0x34,
+	0x16, 0x33, 0xc7, 0xa4, 0xa2, 0x33, 0x54, 0x74, 0xd1, 0x71, 0xfd, 0x0b, 0x02, 0x23, 0xe2, 0x7f,
+	0x03, 0xfb, 0xe7, 0x33, 0xfb, 0x2e, 0x78, 0xc4, 0xbc, 0x15, 0x5f, 0x03, 0x92, 0xc8, 0x07, 0x09,
+	0x56, 0xae, 0xdb, 0x1f, 0x21, 0x3f, 0xc5, 0xde, 0xcc, 0xf6, 0x85, 0x76, 0x3f, 0xe2, 0xec, 0xe9,
+	0xf4, 0x75, 0x9d, 0x12, 0xeb, 0x82, 0x49, 0xb5, 0x61, 0x93, 0x81, 0x48, 0x73, 0x69, 0x39, 0x26,
+	0x7e, 0x4d, 0xd5, 0xc8, 0xe9, 0x6c, 0x33, 0xd7, 0x2d, 0xb3, 0x20, 0xfd, 0x11, 0x6c, 0x0c, 0x5c,
+	0x93, 0x75, 0xa4, 0x39, 0x9d, 0xae, 0x49, 0x43, 0x3a, 0xc6, 0x9e, 0x67, 0x0c, 0xb1, 0x68, 0x48,
+	0xf9, 0x56, 0xfb, 0x9b, 0x04, 0xa8, 0xc3, 0x3a, 0x99, 0x95, 0xc2, 0x28, 0xde, 0x28, 0x65, 0xd6,
+	0x6a, 0x94, 0xd6, 0x8b, 0xb4, 0xa7, 0xb0, 0x1b, 0x51, 0x6d, 0xc5, 0x40, 0xbb, 0x87, 0xea, 0x8d,
+	0x33, 0x59, 0xc3, 0x26, 0x05, 0xf2, 0xc6, 0x74, 0x30, 0xb2, 0xee, 0x31, 0x6f, 0x31, 0xc4, 0x76,
+	0x3d, 0x75, 0x7f, 0x0d, 0x7b, 0xb1, 0x73, 0x57, 0x54, 0xb8, 0x02, 0x3b, 0xa4, 0xb6, 0xf5, 0x8c,
+	0x79, 0x00, 0x6a, 0x6f, 0xa0, 0x1c, 0x80, 0xe6, 0x15, 0x82, 0x35, 0xda, 0x2c, 0xaa, 0x54, 0x26,
+	0x26, 0x4e, 0x55, 0xef, 0x19, 0x43, 0xd6, 0x84, 0xab, 0xcf, 0x20, 0xdb, 0x33, 0x86, 0xa8, 0x0c,
+	0x59, 0xdf, 0x18, 0x72, 0x93, 0xc9, 0x92, 0x94, 0x5a, 0xea, 0x08, 0xd6, 0x9a, 0xb3, 0x62, 0x20,
+	0x13, 0x08, 0xed, 0xcb, 0xb5, 0x57, 0x80, 0xba, 0x98, 0x78, 0x20, 0x92, 0x12, 0x55, 0xc8, 0x7d,
+	0x33, 0xc3, 0xd3, 0x07, 0x2e, 0x88, 0x6d, 0xde, 0xa9, 0xa4, 0xff, 0x3b, 0x03, 0xbb, 0x91, 0x83,
+	0xb8, 0x9d, 0xbf, 0x8b, 0x27, 0x50, 0x8d, 0x99, 0x9a, 0x42, 0x1b, 0x4f, 0x9e, 0x55, 0xeb, 0xba,
+	0xfa, 0x29, 0xc8, 0x97, 0xd6, 0x70, 0x64, 0x5b, 0xc3, 0x11, 0x35, 0xcd, 0xf3, 0x8d, 0xa9, 0x2f,
+	0xf2, 0x8c, 0x6e, 0x88, 0xdf, 0xb0, 0x63, 0x72, 0xa3, 0xc8, 0x52, 0xfd, 0x4e, 0x9a, 0xa7, 0xe6,
+	0xb2, 0x76, 0x91, 0x88, 0x1c, 0xb8, 0x53, 0xe6, 0x13, 0x49, 0x67, 0x1b, 0x12, 0x68, 0x9e, 0x63,
+	0x4d, 0x26, 0xd8, 0xe7, 0xde, 0x10, 0x5b, 0xd4, 0x00, 0x18, 0x09, 0x7d, 0x3c, 0x65, 0x83, 0x9a,
+	0xfd, 0xd1, 0x62, 0xb3, 0xe7, 0xba, 0xeb, 0x21, 0x36, 0xad, 0x0b, 0x95, 0xaf, 0x0c, 0x7f, 0x4e,
+	0xcb, 0xee, 0x2d, 0x32, 0xbe, 0x4a, 0xb1, 0xf1, 0xf5, 0x43, 0xd8, 0x22, 0x9e, 0x1b, 0x47, 0x7d,
+	0x55, 0x64, 0x30, 0x76, 0x4b, 0xff, 0x93, 0x00, 0x85, 0xa5, 0xf2, 0x4b, 0x3a, 0x03, 0xc0, 0xf7,
+	0x64, 0xf2, 0xf2, 0x1f, 0x26, 0x98, 0xca, 0xdd, 0x3e, 0xd1, 0x98, 0xc2, 0x49, 0xea, 0x7a, 0x8b,
+	0x90, 0xf6, 0x1e, 0x26, 0x58, 0x97, 0xb1, 0x58, 0x2e, 0x2d, 0x64, 0x71, 0xe5, 0xb2, 0x49, 0xe5,
+	0x5e, 0x82, 0x3c, 0x17, 0x8d, 0x54, 0xd8, 0x6f, 0x7d, 0x49, 0x26, 0xae, 0xde, 0xd7, 0x9d, 0xf8,
+	0xd8, 0x54, 0x84, 0x7c, 0x43, 0x6f, 0x9d, 0xf5, 0x5a, 0xcd, 0xb2, 0x44, 0x36, 0x37, 0x9d, 0x26,
+	0xdd, 0x64, 0xc8, 0xa6, 0xd9, 0xba, 0x6a, 0xf5, 0xe8, 0xd0, 0x04, 0xb0, 0xd9, 0xb9, 0xd1, 0x3f,
+	0xa7, 0x23, 0xd3, 0x3f, 0x33, 0x2c, 0x2d, 0xc3, 0x25, 0x24, 0x12, 0xee, 0xd2, 0x5b, 0xc3, 0x3d,
+	0x13, 0x0b, 0xf7, 0xe8, 0x45, 0x64, 0x93, 0xff, 0x08, 0x2c, 0xbb, 0x36, 0xc2, 0xd9, 0x75, 0x08,
+	0x05, 0x77, 0x4a, 0xc6, 0x9f, 0xdb, 0x07, 0xfa, 0x55, 0x20, 0xeb, 0x79, 0xba, 0x3f, 0x7f, 0x20,
+	0xce, 0xf1, 0x46, 0xee, 0xb7, 0x7d, 0x36, 0x88, 0x9b, 0xf4, 0x33, 0xa0, 0xa0, 0x17, 0x09, 0x8c,
+	0x35, 0xf6, 0x26, 0xe1, 0x36, 0x9c, 0x87, 0x3e, 0xad, 0x19, 0x79, 0xf6, 0xb0, 0x1a, 0xce, 0x03,
+	0x29, 0x16, 0x14, 0x65, 0xdb, 0x0c, 0x55, 0xe0, 0x28, 0xdb, 0xa6, 0xa8, 0xd8, 0xcc, 0x25, 0xc7,
+	0x67, 0x2e, 0x74, 0x0c, 0x9b, 0x74, 0x66, 0xf6, 0x14, 0xa8, 0x65, 0x53, 0x67, 0x6a, 0x8e, 0xd7,
+	0x5e, 0xb2, 0x22, 0xb6, 0x4e, 0x2d, 0x5c, 0xb9, 0x31, 0xfb, 0xaf, 0x04, 0xf9, 0x06, 0xfb, 0x4d,
+	0x48, 0xfc, 0xd8, 0x84, 0x0a, 0x7d, 0x26, 0xde, 0x80, 0x2e, 0xbe, 0x82, 0xef, 0xe1, 0xa7, 0x8d,
+	0x76, 0x0a, 0x55, 0xd6, 0x32, 0x70, 0x53, 0x45, 0x1c, 0xfe, 0x84, 0xe8, 0x4a, 0x21, 0xdc, 0x91,
+	0x25, 0xe6, 0x48, 0x41, 0x26, 0xb0, 0xda, 0x9f, 0x60, 0x2f, 0x26, 0x80, 0xdf, 0xc3, 0xca, 0x12,
+	0xfe, 0x0a, 0xbb, 0xe4, 0x12, 0x39, 0xfc, 0xfd, 0x36, 0xf9, 0x16, 0x54, 0xa3, 0x67, 0x71, 0x65,
+	0x7f, 0x0a, 0x05, 0xae, 0x8e, 0x78, 0x12, 0x62, 0xda, 0xce, 0xd1, 0x2b, 0xc7, 0xcf, 0x29, 0x54,
+	0xd9, 0x94, 0xfd, 0x0e, 0x9e, 0x8d, 0x09, 0x58, 0xd7, 0xb3, 0x4f, 0xa1, 0xca, 0x52, 0x35, 0xa6,
+	0xc2, 0x23, 0x00, 0x4e, 0x12, 0x78, 0x57, 0xe6, 0x90, 0xb6, 0xa9, 0x3d, 0x83, 0xbd, 0x18, 0x1b,
+	0x3f, 0xf8, 0xed, 0x7c, 0x27, 0x7f, 0x97, 0xa1, 0x48, 0x12, 0xad, 0x8b, 0xa7, 0xf7, 0xd6, 0x00,
+	0xa3, 0x53, 0x80, 0xa0, 0x1d, 0x45, 0x07, 0x5c, 0xc9, 0xf8, 0x27, 0x91, 0xaa, 0x24, 0x11, 0xfc,
+	0xbc, 0xdf, 0x42, 0x41, 0x7c, 0xbe, 0xa0, 0x3d, 0x46, 0x15, 0xfb, 0xd9, 0x51, 0xf7, 0xe3, 0x60,
+	0xce, 0x7a, 0x0a, 0x10, 0xfc, 0x71, 0x88, 0xb3, 0x13, 0x9f, 0x32, 0xaa, 0x92, 0x44, 0x04, 0x02,
+	0x82, 0xff, 0x0b, 0x21, 0x20, 0xf1, 0xa3, 0xa1, 0x2a, 0x49, 0x04, 0x17, 0xf0, 0x19, 0x14, 0x44,
+	0x6d, 0x12, 0xca, 0xc7, 0x8a, 0xbd, 0xba, 0x1f, 0x07, 0x33, 0xd6, 0x4f, 0x24, 0x74, 0x0e, 0xc5,
+	0xd0, 0x37, 0x04, 0x52, 0x84, 0x95, 0xf1, 0x5f, 0x0f, 0xf5, 0x30, 0x05, 0xc3, 0x15, 0xf8, 0x3d,
+	0xc8, 0xf3, 0x9f, 0x03, 0xc4, 0x8f, 0x8a, 0x7f, 0x52, 0xa8, 0x07, 0x09, 0x38, 0xe7, 0xee, 0x41,
+	0x25, 0x31, 0x0e, 0xa3, 0x0f, 0xe2, 0x0a, 0x47, 0x07, 0x73, 0xf5, 0xf1, 0x42, 0x3c, 0x97, 0x7a,
+	0x0d, 0x3b, 0xb1, 0xf1, 0x14, 0xfd, 0x90, 0xf1, 0xa4, 0x0f, 0xc1, 0xea, 0xa3, 0x05, 0x58, 0x2e,
+	0xaf, 0x05, 0x5b, 0xe1, 0x51, 0x11, 0x09, 0x77, 0x24, 0xe7, 0x56, 0x55, 0x4d, 0x43, 0x71, 0x31,
+	0x97, 0x50, 0x8a, 0x8c, 0x81, 0x88, 0x13, 0xa7, 0x8d, 0x93, 0xea, 0x51, 0x2a, 0x8e, 0x4b, 0xea,
+	0xc0, 0x4e, 0x6c, 0x0c, 0x13, 0x06, 0xa6, 0x0f, 0x82, 0xea, 0xa3, 0x05, 0x58, 0x26, 0xef, 0x98,
+	0x86, 0x42, 0x68, 0x46, 0x11, 0xa1, 0x90, 0x9c, 0xa8, 0xd4, 0xc3, 0x14, 0x4c, 0x60, 0x5f, 0x64,
+	0x70, 0x10, 0xf6, 0xa5, 0x4d, 0x31, 0xea, 0x51, 0x2a, 0x2e, 0x48, 0x49, 0x31, 0x10, 0x84, 0xa3,
+	0x3a, 0x34, 0x59, 0xa8, 0xfb, 0x71, 0x30, 0x67, 0x3d, 0x87, 0x62, 0xa8, 0xd3, 0x14, 0x86, 0x24,
+	0x07, 0x01, 0xf5, 0x30, 0x05, 0x13, 0x34, 0x85, 0x41, 0xf3, 0x27, 0xb2, 0x32, 0xd1, 0x92, 0xaa,
+	0x4a, 0x12, 0x21, 0x52, 0xeb, 0xe4, 0x1f, 0x19, 0xd8, 0xe6, 0x85, 0x4d, 0x14, 0x2a, 0xf2, 0xb9,
+	0x1e, 0x7e, 0xc3, 0x84, 0x7b, 0xd2, 0x5e, 0x46, 0xf5, 0x28, 0x15, 0x17, 0xc4, 0x63, 0xf8, 0x7d,
+	0x11, 0xf1, 0x98, 0xf2, 0xbe, 0xa9, 0x6a, 0x1a, 0x2a, 0x74, 0x5f, 0x13, 0x33, 0xa9, 0x50, 0xda,
+	0x83, 0xa2, 0x1e, 0xa5, 0xe2, 0x02, 0x49, 0x91, 0x5a, 0x2e, 0x24, 0xa5, 0xbd, 0x0b, 0xea, 0x51,
+	0x2a, 0x8e, 0x49, 0x3a, 0x2f, 0xbc, 0xa4, 0x0f, 0xf0, 0xe4, 0xf6, 0x76, 0x93, 0xf6, 0x14, 0x9f,
+	0xfe, 0x7f, 0x00, 0xb9, 0x7d, 0x36, 0xfe, 0x29, 0x1b, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// BlogServiceClient is the client API for BlogService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type BlogServiceClient interface {
+	CreateBlog(ctx context.Context, in *CreateBlogRequest, opts ...grpc.CallOption) (*CreateBlogResponse, error)
+	ReadBlog(ctx context.Context, in *ReadBlogRequest, opts ...grpc.CallOption) (*ReadBlogResponse, error)
+	UpdateBlog(ctx context.Context, in *UpdateBlogRequest, opts ...grpc.CallOption) (*UpdateBlogResponse, error)
+	// moves the blog to the trash, return NOT_FOUND if not found
+	DeleteBlog(ctx context.Context, in *DeleteBlogRequest, opts ...grpc.CallOption) (*DeleteBlogResponse, error)
+	ListBlog(ctx context.Context, in *ListBlogRequest, opts ...grpc.CallOption) (BlogService_ListBlogClient, error)
+	// takes the blog out of the trash, return FAILED_PRECONDITION if it is not in the trash
+	RestoreBlog(ctx context.Context, in *RestoreBlogRequest, opts ...grpc.CallOption) (*RestoreBlogResponse, error)
+	// removes a blog in the trash for good, return FAILED_PRECONDITION if it is not in the trash
+	PurgeBlog(ctx context.Context, in *PurgeBlogRequest, opts ...grpc.CallOption) (*PurgeBlogResponse, error)
+	// callers that cannot change the blog only get the revisions in which it
+	// was published and not in the trash
+	ListBlogRevisions(ctx context.Context, in *ListBlogRevisionsRequest, opts ...grpc.CallOption) (*ListBlogRevisionsResponse, error)
+	GetBlogRevision(ctx context.Context, in *GetBlogRevisionRequest, opts ...grpc.CallOption) (*GetBlogRevisionResponse, error)
+	// writes an older revision back as a new revision, return NOT_FOUND if not found
+	RollbackBlog(ctx context.Context, in *RollbackBlogRequest, opts ...grpc.CallOption) (*RollbackBlogResponse, error)
+	BatchGetBlogs(ctx context.Context, in *BatchGetBlogsRequest, opts ...grpc.CallOption) (*BatchGetBlogsResponse, error)
+	// creates every blog sent, a blog that cannot be created does not stop
+	// the others from being created, return RESOURCE_EXHAUSTED if more than
+	// 10000 blogs are sent, the blogs sent before may have been created then
+	BulkCreateBlogs(ctx context.Context, opts ...grpc.CallOption) (BlogService_BulkCreateBlogsClient, error)
+	// publishes the blog now or schedules it, return FAILED_PRECONDITION if it
+	// is already published
+	PublishBlog(ctx context.Context, in *PublishBlogRequest, opts ...grpc.CallOption) (*PublishBlogResponse, error)
+	// takes the blog back to DRAFT or ARCHIVED, return FAILED_PRECONDITION if
+	// it is already there
+	UnpublishBlog(ctx context.Context, in *UnpublishBlogRequest, opts ...grpc.CallOption) (*UnpublishBlogResponse, error)
+	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error)
+	SearchBlogs(ctx context.Context, in *SearchBlogsRequest, opts ...grpc.CallOption) (*SearchBlogsResponse, error)
+	// streams blog changes as they happen, return FAILED_PRECONDITION if the
+	// resume token expired, ABORTED if the client cannot keep up and
+	// UNAVAILABLE if the server shuts down. changes to blogs the caller
+	// cannot see are left out
+	WatchBlogs(ctx context.Context, in *WatchBlogsRequest, opts ...grpc.CallOption) (BlogService_WatchBlogsClient, error)
+}
+
+type blogServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBlogServiceClient(cc grpc.ClientConnInterface) BlogServiceClient {
+	return &blogServiceClient{cc}
+}
+
+func (c *blogServiceClient) CreateBlog(ctx context.Context, in *CreateBlogRequest, opts ...grpc.CallOption) (*CreateBlogResponse, error) {
+	out := new(CreateBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/CreateBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) ReadBlog(ctx context.Context, in *ReadBlogRequest, opts ...grpc.CallOption) (*ReadBlogResponse, error) {
+	out := new(ReadBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/ReadBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) UpdateBlog(ctx context.Context, in *UpdateBlogRequest, opts ...grpc.CallOption) (*UpdateBlogResponse, error) {
+	out := new(UpdateBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/UpdateBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) DeleteBlog(ctx context.Context, in *DeleteBlogRequest, opts ...grpc.CallOption) (*DeleteBlogResponse, error) {
+	out := new(DeleteBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/DeleteBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) ListBlog(ctx context.Context, in *ListBlogRequest, opts ...grpc.CallOption) (BlogService_ListBlogClient, error) {
 	stream, err := c.cc.NewStream(ctx, &_BlogService_serviceDesc.Streams[0], "/blog.BlogService/ListBlog", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &blogServiceListBlogClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	x := &blogServiceListBlogClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlogService_ListBlogClient interface {
+	Recv() (*ListBlogResponse, error)
+	grpc.ClientStream
+}
+
+type blogServiceListBlogClient struct {
+	grpc.ClientStream
+}
+
+func (x *blogServiceListBlogClient) Recv() (*ListBlogResponse, error) {
+	m := new(ListBlogResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *blogServiceClient) RestoreBlog(ctx context.Context, in *RestoreBlogRequest, opts ...grpc.CallOption) (*RestoreBlogResponse, error) {
+	out := new(RestoreBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/RestoreBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) PurgeBlog(ctx context.Context, in *PurgeBlogRequest, opts ...grpc.CallOption) (*PurgeBlogResponse, error) {
+	out := new(PurgeBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/PurgeBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) ListBlogRevisions(ctx context.Context, in *ListBlogRevisionsRequest, opts ...grpc.CallOption) (*ListBlogRevisionsResponse, error) {
+	out := new(ListBlogRevisionsResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/ListBlogRevisions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) GetBlogRevision(ctx context.Context, in *GetBlogRevisionRequest, opts ...grpc.CallOption) (*GetBlogRevisionResponse, error) {
+	out := new(GetBlogRevisionResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/GetBlogRevision", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) RollbackBlog(ctx context.Context, in *RollbackBlogRequest, opts ...grpc.CallOption) (*RollbackBlogResponse, error) {
+	out := new(RollbackBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/RollbackBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) BatchGetBlogs(ctx context.Context, in *BatchGetBlogsRequest, opts ...grpc.CallOption) (*BatchGetBlogsResponse, error) {
+	out := new(BatchGetBlogsResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/BatchGetBlogs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) BulkCreateBlogs(ctx context.Context, opts ...grpc.CallOption) (BlogService_BulkCreateBlogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BlogService_serviceDesc.Streams[1], "/blog.BlogService/BulkCreateBlogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blogServiceBulkCreateBlogsClient{stream}
+	return x, nil
+}
+
+type BlogService_BulkCreateBlogsClient interface {
+	Send(*BulkCreateBlogsRequest) error
+	CloseAndRecv() (*BulkCreateBlogsResponse, error)
+	grpc.ClientStream
+}
+
+type blogServiceBulkCreateBlogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *blogServiceBulkCreateBlogsClient) Send(m *BulkCreateBlogsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *blogServiceBulkCreateBlogsClient) CloseAndRecv() (*BulkCreateBlogsResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(BulkCreateBlogsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *blogServiceClient) PublishBlog(ctx context.Context, in *PublishBlogRequest, opts ...grpc.CallOption) (*PublishBlogResponse, error) {
+	out := new(PublishBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/PublishBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) UnpublishBlog(ctx context.Context, in *UnpublishBlogRequest, opts ...grpc.CallOption) (*UnpublishBlogResponse, error) {
+	out := new(UnpublishBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/UnpublishBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error) {
+	out := new(ListTagsResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/ListTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) SearchBlogs(ctx context.Context, in *SearchBlogsRequest, opts ...grpc.CallOption) (*SearchBlogsResponse, error) {
+	out := new(SearchBlogsResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/SearchBlogs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) WatchBlogs(ctx context.Context, in *WatchBlogsRequest, opts ...grpc.CallOption) (BlogService_WatchBlogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BlogService_serviceDesc.Streams[2], "/blog.BlogService/WatchBlogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blogServiceWatchBlogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlogService_WatchBlogsClient interface {
+	Recv() (*WatchBlogsResponse, error)
+	grpc.ClientStream
+}
+
+type blogServiceWatchBlogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *blogServiceWatchBlogsClient) Recv() (*WatchBlogsResponse, error) {
+	m := new(WatchBlogsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// BlogServiceServer is the server API for BlogService service.
+type BlogServiceServer interface {
+	CreateBlog(context.Context, *CreateBlogRequest) (*CreateBlogResponse, error)
+	ReadBlog(context.Context, *ReadBlogRequest) (*ReadBlogResponse, error)
+	UpdateBlog(context.Context, *UpdateBlogRequest) (*UpdateBlogResponse, error)
+	// moves the blog to the trash, return NOT_FOUND if not found
+	DeleteBlog(context.Context, *DeleteBlogRequest) (*DeleteBlogResponse, error)
+	ListBlog(*ListBlogRequest, BlogService_ListBlogServer) error
+	// takes the blog out of the trash, return FAILED_PRECONDITION if it is not in the trash
+	RestoreBlog(context.Context, *RestoreBlogRequest) (*RestoreBlogResponse, error)
+	// removes a blog in the trash for good, return FAILED_PRECONDITION if it is not in the trash
+	PurgeBlog(context.Context, *PurgeBlogRequest) (*PurgeBlogResponse, error)
+	// callers that cannot change the blog only get the revisions in which it
+	// was published and not in the trash
+	ListBlogRevisions(context.Context, *ListBlogRevisionsRequest) (*ListBlogRevisionsResponse, error)
+	GetBlogRevision(context.Context, *GetBlogRevisionRequest) (*GetBlogRevisionResponse, error)
+	// writes an older revision back as a new revision, return NOT_FOUND if not found
+	RollbackBlog(context.Context, *RollbackBlogRequest) (*RollbackBlogResponse, error)
+	BatchGetBlogs(context.Context, *BatchGetBlogsRequest) (*BatchGetBlogsResponse, error)
+	// creates every blog sent, a blog that cannot be created does not stop
+	// the others from being created, return RESOURCE_EXHAUSTED if more than
+	// 10000 blogs are sent, the blogs sent before may have been created then
+	BulkCreateBlogs(BlogService_BulkCreateBlogsServer) error
+	// publishes the blog now or schedules it, return FAILED_PRECONDITION if it
+	// is already published
+	PublishBlog(context.Context, *PublishBlogRequest) (*PublishBlogResponse, error)
+	// takes the blog back to DRAFT or ARCHIVED, return FAILED_PRECONDITION if
+	// it is already there
+	UnpublishBlog(context.Context, *UnpublishBlogRequest) (*UnpublishBlogResponse, error)
+	ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error)
+	SearchBlogs(context.Context, *SearchBlogsRequest) (*SearchBlogsResponse, error)
+	// streams blog changes as they happen, return FAILED_PRECONDITION if the
+	// resume token expired, ABORTED if the client cannot keep up and
+	// UNAVAILABLE if the server shuts down. changes to blogs the caller
+	// cannot see are left out
+	WatchBlogs(*WatchBlogsRequest, BlogService_WatchBlogsServer) error
+}
+
+// UnimplementedBlogServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedBlogServiceServer struct {
+}
+
+func (*UnimplementedBlogServiceServer) CreateBlog(ctx context.Context, req *CreateBlogRequest) (*CreateBlogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBlog not implemented")
+}
+func (*UnimplementedBlogServiceServer) ReadBlog(ctx context.Context, req *ReadBlogRequest) (*ReadBlogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadBlog not implemented")
+}
+func (*UnimplementedBlogServiceServer) UpdateBlog(ctx context.Context, req *UpdateBlogRequest) (*UpdateBlogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBlog not implemented")
+}
+func (*UnimplementedBlogServiceServer) DeleteBlog(ctx context.Context, req *DeleteBlogRequest) (*DeleteBlogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBlog not implemented")
+}
+func (*UnimplementedBlogServiceServer) ListBlog(req *ListBlogRequest, srv BlogService_ListBlogServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListBlog not implemented")
+}
+func (*UnimplementedBlogServiceServer) RestoreBlog(ctx context.Context, req *RestoreBlogRequest) (*RestoreBlogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreBlog not implemented")
+}
+func (*UnimplementedBlogServiceServer) PurgeBlog(ctx context.Context, req *PurgeBlogRequest) (*PurgeBlogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeBlog not implemented")
+}
+func (*UnimplementedBlogServiceServer) ListBlogRevisions(ctx context.Context, req *ListBlogRevisionsRequest) (*ListBlogRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBlogRevisions not implemented")
+}
+func (*UnimplementedBlogServiceServer) GetBlogRevision(ctx context.Context, req *GetBlogRevisionRequest) (*GetBlogRevisionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlogRevision not implemented")
+}
+func (*UnimplementedBlogServiceServer) RollbackBlog(ctx context.Context, req *RollbackBlogRequest) (*RollbackBlogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackBlog not implemented")
+}
+func (*UnimplementedBlogServiceServer) BatchGetBlogs(ctx context.Context, req *BatchGetBlogsRequest) (*BatchGetBlogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetBlogs not implemented")
+}
+func (*UnimplementedBlogServiceServer) BulkCreateBlogs(srv BlogService_BulkCreateBlogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method BulkCreateBlogs not implemented")
+}
+func (*UnimplementedBlogServiceServer) PublishBlog(ctx context.Context, req *PublishBlogRequest) (*PublishBlogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishBlog not implemented")
+}
+func (*UnimplementedBlogServiceServer) UnpublishBlog(ctx context.Context, req *UnpublishBlogRequest) (*UnpublishBlogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnpublishBlog not implemented")
+}
+func (*UnimplementedBlogServiceServer) ListTags(ctx context.Context, req *ListTagsRequest) (*ListTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTags not implemented")
+}
+func (*UnimplementedBlogServiceServer) SearchBlogs(ctx context.Context, req *SearchBlogsRequest) (*SearchBlogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchBlogs not implemented")
+}
+func (*UnimplementedBlogServiceServer) WatchBlogs(req *WatchBlogsRequest, srv BlogService_WatchBlogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchBlogs not implemented")
+}
+
+func RegisterBlogServiceServer(s *grpc.Server, srv BlogServiceServer) {
+	s.RegisterService(&_BlogService_serviceDesc, srv)
+}
+
+func _BlogService_CreateBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).CreateBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/CreateBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).CreateBlog(ctx, req.(*CreateBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_ReadBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).ReadBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/ReadBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).ReadBlog(ctx, req.(*ReadBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_UpdateBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).UpdateBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/UpdateBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).UpdateBlog(ctx, req.(*UpdateBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_DeleteBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).DeleteBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/DeleteBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).DeleteBlog(ctx, req.(*DeleteBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_ListBlog_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListBlogRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlogServiceServer).ListBlog(m, &blogServiceListBlogServer{stream})
+}
+
+type BlogService_ListBlogServer interface {
+	Send(*ListBlogResponse) error
+	grpc.ServerStream
+}
+
+type blogServiceListBlogServer struct {
+	grpc.ServerStream
+}
+
+func (x *blogServiceListBlogServer) Send(m *ListBlogResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BlogService_RestoreBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).RestoreBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/RestoreBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).RestoreBlog(ctx, req.(*RestoreBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_PurgeBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).PurgeBlog(ctx, in)
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/PurgeBlog",
 	}
-	return x, nil
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).PurgeBlog(ctx, req.(*PurgeBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type BlogService_ListBlogClient interface {
-	Recv() (*ListBlogResponse, error)
-	grpc.ClientStream
+func _BlogService_ListBlogRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBlogRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).ListBlogRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/ListBlogRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).ListBlogRevisions(ctx, req.(*ListBlogRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type blogServiceListBlogClient struct {
-	grpc.ClientStream
+func _BlogService_GetBlogRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlogRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).GetBlogRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/GetBlogRevision",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).GetBlogRevision(ctx, req.(*GetBlogRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *blogServiceListBlogClient) Recv() (*ListBlogResponse, error) {
-	m := new(ListBlogResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func _BlogService_RollbackBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackBlogRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
+	if interceptor == nil {
+		return srv.(BlogServiceServer).RollbackBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/RollbackBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).RollbackBlog(ctx, req.(*RollbackBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// BlogServiceServer is the server API for BlogService service.
-type BlogServiceServer interface {
-	CreateBlog(context.Context, *CreateBlogRequest) (*CreateBlogResponse, error)
-	ReadBlog(context.Context, *ReadBlogRequest) (*ReadBlogResponse, error)
-	UpdateBlog(context.Context, *UpdateBlogRequest) (*UpdateBlogResponse, error)
-	DeleteBlog(context.Context, *DeleteBlogRequest) (*DeleteBlogResponse, error)
-	ListBlog(*ListBlogRequest, BlogService_ListBlogServer) error
+func _BlogService_BatchGetBlogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetBlogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).BatchGetBlogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/BatchGetBlogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).BatchGetBlogs(ctx, req.(*BatchGetBlogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedBlogServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedBlogServiceServer struct {
+func _BlogService_BulkCreateBlogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BlogServiceServer).BulkCreateBlogs(&blogServiceBulkCreateBlogsServer{stream})
 }
 
-func (*UnimplementedBlogServiceServer) CreateBlog(ctx context.Context, req *CreateBlogRequest) (*CreateBlogResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateBlog not implemented")
-}
-func (*UnimplementedBlogServiceServer) ReadBlog(ctx context.Context, req *ReadBlogRequest) (*ReadBlogResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ReadBlog not implemented")
-}
-func (*UnimplementedBlogServiceServer) UpdateBlog(ctx context.Context, req *UpdateBlogRequest) (*UpdateBlogResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateBlog not implemented")
+type BlogService_BulkCreateBlogsServer interface {
+	SendAndClose(*BulkCreateBlogsResponse) error
+	Recv() (*BulkCreateBlogsRequest, error)
+	grpc.ServerStream
 }
-func (*UnimplementedBlogServiceServer) DeleteBlog(ctx context.Context, req *DeleteBlogRequest) (*DeleteBlogResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteBlog not implemented")
+
+type blogServiceBulkCreateBlogsServer struct {
+	grpc.ServerStream
 }
-func (*UnimplementedBlogServiceServer) ListBlog(req *ListBlogRequest, srv BlogService_ListBlogServer) error {
-	return status.Errorf(codes.Unimplemented, "method ListBlog not implemented")
+
+func (x *blogServiceBulkCreateBlogsServer) SendAndClose(m *BulkCreateBlogsResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func RegisterBlogServiceServer(s *grpc.Server, srv BlogServiceServer) {
-	s.RegisterService(&_BlogService_serviceDesc, srv)
+func (x *blogServiceBulkCreateBlogsServer) Recv() (*BulkCreateBlogsRequest, error) {
+	m := new(BulkCreateBlogsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
-func _BlogService_CreateBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateBlogRequest)
+func _BlogService_PublishBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishBlogRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BlogServiceServer).CreateBlog(ctx, in)
+		return srv.(BlogServiceServer).PublishBlog(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/blog.BlogService/CreateBlog",
+		FullMethod: "/blog.BlogService/PublishBlog",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BlogServiceServer).CreateBlog(ctx, req.(*CreateBlogRequest))
+		return srv.(BlogServiceServer).PublishBlog(ctx, req.(*PublishBlogRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BlogService_ReadBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReadBlogRequest)
+func _BlogService_UnpublishBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnpublishBlogRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BlogServiceServer).ReadBlog(ctx, in)
+		return srv.(BlogServiceServer).UnpublishBlog(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/blog.BlogService/ReadBlog",
+		FullMethod: "/blog.BlogService/UnpublishBlog",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BlogServiceServer).ReadBlog(ctx, req.(*ReadBlogRequest))
+		return srv.(BlogServiceServer).UnpublishBlog(ctx, req.(*UnpublishBlogRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BlogService_UpdateBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateBlogRequest)
+func _BlogService_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BlogServiceServer).UpdateBlog(ctx, in)
+		return srv.(BlogServiceServer).ListTags(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/blog.BlogService/UpdateBlog",
+		FullMethod: "/blog.BlogService/ListTags",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BlogServiceServer).UpdateBlog(ctx, req.(*UpdateBlogRequest))
+		return srv.(BlogServiceServer).ListTags(ctx, req.(*ListTagsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BlogService_DeleteBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteBlogRequest)
+func _BlogService_SearchBlogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchBlogsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BlogServiceServer).DeleteBlog(ctx, in)
+		return srv.(BlogServiceServer).SearchBlogs(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/blog.BlogService/DeleteBlog",
+		FullMethod: "/blog.BlogService/SearchBlogs",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BlogServiceServer).DeleteBlog(ctx, req.(*DeleteBlogRequest))
+		return srv.(BlogServiceServer).SearchBlogs(ctx, req.(*SearchBlogsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BlogService_ListBlog_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(ListBlogRequest)
+func _BlogService_WatchBlogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchBlogsRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(BlogServiceServer).ListBlog(m, &blogServiceListBlogServer{stream})
+	return srv.(BlogServiceServer).WatchBlogs(m, &blogServiceWatchBlogsServer{stream})
 }
 
-type BlogService_ListBlogServer interface {
-	Send(*ListBlogResponse) error
+type BlogService_WatchBlogsServer interface {
+	Send(*WatchBlogsResponse) error
 	grpc.ServerStream
 }
 
-type blogServiceListBlogServer struct {
+type blogServiceWatchBlogsServer struct {
 	grpc.ServerStream
 }
 
-func (x *blogServiceListBlogServer) Send(m *ListBlogResponse) error {
+func (x *blogServiceWatchBlogsServer) Send(m *WatchBlogsResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
@@ -754,6 +3574,46 @@ var _BlogService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteBlog",
 			Handler:    _BlogService_DeleteBlog_Handler,
 		},
+		{
+			MethodName: "RestoreBlog",
+			Handler:    _BlogService_RestoreBlog_Handler,
+		},
+		{
+			MethodName: "PurgeBlog",
+			Handler:    _BlogService_PurgeBlog_Handler,
+		},
+		{
+			MethodName: "ListBlogRevisions",
+			Handler:    _BlogService_ListBlogRevisions_Handler,
+		},
+		{
+			MethodName: "GetBlogRevision",
+			Handler:    _BlogService_GetBlogRevision_Handler,
+		},
+		{
+			MethodName: "RollbackBlog",
+			Handler:    _BlogService_RollbackBlog_Handler,
+		},
+		{
+			MethodName: "BatchGetBlogs",
+			Handler:    _BlogService_BatchGetBlogs_Handler,
+		},
+		{
+			MethodName: "PublishBlog",
+			Handler:    _BlogService_PublishBlog_Handler,
+		},
+		{
+			MethodName: "UnpublishBlog",
+			Handler:    _BlogService_UnpublishBlog_Handler,
+		},
+		{
+			MethodName: "ListTags",
+			Handler:    _BlogService_ListTags_Handler,
+		},
+		{
+			MethodName: "SearchBlogs",
+			Handler:    _BlogService_SearchBlogs_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -761,6 +3621,204 @@ var _BlogService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _BlogService_ListBlog_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "BulkCreateBlogs",
+			Handler:       _BlogService_BulkCreateBlogs_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchBlogs",
+			Handler:       _BlogService_WatchBlogs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pb/blog.proto",
+}
+
+// CommentServiceClient is the client API for CommentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type CommentServiceClient interface {
+	// return NOT_FOUND if the blog is not found or in the trash
+	CreateComment(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*CreateCommentResponse, error)
+	// return NOT_FOUND if the blog is not found or in the trash
+	ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error)
+	UpdateComment(ctx context.Context, in *UpdateCommentRequest, opts ...grpc.CallOption) (*UpdateCommentResponse, error)
+	// the author of the comment and the author of the blog can delete it,
+	// return NOT_FOUND if not found
+	DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*DeleteCommentResponse, error)
+}
+
+type commentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCommentServiceClient(cc grpc.ClientConnInterface) CommentServiceClient {
+	return &commentServiceClient{cc}
+}
+
+func (c *commentServiceClient) CreateComment(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*CreateCommentResponse, error) {
+	out := new(CreateCommentResponse)
+	err := c.cc.Invoke(ctx, "/blog.CommentService/CreateComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentServiceClient) ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error) {
+	out := new(ListCommentsResponse)
+	err := c.cc.Invoke(ctx, "/blog.CommentService/ListComments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentServiceClient) UpdateComment(ctx context.Context, in *UpdateCommentRequest, opts ...grpc.CallOption) (*UpdateCommentResponse, error) {
+	out := new(UpdateCommentResponse)
+	err := c.cc.Invoke(ctx, "/blog.CommentService/UpdateComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentServiceClient) DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*DeleteCommentResponse, error) {
+	out := new(DeleteCommentResponse)
+	err := c.cc.Invoke(ctx, "/blog.CommentService/DeleteComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CommentServiceServer is the server API for CommentService service.
+type CommentServiceServer interface {
+	// return NOT_FOUND if the blog is not found or in the trash
+	CreateComment(context.Context, *CreateCommentRequest) (*CreateCommentResponse, error)
+	// return NOT_FOUND if the blog is not found or in the trash
+	ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error)
+	UpdateComment(context.Context, *UpdateCommentRequest) (*UpdateCommentResponse, error)
+	// the author of the comment and the author of the blog can delete it,
+	// return NOT_FOUND if not found
+	DeleteComment(context.Context, *DeleteCommentRequest) (*DeleteCommentResponse, error)
+}
+
+// UnimplementedCommentServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedCommentServiceServer struct {
+}
+
+func (*UnimplementedCommentServiceServer) CreateComment(ctx context.Context, req *CreateCommentRequest) (*CreateCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateComment not implemented")
+}
+func (*UnimplementedCommentServiceServer) ListComments(ctx context.Context, req *ListCommentsRequest) (*ListCommentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListComments not implemented")
+}
+func (*UnimplementedCommentServiceServer) UpdateComment(ctx context.Context, req *UpdateCommentRequest) (*UpdateCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateComment not implemented")
+}
+func (*UnimplementedCommentServiceServer) DeleteComment(ctx context.Context, req *DeleteCommentRequest) (*DeleteCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteComment not implemented")
+}
+
+func RegisterCommentServiceServer(s *grpc.Server, srv CommentServiceServer) {
+	s.RegisterService(&_CommentService_serviceDesc, srv)
+}
+
+func _CommentService_CreateComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).CreateComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.CommentService/CreateComment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).CreateComment(ctx, req.(*CreateCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentService_ListComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).ListComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.CommentService/ListComments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).ListComments(ctx, req.(*ListCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentService_UpdateComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).UpdateComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.CommentService/UpdateComment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).UpdateComment(ctx, req.(*UpdateCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentService_DeleteComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).DeleteComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.CommentService/DeleteComment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).DeleteComment(ctx, req.(*DeleteCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _CommentService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "blog.CommentService",
+	HandlerType: (*CommentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateComment",
+			Handler:    _CommentService_CreateComment_Handler,
+		},
+		{
+			MethodName: "ListComments",
+			Handler:    _CommentService_ListComments_Handler,
+		},
+		{
+			MethodName: "UpdateComment",
+			Handler:    _CommentService_UpdateComment_Handler,
+		},
+		{
+			MethodName: "DeleteComment",
+			Handler:    _CommentService_DeleteComment_Handler,
+		},
 	},
+	Streams:  []grpc.StreamDesc{},
 	Metadata: "pb/blog.proto",
 }
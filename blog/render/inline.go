@@ -0,0 +1,305 @@
+package render
+
+import (
+	"html"
+	"net/url"
+	"strings"
+)
+
+// renderInline renders the inline markup of text: code spans, emphasis, links,
+// images and autolinks. Links are not rendered inside the text of a link.
+func renderInline(b *strings.Builder, text string, inLink bool) {
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '\\' && i+1 < len(text) && isPunct(text[i+1]):
+			b.WriteString(html.EscapeString(text[i+1 : i+2]))
+			i += 2
+			continue
+		case c == '`':
+			i += renderCode(b, text[i:])
+			continue
+		case c == '!' && strings.HasPrefix(text[i:], "!["):
+			if n := renderImage(b, text[i:]); n > 0 {
+				i += n
+				continue
+			}
+		case c == '[' && !inLink:
+			if n := renderLink(b, text[i:]); n > 0 {
+				i += n
+				continue
+			}
+		case c == '<' && !inLink:
+			if n := renderAutolink(b, text[i:]); n > 0 {
+				i += n
+				continue
+			}
+		case c == '*' || c == '_':
+			i += renderEmphasis(b, text, i, inLink)
+			continue
+		}
+		b.WriteString(html.EscapeString(text[i : i+1]))
+		i++
+	}
+}
+
+func isPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n'
+}
+
+func isAlnum(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
+
+// renderCode renders the code span that text starts with and returns its
+// length. A run of backticks without a closing run is written as is.
+func renderCode(b *strings.Builder, text string) int {
+	n := len(text) - len(strings.TrimLeft(text, "`"))
+	run := text[:n]
+	for j := n; j < len(text); {
+		k := strings.Index(text[j:], run)
+		if k < 0 {
+			break
+		}
+		k += j
+		// the closing run must be exactly as long as the opening one
+		end := k + n
+		if end < len(text) && text[end] == '`' {
+			j = end + len(text[end:]) - len(strings.TrimLeft(text[end:], "`"))
+			continue
+		}
+
+		code := text[n:k]
+		if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+			code = code[1 : len(code)-1]
+		}
+		b.WriteString("<code>")
+		b.WriteString(html.EscapeString(code))
+		b.WriteString("</code>")
+		return end
+	}
+	b.WriteString(run)
+	return n
+}
+
+// renderEmphasis renders the emphasis opened by the run of * or _ at text[i]
+// and returns how much of text it used. A run that opens nothing is written
+// as is.
+func renderEmphasis(b *strings.Builder, text string, i int, inLink bool) int {
+	c := text[i]
+	n := len(text[i:]) - len(strings.TrimLeft(text[i:], text[i:i+1]))
+	run := text[i : i+n]
+
+	// an opening run is followed by text, and _ does not open within a word
+	opens := i+n < len(text) && !isSpace(text[i+n]) && !(c == '_' && i > 0 && isAlnum(text[i-1]))
+	if opens && n <= 3 {
+		for _, width := range []int{2, 1} {
+			if n < width {
+				continue
+			}
+			delim := run[:width]
+			if end := closingDelim(text, i+n, delim); end > 0 {
+				tag := "em"
+				if width == 2 {
+					tag = "strong"
+				}
+				// write the extra delimiters of a longer opening run as is
+				b.WriteString(run[:n-width])
+				b.WriteString("<" + tag + ">")
+				renderInline(b, text[i+n:end], inLink)
+				b.WriteString("</" + tag + ">")
+				return end + width - i
+			}
+		}
+	}
+	b.WriteString(html.EscapeString(run))
+	return n
+}
+
+// closingDelim returns the index of the delimiter that closes emphasis whose
+// text starts at text[from], or -1.
+func closingDelim(text string, from int, delim string) int {
+	c := delim[0]
+	for j := from + 1; j+len(delim) <= len(text); j++ {
+		if text[j-1] == '\\' || !strings.HasPrefix(text[j:], delim) {
+			continue
+		}
+		// a closing run follows text, and _ does not close within a word
+		if isSpace(text[j-1]) || text[j-1] == c {
+			continue
+		}
+		end := j + len(delim)
+		if end < len(text) && text[end] == c {
+			// part of a longer run, which closes something else
+			j = end
+			continue
+		}
+		if c == '_' && end < len(text) && isAlnum(text[end]) {
+			continue
+		}
+		return j
+	}
+	return -1
+}
+
+// renderLink renders the [text](url "title") link that text starts with and
+// returns its length, or 0 if text does not start with a link.
+func renderLink(b *strings.Builder, text string) int {
+	label, dest, title, n := parseLink(text)
+	if n == 0 {
+		return 0
+	}
+
+	href, ok := safeURL(dest)
+	if !ok {
+		// keep the text of a link to somewhere unsafe
+		renderInline(b, label, true)
+		return n
+	}
+	b.WriteString(`<a href="` + html.EscapeString(href) + `"`)
+	if title != "" {
+		b.WriteString(` title="` + html.EscapeString(title) + `"`)
+	}
+	b.WriteString(` rel="nofollow noopener">`)
+	renderInline(b, label, true)
+	b.WriteString("</a>")
+	return n
+}
+
+// renderImage renders the ![alt](url "title") image that text starts with and
+// returns its length, or 0 if text does not start with an image.
+func renderImage(b *strings.Builder, text string) int {
+	alt, dest, title, n := parseLink(text[1:])
+	if n == 0 {
+		return 0
+	}
+
+	src, ok := safeURL(dest)
+	if !ok {
+		b.WriteString(html.EscapeString(alt))
+		return n + 1
+	}
+	b.WriteString(`<img src="` + html.EscapeString(src) + `" alt="` + html.EscapeString(alt) + `"`)
+	if title != "" {
+		b.WriteString(` title="` + html.EscapeString(title) + `"`)
+	}
+	b.WriteString(">")
+	return n + 1
+}
+
+// parseLink parses the [label](dest "title") that text starts with. n is the
+// length of the link, or 0 if text does not start with one.
+func parseLink(text string) (label, dest, title string, n int) {
+	// find the ] that closes the label, brackets in it must be balanced
+	depth := 0
+	end := -1
+	for i := 0; i < len(text) && end < 0; i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				end = i
+			}
+		}
+	}
+	if end < 0 || end+1 >= len(text) || text[end+1] != '(' {
+		return "", "", "", 0
+	}
+	label = text[1:end]
+
+	i := end + 2
+	skipSpaces := func() {
+		for i < len(text) && isSpace(text[i]) {
+			i++
+		}
+	}
+
+	skipSpaces()
+	if i < len(text) && text[i] == '<' {
+		k := strings.IndexAny(text[i:], ">\n")
+		if k < 0 || text[i+k] != '>' {
+			return "", "", "", 0
+		}
+		dest = text[i+1 : i+k]
+		i += k + 1
+	} else {
+		start := i
+		parens := 0
+		for ; i < len(text) && !isSpace(text[i]); i++ {
+			if text[i] == '(' {
+				parens++
+			} else if text[i] == ')' {
+				if parens == 0 {
+					break
+				}
+				parens--
+			}
+		}
+		dest = text[start:i]
+	}
+
+	skipSpaces()
+	if i < len(text) && (text[i] == '"' || text[i] == '\'') {
+		k := strings.IndexByte(text[i+1:], text[i])
+		if k < 0 {
+			return "", "", "", 0
+		}
+		title = text[i+1 : i+1+k]
+		i += k + 2
+		skipSpaces()
+	}
+	if i >= len(text) || text[i] != ')' {
+		return "", "", "", 0
+	}
+	return label, dest, title, i + 1
+}
+
+// renderAutolink renders the <url> autolink that text starts with and returns
+// its length, or 0 if text does not start with one.
+func renderAutolink(b *strings.Builder, text string) int {
+	k := strings.IndexAny(text, "> \n")
+	if k < 0 || text[k] != '>' {
+		return 0
+	}
+	raw := text[1:k]
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme == "" {
+		return 0
+	}
+	href, ok := safeURL(raw)
+	if !ok {
+		return 0
+	}
+	b.WriteString(`<a href="` + html.EscapeString(href) + `" rel="nofollow noopener">`)
+	b.WriteString(html.EscapeString(raw))
+	b.WriteString("</a>")
+	return k + 1
+}
+
+// safeSchemes are the url schemes links and images may use.
+var safeSchemes = map[string]bool{
+	"http":   true,
+	"https":  true,
+	"mailto": true,
+}
+
+// safeURL returns raw if it is a relative url or uses one of safeSchemes, so
+// it cannot run script when followed.
+func safeURL(raw string) (string, bool) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", false
+	}
+	if u.Scheme != "" && !safeSchemes[strings.ToLower(u.Scheme)] {
+		return "", false
+	}
+	return raw, true
+}
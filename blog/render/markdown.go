@@ -0,0 +1,369 @@
+package render
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// markdownHTML renders the blocks of a markdown document.
+func markdownHTML(src string) string {
+	var b strings.Builder
+	renderBlocks(&b, splitLines(src), false)
+	return b.String()
+}
+
+// renderBlocks renders lines as a sequence of blocks. Paragraphs are written
+// without <p> tags when tight is set, as in the items of a tight list.
+func renderBlocks(b *strings.Builder, lines []string, tight bool) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case isBlank(line):
+			i++
+		case fenceOf(line) != "":
+			i = renderFence(b, lines, i)
+		case headingLevel(line) > 0:
+			renderHeading(b, line)
+			i++
+		case isRule(line):
+			b.WriteString("<hr>\n")
+			i++
+		case isQuote(line):
+			i = renderQuote(b, lines, i)
+		case isListItem(line):
+			i = renderList(b, lines, i)
+		default:
+			i = renderParagraph(b, lines, i, tight)
+		}
+	}
+}
+
+// indentOf returns the number of leading spaces of line.
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// startsBlock reports whether line starts a block other than a paragraph, so
+// it ends the paragraph before it.
+func startsBlock(line string) bool {
+	return fenceOf(line) != "" || headingLevel(line) > 0 || isRule(line) || isQuote(line) || isListItem(line)
+}
+
+// fenceOf returns the ``` or ~~~ run that opens a fenced code block on line,
+// or an empty string.
+func fenceOf(line string) string {
+	if indentOf(line) > 3 {
+		return ""
+	}
+	trimmed := strings.TrimLeft(line, " ")
+	for _, c := range []string{"`", "~"} {
+		n := len(trimmed) - len(strings.TrimLeft(trimmed, c))
+		if n >= 3 {
+			// a backtick fence cannot have backticks in its info string
+			if c == "`" && strings.Contains(trimmed[n:], "`") {
+				return ""
+			}
+			return trimmed[:n]
+		}
+	}
+	return ""
+}
+
+// renderFence renders the fenced code block opening at lines[i] and returns
+// the index of the line after it. An unclosed fence runs to the end.
+func renderFence(b *strings.Builder, lines []string, i int) int {
+	fence := fenceOf(lines[i])
+	info := strings.Fields(strings.TrimSpace(strings.TrimLeft(lines[i], " "))[len(fence):])
+
+	b.WriteString("<pre><code")
+	if len(info) > 0 {
+		if lang := languageClass(info[0]); lang != "" {
+			fmt.Fprintf(b, ` class="language-%s"`, lang)
+		}
+	}
+	b.WriteString(">")
+
+	i++
+	for ; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			i++
+			break
+		}
+		b.WriteString(html.EscapeString(lines[i]))
+		b.WriteString("\n")
+	}
+	b.WriteString("</code></pre>\n")
+	return i
+}
+
+// languageClass keeps the characters of a fence info string that are safe in
+// a class name.
+func languageClass(info string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '+':
+			return r
+		}
+		return -1
+	}, info)
+}
+
+// headingLevel returns the level of the atx heading on line, or 0.
+func headingLevel(line string) int {
+	if indentOf(line) > 3 {
+		return 0
+	}
+	trimmed := strings.TrimLeft(line, " ")
+	n := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+	if n < 1 || n > 6 {
+		return 0
+	}
+	if len(trimmed) > n && trimmed[n] != ' ' {
+		return 0
+	}
+	return n
+}
+
+func renderHeading(b *strings.Builder, line string) {
+	level := headingLevel(line)
+	text := strings.TrimSpace(strings.TrimLeft(line, " ")[level:])
+	// drop a closing run of #, as in "## title ##"
+	if closed := strings.TrimRight(text, "#"); closed == "" || strings.HasSuffix(closed, " ") {
+		text = strings.TrimSpace(closed)
+	}
+
+	fmt.Fprintf(b, "<h%d>", level)
+	renderInline(b, text, false)
+	fmt.Fprintf(b, "</h%d>\n", level)
+}
+
+// isRule reports whether line is a horizontal rule: three or more of the same
+// -, * or _ with nothing but spaces between them.
+func isRule(line string) bool {
+	if indentOf(line) > 3 {
+		return false
+	}
+	s := strings.Replace(line, " ", "", -1)
+	if len(s) < 3 {
+		return false
+	}
+	return strings.Trim(s, s[:1]) == "" && strings.Contains("-*_", s[:1])
+}
+
+func isQuote(line string) bool {
+	return indentOf(line) <= 3 && strings.HasPrefix(strings.TrimLeft(line, " "), ">")
+}
+
+// renderQuote renders the block quote starting at lines[i] and returns the
+// index of the line after it.
+func renderQuote(b *strings.Builder, lines []string, i int) int {
+	var inner []string
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if isQuote(line) {
+			line = strings.TrimPrefix(strings.TrimLeft(line, " "), ">")
+			inner = append(inner, strings.TrimPrefix(line, " "))
+			continue
+		}
+		// a paragraph in the quote goes on over lines without >
+		if isBlank(line) || startsBlock(line) || len(inner) == 0 || isBlank(inner[len(inner)-1]) {
+			break
+		}
+		inner = append(inner, line)
+	}
+
+	b.WriteString("<blockquote>\n")
+	renderBlocks(b, inner, false)
+	b.WriteString("</blockquote>\n")
+	return i
+}
+
+// listMarker is the marker that starts a list item.
+type listMarker struct {
+	// bullet is the -, * or + of a bullet list, or the . or ) that follows
+	// the number of an ordered list
+	bullet  byte
+	ordered bool
+	number  int
+	// width is the indentation of the content of the item
+	width int
+}
+
+// parseListMarker returns the list marker starting line, if any.
+func parseListMarker(line string) (listMarker, bool) {
+	indent := indentOf(line)
+	if indent > 3 || isRule(line) {
+		return listMarker{}, false
+	}
+	s := line[indent:]
+
+	m := listMarker{}
+	n := 0
+	switch {
+	case len(s) > 0 && strings.IndexByte("-*+", s[0]) >= 0:
+		m.bullet = s[0]
+		n = 1
+	default:
+		for n < len(s) && n < 9 && s[n] >= '0' && s[n] <= '9' {
+			m.number = m.number*10 + int(s[n]-'0')
+			n++
+		}
+		if n == 0 || n >= len(s) || (s[n] != '.' && s[n] != ')') {
+			return listMarker{}, false
+		}
+		m.ordered = true
+		m.bullet = s[n]
+		n++
+	}
+
+	if n == len(s) {
+		m.width = indent + n + 1
+		return m, true
+	}
+	if s[n] != ' ' {
+		return listMarker{}, false
+	}
+	spaces := indentOf(s[n:])
+	if spaces > 4 {
+		// the content is indented code, which only takes one space
+		spaces = 1
+	}
+	m.width = indent + n + spaces
+	return m, true
+}
+
+func isListItem(line string) bool {
+	_, ok := parseListMarker(line)
+	return ok
+}
+
+// renderList renders the list starting at lines[i] and returns the index of
+// the line after it.
+func renderList(b *strings.Builder, lines []string, i int) int {
+	first, _ := parseListMarker(lines[i])
+	sameList := func(line string) (listMarker, bool) {
+		m, ok := parseListMarker(line)
+		return m, ok && m.ordered == first.ordered && m.bullet == first.bullet
+	}
+
+	var items [][]string
+	width := 0
+	loose := false
+	for i < len(lines) {
+		line := lines[i]
+		if m, ok := sameList(line); ok {
+			if len(items) > 0 && isBlank(last(items[len(items)-1])) {
+				loose = true
+			}
+			width = m.width
+			content := ""
+			if len(line) > m.width {
+				content = line[m.width:]
+			}
+			items = append(items, []string{content})
+			i++
+			continue
+		}
+
+		item := items[len(items)-1]
+		switch {
+		case isBlank(line):
+			// a blank line only belongs to the list if the list goes on
+			// after it
+			j := i + 1
+			for j < len(lines) && isBlank(lines[j]) {
+				j++
+			}
+			if j == len(lines) {
+				return finishList(b, first, items, loose, j)
+			}
+			if _, ok := sameList(lines[j]); !ok && indentOf(lines[j]) < width {
+				return finishList(b, first, items, loose, j)
+			}
+			item = append(item, "")
+		case indentOf(line) >= width:
+			if isBlank(last(item)) {
+				loose = true
+			}
+			item = append(item, line[width:])
+		case !isBlank(last(item)) && !startsBlock(line):
+			// lazy continuation of the paragraph in the item
+			item = append(item, strings.TrimLeft(line, " "))
+		default:
+			return finishList(b, first, items, loose, i)
+		}
+		items[len(items)-1] = item
+		i++
+	}
+	return finishList(b, first, items, loose, i)
+}
+
+func finishList(b *strings.Builder, first listMarker, items [][]string, loose bool, next int) int {
+	tag := "ul"
+	if first.ordered {
+		tag = "ol"
+	}
+	if first.ordered && first.number != 1 {
+		fmt.Fprintf(b, "<ol start=\"%d\">\n", first.number)
+	} else {
+		fmt.Fprintf(b, "<%s>\n", tag)
+	}
+	for _, item := range items {
+		b.WriteString("<li>")
+		var inner strings.Builder
+		renderBlocks(&inner, item, !loose)
+		b.WriteString(strings.TrimSuffix(inner.String(), "\n"))
+		b.WriteString("</li>\n")
+	}
+	fmt.Fprintf(b, "</%s>\n", tag)
+	return next
+}
+
+func last(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	return lines[len(lines)-1]
+}
+
+// renderParagraph renders the paragraph starting at lines[i] and returns the
+// index of the line after it.
+func renderParagraph(b *strings.Builder, lines []string, i int, tight bool) int {
+	var para []string
+	for ; i < len(lines); i++ {
+		if isBlank(lines[i]) || (len(para) > 0 && startsBlock(lines[i])) {
+			break
+		}
+		para = append(para, lines[i])
+	}
+
+	if !tight {
+		b.WriteString("<p>")
+	}
+	for j, line := range para {
+		line = strings.TrimLeft(line, " ")
+		if j == len(para)-1 {
+			renderInline(b, strings.TrimRight(line, " "), false)
+			break
+		}
+		// two trailing spaces or a backslash break the line
+		switch {
+		case strings.HasSuffix(line, "  "):
+			renderInline(b, strings.TrimRight(line, " "), false)
+			b.WriteString("<br>\n")
+		case strings.HasSuffix(line, "\\"):
+			renderInline(b, strings.TrimSuffix(line, "\\"), false)
+			b.WriteString("<br>\n")
+		default:
+			renderInline(b, line, false)
+			b.WriteString("\n")
+		}
+	}
+	if !tight {
+		b.WriteString("</p>")
+	}
+	b.WriteString("\n")
+	return i
+}
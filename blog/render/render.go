@@ -0,0 +1,83 @@
+// Package render turns blog content into html that is safe to embed in a page.
+//
+// The html is safe by construction rather than cleaned up afterwards: every
+// character of the source is escaped, and the only markup in the output is the
+// fixed set of tags the renderer writes itself. Links and images only keep
+// http, https, mailto and relative urls.
+package render
+
+import (
+	"html"
+	"strings"
+)
+
+// Format is the markup language of blog content.
+type Format string
+
+const (
+	// Plain content is shown as written. Blank lines separate paragraphs and
+	// every other line break is kept.
+	Plain Format = "plain"
+	// Markdown content supports headings, paragraphs, emphasis, code, block
+	// quotes, lists, links, images and horizontal rules. Html written in the
+	// source is shown as text.
+	Markdown Format = "markdown"
+)
+
+// HTML renders src written in the given format. Unknown formats render as
+// Plain.
+func HTML(src string, f Format) string {
+	if f == Markdown {
+		return markdownHTML(src)
+	}
+	return plainHTML(src)
+}
+
+// plainHTML renders src as paragraphs split on blank lines.
+func plainHTML(src string) string {
+	var b strings.Builder
+	for _, para := range paragraphs(splitLines(src)) {
+		b.WriteString("<p>")
+		for i, line := range para {
+			if i > 0 {
+				b.WriteString("<br>\n")
+			}
+			b.WriteString(html.EscapeString(line))
+		}
+		b.WriteString("</p>\n")
+	}
+	return b.String()
+}
+
+// paragraphs groups lines into runs of non-blank lines.
+func paragraphs(lines []string) [][]string {
+	var paras [][]string
+	var cur []string
+	for _, line := range lines {
+		if isBlank(line) {
+			if cur != nil {
+				paras = append(paras, cur)
+				cur = nil
+			}
+			continue
+		}
+		cur = append(cur, line)
+	}
+	if cur != nil {
+		paras = append(paras, cur)
+	}
+	return paras
+}
+
+// splitLines splits src into lines, whatever line endings it uses, and expands
+// tabs to four spaces.
+func splitLines(src string) []string {
+	src = strings.Replace(src, "\r\n", "\n", -1)
+	src = strings.Replace(src, "\r", "\n", -1)
+	src = strings.Replace(src, "\t", "    ", -1)
+	return strings.Split(src, "\n")
+}
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
@@ -0,0 +1,127 @@
+package render
+
+import (
+	"flag"
+	"html"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// TestMarkdownGolden renders every testdata/*.md file and compares the result
+// with the .html file next to it.
+func TestMarkdownGolden(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "*.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no golden files in testdata")
+	}
+
+	for _, file := range files {
+		file := file
+		name := strings.TrimSuffix(filepath.Base(file), ".md")
+		t.Run(name, func(t *testing.T) {
+			src, err := ioutil.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := HTML(string(src), Markdown)
+
+			golden := strings.TrimSuffix(file, ".md") + ".html"
+			if *update {
+				if err := ioutil.WriteFile(golden, []byte(got), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := ioutil.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != string(want) {
+				t.Errorf("HTML(%s) =\n%s\nwant\n%s", file, got, want)
+			}
+		})
+	}
+}
+
+// tagPattern matches the markup in rendered html. Source text never adds to
+// it, as its < and > are escaped.
+var tagPattern = regexp.MustCompile(`<(/?)([a-z0-9]+)([^>]*)>`)
+
+// attrPattern matches an attribute of a tag written by the renderer.
+var attrPattern = regexp.MustCompile(` ([a-z]+)="([^"]*)"`)
+
+// safeTags are the tags the renderer writes, with the attributes they may
+// have.
+var safeTags = map[string]map[string]bool{
+	"p": nil, "br": nil, "em": nil, "strong": nil, "code": {"class": true},
+	"pre": nil, "blockquote": nil, "ul": nil, "ol": nil, "li": nil, "hr": nil,
+	"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil,
+	"a":   {"href": true, "title": true, "rel": true},
+	"img": {"src": true, "alt": true, "title": true},
+}
+
+// TestSafeOutput checks that no golden output can run script, whatever the
+// golden files say: only the renderer's own tags and attributes are written,
+// and urls only use safe schemes.
+func TestSafeOutput(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "*.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range files {
+		src, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out := HTML(string(src), Markdown)
+		for _, m := range tagPattern.FindAllStringSubmatch(out, -1) {
+			attrs, ok := safeTags[m[2]]
+			if !ok {
+				t.Errorf("%s: unexpected tag %s", file, m[0])
+				continue
+			}
+			rest := attrPattern.ReplaceAllStringFunc(m[3], func(attr string) string {
+				a := attrPattern.FindStringSubmatch(attr)
+				if !attrs[a[1]] {
+					t.Errorf("%s: unexpected attribute in %s", file, m[0])
+				}
+				if a[1] == "href" || a[1] == "src" {
+					if _, ok := safeURL(html.UnescapeString(a[2])); !ok {
+						t.Errorf("%s: unsafe url in %s", file, m[0])
+					}
+				}
+				return ""
+			})
+			if rest != "" {
+				t.Errorf("%s: malformed tag %s", file, m[0])
+			}
+		}
+	}
+}
+
+func TestPlain(t *testing.T) {
+	tests := []struct {
+		src, want string
+	}{
+		{"", ""},
+		{"one line", "<p>one line</p>\n"},
+		{"two\nlines", "<p>two<br>\nlines</p>\n"},
+		{"first\n\n\nsecond", "<p>first</p>\n<p>second</p>\n"},
+		{"windows\r\nline\rendings", "<p>windows<br>\nline<br>\nendings</p>\n"},
+		{"<script>alert(\"1\")</script>", "<p>&lt;script&gt;alert(&#34;1&#34;)&lt;/script&gt;</p>\n"},
+		{"*not* markdown", "<p>*not* markdown</p>\n"},
+	}
+	for _, tt := range tests {
+		if got := HTML(tt.src, Plain); got != tt.want {
+			t.Errorf("HTML(%q, Plain) = %q, want %q", tt.src, got, tt.want)
+		}
+	}
+}
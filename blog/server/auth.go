@@ -0,0 +1,272 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// adminRole lets a caller change blogs written by other authors.
+const adminRole = "admin"
+
+// maxTokenLifetime is the longest a token may stay valid. Tokens expiring
+// later than that from now are rejected.
+const maxTokenLifetime = 30 * 24 * time.Hour
+
+// authenticatedMethods are the methods that change blogs or comments. Callers
+// of these must send a token. The read-only methods are open to everyone, a
+// token sent to them lets the caller see their unpublished blogs.
+var authenticatedMethods = map[string]bool{
+	"/blog.BlogService/CreateBlog":      true,
+	"/blog.BlogService/UpdateBlog":      true,
+	"/blog.BlogService/DeleteBlog":      true,
+	"/blog.BlogService/RestoreBlog":     true,
+	"/blog.BlogService/PurgeBlog":       true,
+	"/blog.BlogService/RollbackBlog":    true,
+	"/blog.BlogService/BulkCreateBlogs": true,
+	"/blog.BlogService/PublishBlog":     true,
+	"/blog.BlogService/UnpublishBlog":   true,
+
+	"/blog.CommentService/CreateComment": true,
+	"/blog.CommentService/UpdateComment": true,
+	"/blog.CommentService/DeleteComment": true,
+}
+
+// blogClaims are the claims of a blog token. The subject is the author id of
+// the caller.
+type blogClaims struct {
+	Roles []string `json:"roles,omitempty"`
+	jwt.StandardClaims
+}
+
+// caller is the user behind a request. Anonymous callers of the read-only
+// methods have no author id.
+type caller struct {
+	AuthorID string
+	Admin    bool
+}
+
+// canWrite reports whether c may change a blog written by authorID.
+func (c *caller) canWrite(authorID string) bool {
+	return c.Admin || (c.AuthorID != "" && c.AuthorID == authorID)
+}
+
+type callerKey struct{}
+
+// callerFromContext returns the caller the auth interceptors stored in ctx,
+// or nil when authentication is disabled.
+func callerFromContext(ctx context.Context) *caller {
+	c, _ := ctx.Value(callerKey{}).(*caller)
+	return c
+}
+
+// authenticator verifies the JWTs sent in the "authorization" metadata as
+// "Bearer <token>". Tokens are signed with either an HMAC secret or an RSA key.
+type authenticator struct {
+	method string
+	key    interface{}
+}
+
+// newHMACAuthenticator returns an authenticator for HS256 tokens signed with
+// the secret stored in secretFile.
+func newHMACAuthenticator(secretFile string) (*authenticator, error) {
+	secret, err := ioutil.ReadFile(secretFile)
+	if err != nil {
+		return nil, err
+	}
+	secret = []byte(strings.TrimSpace(string(secret)))
+	if len(secret) == 0 {
+		return nil, errors.New("hmac secret is empty")
+	}
+	return &authenticator{method: jwt.SigningMethodHS256.Alg(), key: secret}, nil
+}
+
+// newRSAAuthenticator returns an authenticator for RS256 tokens signed with
+// the private half of the PEM encoded public key stored in publicKeyFile.
+func newRSAAuthenticator(publicKeyFile string) (*authenticator, error) {
+	pem, err := ioutil.ReadFile(publicKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	key, err := jwt.ParseRSAPublicKeyFromPEM(pem)
+	if err != nil {
+		return nil, err
+	}
+	return &authenticator{method: jwt.SigningMethodRS256.Alg(), key: key}, nil
+}
+
+// authenticate verifies the token sent with the request and returns the caller.
+func (a *authenticator) authenticate(ctx context.Context) (*caller, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "missing authorization token")
+	}
+
+	raw := strings.TrimSpace(values[0])
+	if len(raw) < len("bearer ") || !strings.EqualFold(raw[:len("bearer ")], "bearer ") {
+		return nil, status.Errorf(codes.Unauthenticated, "authorization must be a bearer token")
+	}
+
+	// only accept the configured algorithm, so an HMAC token can never be
+	// checked against an RSA public key used as a secret
+	parser := &jwt.Parser{ValidMethods: []string{a.method}}
+	claims := &blogClaims{}
+	_, err := parser.ParseWithClaims(strings.TrimSpace(raw[len("bearer "):]), claims, func(*jwt.Token) (interface{}, error) {
+		return a.key, nil
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, fmt.Sprintf("invalid token: %v", err))
+	}
+	if claims.Subject == "" {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token: missing subject")
+	}
+	// the claims are valid without an expiry, but such a token never expires
+	if claims.ExpiresAt == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token: missing expiry")
+	}
+	if time.Until(time.Unix(claims.ExpiresAt, 0)) > maxTokenLifetime {
+		return nil, status.Errorf(
+			codes.Unauthenticated,
+			fmt.Sprintf("invalid token: expires more than %v from now", maxTokenLifetime),
+		)
+	}
+
+	c := &caller{AuthorID: claims.Subject}
+	for _, role := range claims.Roles {
+		if role == adminRole {
+			c.Admin = true
+		}
+	}
+	return c, nil
+}
+
+// callerOf returns the caller of method. Callers of the read-only methods that
+// send no token are anonymous.
+func (a *authenticator) callerOf(ctx context.Context, method string) (*caller, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if !authenticatedMethods[method] && len(md.Get("authorization")) == 0 {
+		return &caller{}, nil
+	}
+	return a.authenticate(ctx)
+}
+
+func (a *authenticator) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	c, err := a.callerOf(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(context.WithValue(ctx, callerKey{}, c), req)
+}
+
+func (a *authenticator) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	c, err := a.callerOf(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &callerStream{ss, context.WithValue(ss.Context(), callerKey{}, c)})
+}
+
+// callerStream is a server stream whose context holds the caller.
+type callerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *callerStream) Context() context.Context {
+	return s.ctx
+}
+
+// checkCanWrite returns PERMISSION_DENIED unless the caller may change a blog
+// written by authorID. Everyone may when authentication is disabled.
+func checkCanWrite(ctx context.Context, authorID string) error {
+	c := callerFromContext(ctx)
+	if c == nil || c.canWrite(authorID) {
+		return nil
+	}
+	return status.Errorf(
+		codes.PermissionDenied,
+		fmt.Sprintf("%q cannot change blogs written by %q", c.AuthorID, authorID),
+	)
+}
+
+// canSee reports whether the caller may see data. Everyone sees published
+// blogs, the others are seen by those who may change them.
+func canSee(ctx context.Context, data *blogItem) bool {
+	return data.state() == statePublished || checkCanWrite(ctx, data.AuthorID) == nil
+}
+
+// checkCanListHidden returns PERMISSION_DENIED unless the caller may list the
+// hidden blogs of authorID, or of every author when it is empty. kind says
+// which blogs are hidden, such as "unpublished" or "deleted".
+func checkCanListHidden(ctx context.Context, authorID, kind string) error {
+	c := callerFromContext(ctx)
+	if c == nil || c.Admin {
+		return nil
+	}
+	if authorID == "" {
+		return status.Errorf(
+			codes.PermissionDenied,
+			fmt.Sprintf("only admins can list %s blogs of every author, set author_id", kind),
+		)
+	}
+	if !c.canWrite(authorID) {
+		return status.Errorf(
+			codes.PermissionDenied,
+			fmt.Sprintf("%q cannot list %s blogs of %q", c.AuthorID, kind, authorID),
+		)
+	}
+	return nil
+}
+
+// authorOf returns the author of a blog or comment the caller is creating,
+// requested being the author sent in the given field. It is the caller, unless
+// an admin creates it on behalf of requested. Without authentication the
+// requested author is trusted.
+func authorOf(ctx context.Context, field, requested string) (string, error) {
+	c := callerFromContext(ctx)
+	if c == nil {
+		if requested == "" {
+			return "", status.Errorf(codes.InvalidArgument, fmt.Sprintf("%s must not be empty", field))
+		}
+		return requested, nil
+	}
+	if requested == "" {
+		return c.AuthorID, nil
+	}
+	if !c.canWrite(requested) {
+		return "", status.Errorf(
+			codes.PermissionDenied,
+			fmt.Sprintf("%q cannot write on behalf of %q", c.AuthorID, requested),
+		)
+	}
+	return requested, nil
+}
+
+// loadAuthenticator returns the authenticator for the configured key, or nil
+// when authentication is disabled.
+func loadAuthenticator(hmacSecretFile, rsaPublicKeyFile string, noAuth bool) (*authenticator, error) {
+	switch {
+	case noAuth:
+		if hmacSecretFile != "" || rsaPublicKeyFile != "" {
+			return nil, errors.New("a jwt key cannot be set together with -no-auth")
+		}
+		return nil, nil
+	case hmacSecretFile != "" && rsaPublicKeyFile != "":
+		return nil, errors.New("set either an hmac secret or an rsa public key, not both")
+	case hmacSecretFile != "":
+		return newHMACAuthenticator(hmacSecretFile)
+	case rsaPublicKeyFile != "":
+		return newRSAAuthenticator(rsaPublicKeyFile)
+	}
+	return nil, errors.New("no jwt key set, pass -jwt-hmac-secret-file or -jwt-rsa-public-key-file, or -no-auth to disable authentication")
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	bpb "blog/pb"
+)
+
+const (
+	// maxBatchGetBlogs is the number of ids BatchGetBlogs takes at once.
+	maxBatchGetBlogs = 1000
+	// maxBulkCreateBlogs is the number of blogs BulkCreateBlogs creates in a
+	// single call, sending more fails the call.
+	maxBulkCreateBlogs = 10000
+	// bulkCreateChunkSize is the number of blogs BulkCreateBlogs hands to
+	// the store at once.
+	bulkCreateChunkSize = 100
+)
+
+func (s *server) BatchGetBlogs(ctx context.Context, req *bpb.BatchGetBlogsRequest) (*bpb.BatchGetBlogsResponse, error) {
+	fmt.Println("batch get blogs request")
+
+	ids := make([]primitive.ObjectID, 0, len(req.GetBlogIds()))
+	for _, blogID := range req.GetBlogIds() {
+		oid, err := primitive.ObjectIDFromHex(blogID)
+		if err != nil {
+			return nil, status.Errorf(
+				codes.InvalidArgument,
+				fmt.Sprintf("cannot parse id %q", blogID),
+			)
+		}
+		ids = append(ids, oid)
+	}
+
+	items, err := s.store.ReadMany(ctx, ids)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			fmt.Sprintf("cannot read blogs: %v", err),
+		)
+	}
+
+	found := make(map[primitive.ObjectID]*blogItem, len(items))
+	for _, data := range items {
+		if data.DeleteTime == nil && canSee(ctx, data) {
+			found[data.ID] = data
+		}
+	}
+
+	res := &bpb.BatchGetBlogsResponse{}
+	var ordered []*blogItem
+	for i, oid := range ids {
+		if data, ok := found[oid]; ok {
+			ordered = append(ordered, data)
+		} else {
+			res.NotFoundIds = append(res.NotFoundIds, req.GetBlogIds()[i])
+		}
+	}
+
+	res.Blogs, err = s.blogsToPb(ctx, ordered)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *server) BulkCreateBlogs(stream bpb.BlogService_BulkCreateBlogsServer) error {
+	fmt.Println("bulk create blogs request")
+
+	var results []*bpb.BulkCreateBlogsResponse_Result
+	var pending []*blogItem
+	var pendingIndexes []int32
+
+	flush := func() {
+		if len(pending) == 0 {
+			return
+		}
+		created, errs := s.store.CreateMany(stream.Context(), pending)
+		for i, index := range pendingIndexes {
+			if errs[i] != nil {
+				results[index].Code = int32(codes.Internal)
+				results[index].Message = fmt.Sprintf("cannot create blog: %v", errs[i])
+				continue
+			}
+			results[index].Blog = dataToBlogPb(created[i])
+		}
+		pending, pendingIndexes = pending[:0], pendingIndexes[:0]
+	}
+
+	for index := int32(0); ; index++ {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		// the results are kept until the stream ends, so the stream stops
+		// being read once it is too long
+		if index >= maxBulkCreateBlogs {
+			return status.Errorf(
+				codes.ResourceExhausted,
+				fmt.Sprintf("cannot create more than %d blogs at once", maxBulkCreateBlogs),
+			)
+		}
+
+		result := &bpb.BulkCreateBlogsResponse_Result{Index: index}
+		results = append(results, result)
+
+		// a bad blog only fails its own result, not the whole stream
+		if err := validateRequest(&bpb.CreateBlogRequest{Blog: req.GetBlog()}); err != nil {
+			st := status.Convert(err)
+			result.Code = int32(st.Code())
+			result.Message = st.Message()
+			continue
+		}
+
+		authorID, err := authorOf(stream.Context(), "blog.author_id", req.GetBlog().GetAuthorId())
+		if err != nil {
+			st := status.Convert(err)
+			result.Code = int32(st.Code())
+			result.Message = st.Message()
+			continue
+		}
+
+		pending = append(pending, newBlogItem(req.GetBlog(), authorID))
+		pendingIndexes = append(pendingIndexes, index)
+		if len(pending) == bulkCreateChunkSize {
+			flush()
+		}
+	}
+	flush()
+
+	return stream.SendAndClose(&bpb.BulkCreateBlogsResponse{Results: results})
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// errCommentNotFound is returned by a CommentStore when no comment matches the
+// given id.
+var errCommentNotFound = errors.New("comment not found")
+
+type commentItem struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	BlogID     primitive.ObjectID `bson:"blog_id"`
+	AuthorID   string             `bson:"author_id"`
+	Content    string             `bson:"content"`
+	CreateTime time.Time          `bson:"create_time"`
+	UpdateTime time.Time          `bson:"update_time"`
+}
+
+// CommentStore is the storage backend of the comments on blogs.
+type CommentStore interface {
+	// Create stores a new comment and returns it with its assigned id.
+	Create(ctx context.Context, data *commentItem) (*commentItem, error)
+	// Read returns the comment with the given id.
+	Read(ctx context.Context, id primitive.ObjectID) (*commentItem, error)
+	// Update replaces the stored comment that has the same id as data.
+	Update(ctx context.Context, data *commentItem) (*commentItem, error)
+	// Delete removes the comment with the given id.
+	Delete(ctx context.Context, id primitive.ObjectID) error
+	// DeleteBlog removes every comment on a blog.
+	DeleteBlog(ctx context.Context, blogID primitive.ObjectID) error
+	// List calls fn for the comments on a blog, oldest first, until fn
+	// returns an error. Only comments after the one with id after are listed
+	// unless after is zero, and at most limit comments unless limit is 0.
+	List(ctx context.Context, blogID, after primitive.ObjectID, limit int, fn func(*commentItem) error) error
+	// Count returns the number of comments on each of the given blogs. Blogs
+	// without comments are left out.
+	Count(ctx context.Context, blogIDs []primitive.ObjectID) (map[primitive.ObjectID]int64, error)
+}
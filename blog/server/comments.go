@@ -0,0 +1,261 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	bpb "blog/pb"
+)
+
+// commentServer implements CommentService. It reads blogs from the blog store
+// to check that the comments it serves belong to a blog that can be seen.
+type commentServer struct {
+	blogs    BlogStore
+	comments CommentStore
+}
+
+func (s *commentServer) CreateComment(ctx context.Context, req *bpb.CreateCommentRequest) (*bpb.CreateCommentResponse, error) {
+	fmt.Println("create comment request")
+
+	comment := req.GetComment()
+	blogID, err := primitive.ObjectIDFromHex(comment.GetBlogId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("cannot parse blog id"),
+		)
+	}
+
+	if _, err := s.readBlog(ctx, blogID); err != nil {
+		return nil, err
+	}
+
+	authorID, err := authorOf(ctx, "comment.author_id", comment.GetAuthorId())
+	if err != nil {
+		return nil, err
+	}
+
+	now := now()
+	data, err := s.comments.Create(ctx, &commentItem{
+		BlogID:     blogID,
+		AuthorID:   authorID,
+		Content:    comment.GetContent(),
+		CreateTime: now,
+		UpdateTime: now,
+	})
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			fmt.Sprintf("internal error: %v", err),
+		)
+	}
+
+	return &bpb.CreateCommentResponse{
+		Comment: commentToPb(data),
+	}, nil
+}
+
+func (s *commentServer) ListComments(ctx context.Context, req *bpb.ListCommentsRequest) (*bpb.ListCommentsResponse, error) {
+	fmt.Println("list comments request")
+
+	blogID, err := primitive.ObjectIDFromHex(req.GetBlogId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("cannot parse blog id"),
+		)
+	}
+
+	size, err := pageSize(req.GetPageSize())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	after, err := decodeCommentPageToken(req.GetPageToken())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	if _, err := s.readBlog(ctx, blogID); err != nil {
+		return nil, err
+	}
+
+	// fetch one comment past the page to find out whether there is a next page
+	res := &bpb.ListCommentsResponse{}
+	var last *commentItem
+	err = s.comments.List(ctx, blogID, after, size+1, func(data *commentItem) error {
+		if len(res.Comments) == size {
+			res.NextPageToken = encodeCommentPageToken(last.ID)
+			return nil
+		}
+		res.Comments = append(res.Comments, commentToPb(data))
+		last = data
+		return nil
+	})
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			fmt.Sprintf("cannot list comments: %v", err),
+		)
+	}
+	return res, nil
+}
+
+func (s *commentServer) UpdateComment(ctx context.Context, req *bpb.UpdateCommentRequest) (*bpb.UpdateCommentResponse, error) {
+	fmt.Println("update comment request")
+
+	comment := req.GetComment()
+	oid, err := primitive.ObjectIDFromHex(comment.GetId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("cannot parse id"),
+		)
+	}
+
+	data, _, err := s.readComment(ctx, oid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkCanChangeComment(ctx, data); err != nil {
+		return nil, err
+	}
+
+	data.Content = comment.GetContent()
+	data.UpdateTime = now()
+
+	data, err = s.comments.Update(ctx, data)
+	if err != nil {
+		return nil, storeError(err, "cannot update comment")
+	}
+
+	return &bpb.UpdateCommentResponse{
+		Comment: commentToPb(data),
+	}, nil
+}
+
+func (s *commentServer) DeleteComment(ctx context.Context, req *bpb.DeleteCommentRequest) (*bpb.DeleteCommentResponse, error) {
+	fmt.Println("delete comment request")
+
+	oid, err := primitive.ObjectIDFromHex(req.GetCommentId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("cannot parse id"),
+		)
+	}
+
+	data, blog, err := s.readComment(ctx, oid)
+	if err != nil {
+		return nil, err
+	}
+
+	// authors moderate the comments on their blogs
+	if err := checkCanChangeComment(ctx, data, blog.AuthorID); err != nil {
+		return nil, err
+	}
+
+	if err := s.comments.Delete(ctx, oid); err != nil {
+		return nil, storeError(err, "cannot delete comment")
+	}
+
+	return &bpb.DeleteCommentResponse{CommentId: req.GetCommentId()}, nil
+}
+
+// readBlog returns the blog with the given id, or NOT_FOUND if it does not
+// exist, is in the trash or cannot be seen by the caller.
+func (s *commentServer) readBlog(ctx context.Context, blogID primitive.ObjectID) (*blogItem, error) {
+	blog, err := s.blogs.Read(ctx, blogID)
+	if err != nil {
+		return nil, storeError(err, "cannot find blog with specified id")
+	}
+	if !canSee(ctx, blog) {
+		return nil, status.Errorf(
+			codes.NotFound,
+			fmt.Sprintf("cannot find blog with specified id"),
+		)
+	}
+	if blog.DeleteTime != nil {
+		return nil, status.Errorf(
+			codes.NotFound,
+			fmt.Sprintf("cannot find blog with specified id: blog is in the trash"),
+		)
+	}
+	return blog, nil
+}
+
+// readComment returns the comment with the given id and the blog it belongs
+// to. The comments of a blog in the trash are hidden, so they are not found.
+func (s *commentServer) readComment(ctx context.Context, oid primitive.ObjectID) (*commentItem, *blogItem, error) {
+	data, err := s.comments.Read(ctx, oid)
+	if err != nil {
+		return nil, nil, storeError(err, "cannot find comment with specified id")
+	}
+
+	blog, err := s.blogs.Read(ctx, data.BlogID)
+	if err == errBlogNotFound || (err == nil && (blog.DeleteTime != nil || !canSee(ctx, blog))) {
+		return nil, nil, status.Errorf(
+			codes.NotFound,
+			fmt.Sprintf("cannot find comment with specified id: its blog is gone, in the trash or not published"),
+		)
+	}
+	if err != nil {
+		return nil, nil, storeError(err, "cannot find blog of comment")
+	}
+	return data, blog, nil
+}
+
+// checkCanChangeComment returns PERMISSION_DENIED unless the caller wrote the
+// comment or is one of the other authors allowed to change it.
+func checkCanChangeComment(ctx context.Context, data *commentItem, others ...string) error {
+	c := callerFromContext(ctx)
+	if c == nil || c.canWrite(data.AuthorID) {
+		return nil
+	}
+	for _, authorID := range others {
+		if c.canWrite(authorID) {
+			return nil
+		}
+	}
+	return status.Errorf(
+		codes.PermissionDenied,
+		fmt.Sprintf("%q cannot change comments written by %q", c.AuthorID, data.AuthorID),
+	)
+}
+
+func commentToPb(data *commentItem) *bpb.Comment {
+	return &bpb.Comment{
+		Id:         data.ID.Hex(),
+		BlogId:     data.BlogID.Hex(),
+		AuthorId:   data.AuthorID,
+		Content:    data.Content,
+		CreateTime: timestampProto(data.CreateTime),
+		UpdateTime: timestampProto(data.UpdateTime),
+	}
+}
+
+// encodeCommentPageToken returns a token that resumes a comment listing right
+// after the comment with the given id.
+func encodeCommentPageToken(last primitive.ObjectID) string {
+	return last.Hex()
+}
+
+// decodeCommentPageToken is the inverse of encodeCommentPageToken. An empty
+// token decodes to the zero id, which lists from the oldest comment.
+func decodeCommentPageToken(token string) (primitive.ObjectID, error) {
+	if token == "" {
+		return primitive.NilObjectID, nil
+	}
+
+	oid, err := primitive.ObjectIDFromHex(token)
+	if err != nil {
+		return primitive.NilObjectID, errors.New("malformed page token")
+	}
+	return oid, nil
+}
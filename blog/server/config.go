@@ -0,0 +1,240 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"lifecycle"
+)
+
+// envPrefix starts the name of the environment variable of every flag, so
+// -mongo-uri is read from BLOG_MONGO_URI.
+const envPrefix = "BLOG_"
+
+// config is the configuration of the blog server. Every setting is taken from,
+// in order of precedence, its flag, its environment variable, the config file
+// and its default.
+type config struct {
+	Listen string      `yaml:"listen"`
+	Store  string      `yaml:"store"`
+	Mongo  mongoConfig `yaml:"mongo"`
+	Auth   authConfig  `yaml:"auth"`
+	// IdempotencyWindow is how long the request ids of CreateBlog calls are
+	// remembered.
+	IdempotencyWindow duration `yaml:"idempotency_window"`
+	// PublishInterval is how often scheduled blogs are checked for
+	// publishing.
+	PublishInterval duration `yaml:"publish_interval"`
+	// DrainTimeout is how long in-flight rpcs get to finish when the server
+	// stops.
+	DrainTimeout duration `yaml:"drain_timeout"`
+}
+
+type mongoConfig struct {
+	URI      string `yaml:"uri"`
+	Database string `yaml:"database"`
+	// Collection holds the blogs. Revisions, comments and request ids are
+	// kept in collections named after it, such as blog_revisions.
+	Collection string `yaml:"collection"`
+}
+
+type authConfig struct {
+	HMACSecretFile   string `yaml:"jwt_hmac_secret_file"`
+	RSAPublicKeyFile string `yaml:"jwt_rsa_public_key_file"`
+	NoAuth           bool   `yaml:"no_auth"`
+}
+
+func defaultConfig() config {
+	return config{
+		Listen: "0.0.0.0:50051",
+		Store:  "mongo",
+		Mongo: mongoConfig{
+			URI:        "mongodb://localhost:27017/blog_mongo",
+			Database:   "blog_mongo",
+			Collection: "blog",
+		},
+		IdempotencyWindow: duration(24 * time.Hour),
+		PublishInterval:   duration(10 * time.Second),
+		DrainTimeout:      duration(lifecycle.DefaultDrainTimeout),
+	}
+}
+
+// collection returns the name of the collection that keeps the given kind of
+// blog data, such as "revisions".
+func (c *mongoConfig) collection(kind string) string {
+	return c.Collection + "_" + kind
+}
+
+// runOptions are the command line options that are not settings of the server.
+type runOptions struct {
+	configFile  string
+	printConfig bool
+}
+
+// loadConfig builds the configuration from the command line arguments, the
+// environment and the config file named by -config or BLOG_CONFIG. The file is
+// YAML, which JSON files are also valid as.
+func loadConfig(args []string, lookupEnv func(string) (string, bool)) (*config, runOptions, error) {
+	cfg := defaultConfig()
+	var opts runOptions
+
+	fs := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	fs.StringVar(&opts.configFile, "config", "", "YAML or JSON file to read settings from, also read from "+envPrefix+"CONFIG")
+	fs.BoolVar(&opts.printConfig, "print-config", false, "print the configuration and exit")
+	fs.StringVar(&cfg.Listen, "listen", cfg.Listen, "address to serve on")
+	fs.StringVar(&cfg.Store, "store", cfg.Store, "blog storage backend: mongo or memory")
+	fs.StringVar(&cfg.Mongo.URI, "mongo-uri", cfg.Mongo.URI, "mongo db connection string")
+	fs.StringVar(&cfg.Mongo.Database, "mongo-database", cfg.Mongo.Database, "mongo db database")
+	fs.StringVar(&cfg.Mongo.Collection, "mongo-collection", cfg.Mongo.Collection, "mongo db collection of the blogs, other collections are named after it")
+	fs.StringVar(&cfg.Auth.HMACSecretFile, "jwt-hmac-secret-file", "", "file holding the secret that signs HS256 tokens")
+	fs.StringVar(&cfg.Auth.RSAPublicKeyFile, "jwt-rsa-public-key-file", "", "PEM file holding the public key that verifies RS256 tokens")
+	fs.BoolVar(&cfg.Auth.NoAuth, "no-auth", false, "let anyone change any blog, for local development")
+	fs.Var(&cfg.IdempotencyWindow, "idempotency-window", "how long the request ids of CreateBlog calls are remembered")
+	fs.Var(&cfg.PublishInterval, "publish-interval", "how often scheduled blogs are checked for publishing")
+	fs.Var(&cfg.DrainTimeout, "drain-timeout", "how long in-flight rpcs get to finish when the server stops")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage of %s:\n", args[0])
+		fs.PrintDefaults()
+		fmt.Fprintf(fs.Output(), "\nEvery flag can also be set with an environment variable, such as %sMONGO_URI for -mongo-uri.\n", envPrefix)
+	}
+
+	// the flags are parsed once to find the config file, and again after the
+	// file and the environment are read so they take precedence
+	if err := fs.Parse(args[1:]); err != nil {
+		return nil, opts, err
+	}
+	if fs.NArg() > 0 {
+		return nil, opts, fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+	if opts.configFile == "" {
+		opts.configFile, _ = lookupEnv(envPrefix + "CONFIG")
+	}
+
+	cfg = defaultConfig()
+	if opts.configFile != "" {
+		if err := readConfigFile(opts.configFile, &cfg); err != nil {
+			return nil, opts, err
+		}
+	}
+
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		if err != nil || f.Name == "config" || f.Name == "print-config" {
+			return
+		}
+		name := envPrefix + strings.ToUpper(strings.Replace(f.Name, "-", "_", -1))
+		if v, ok := lookupEnv(name); ok {
+			if setErr := fs.Set(f.Name, v); setErr != nil {
+				err = fmt.Errorf("invalid value %q for %s: %v", v, name, setErr)
+			}
+		}
+	})
+	if err != nil {
+		return nil, opts, err
+	}
+
+	if err := fs.Parse(args[1:]); err != nil {
+		return nil, opts, err
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, opts, err
+	}
+	return &cfg, opts, nil
+}
+
+// readConfigFile reads the settings in the given file into cfg. Settings the
+// file leaves out keep their value.
+func readConfigFile(name string, cfg *config) error {
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		return fmt.Errorf("cannot read config file: %v", err)
+	}
+	if err := yaml.UnmarshalStrict(b, cfg); err != nil {
+		return fmt.Errorf("cannot parse config file %s: %v", name, err)
+	}
+	return nil
+}
+
+// validate reports every invalid setting of c.
+func (c *config) validate() error {
+	var problems []string
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+	}
+
+	_, _, err := net.SplitHostPort(c.Listen)
+	check(err == nil, "listen must be a host:port address, got %q", c.Listen)
+	check(c.Store == "mongo" || c.Store == "memory", "store must be mongo or memory, got %q", c.Store)
+	if c.Store == "mongo" {
+		u, err := url.Parse(c.Mongo.URI)
+		check(err == nil && (u.Scheme == "mongodb" || u.Scheme == "mongodb+srv") && u.Host != "",
+			"mongo uri must be a mongodb:// or mongodb+srv:// connection string")
+		check(c.Mongo.Database != "", "mongo database must be set")
+		check(c.Mongo.Collection != "", "mongo collection must be set")
+	}
+	check(c.IdempotencyWindow > 0, "idempotency window must be positive")
+	check(c.PublishInterval > 0, "publish interval must be positive")
+	check(c.DrainTimeout > 0, "drain timeout must be positive")
+
+	if len(problems) > 0 {
+		return errors.New("invalid configuration: " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// print writes c as YAML, in the format of the config file. The password of
+// the mongo uri is hidden.
+func (c config) print() error {
+	if u, err := url.Parse(c.Mongo.URI); err == nil && u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), "xxxxx")
+			c.Mongo.URI = u.String()
+		}
+	}
+	b, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(b)
+	return err
+}
+
+// duration is a time.Duration written as a string such as "10s" in flags and
+// config files.
+type duration time.Duration
+
+func (d *duration) String() string {
+	return time.Duration(*d).String()
+}
+
+func (d *duration) Set(s string) error {
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = duration(v)
+	return nil
+}
+
+func (d duration) MarshalYAML() (interface{}, error) {
+	return time.Duration(d).String(), nil
+}
+
+func (d *duration) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return d.Set(s)
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+)
+
+// eventType is the kind of change a blogEvent reports.
+type eventType int
+
+const (
+	eventCreated eventType = iota + 1
+	eventUpdated
+	// eventDeleted reports a blog moved to the trash.
+	eventDeleted
+	// eventPurged reports a blog removed for good. Only the id of its blog
+	// is set.
+	eventPurged
+)
+
+var (
+	// errInvalidResumeToken is returned by BlogStore.Watch when it cannot
+	// make sense of a resume token.
+	errInvalidResumeToken = errors.New("invalid resume token")
+	// errResumeTokenExpired is returned by BlogStore.Watch when the events
+	// after a resume token are no longer available.
+	errResumeTokenExpired = errors.New("resume token expired")
+	// errWatchTooSlow is returned by BlogStore.Watch when the watcher fell
+	// too far behind the events. It can resume from the last token it got.
+	errWatchTooSlow = errors.New("watcher fell behind the blog changes")
+)
+
+// blogEvent is a change to a blog, as reported by BlogStore.Watch.
+type blogEvent struct {
+	Type eventType
+	Blog blogItem
+	// ResumeToken resumes a watch right after this event.
+	ResumeToken string
+}
+
+// watchEventType tells which event writing data creates, given whether the
+// write inserted it.
+func watchEventType(data *blogItem, inserted bool) eventType {
+	switch {
+	case inserted:
+		return eventCreated
+	case data.DeleteTime != nil:
+		return eventDeleted
+	}
+	return eventUpdated
+}
+
+const (
+	// feedBacklog is the number of past events an eventFeed keeps around for
+	// watchers that resume.
+	feedBacklog = 1024
+	// feedBuffer is the number of events a watcher can fall behind before it
+	// is dropped.
+	feedBuffer = 64
+)
+
+// eventFeed fans blog events out to in-process watchers. Every event gets a
+// sequence number that serves as its resume token.
+type eventFeed struct {
+	mu       sync.Mutex
+	seq      int64
+	backlog  []blogEvent
+	watchers map[*feedWatcher]struct{}
+}
+
+type feedWatcher struct {
+	authorID string
+	events   chan blogEvent
+	// dropped is closed when the watcher fell behind and stops getting events
+	dropped chan struct{}
+}
+
+func newEventFeed() *eventFeed {
+	return &eventFeed{watchers: make(map[*feedWatcher]struct{})}
+}
+
+// publish sends an event about data to every watcher.
+func (f *eventFeed) publish(typ eventType, data *blogItem) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.seq++
+	ev := blogEvent{Type: typ, Blog: *data, ResumeToken: strconv.FormatInt(f.seq, 10)}
+	f.backlog = append(f.backlog, ev)
+	if len(f.backlog) > feedBacklog {
+		f.backlog = f.backlog[len(f.backlog)-feedBacklog:]
+	}
+
+	for w := range f.watchers {
+		if !w.matches(&ev) {
+			continue
+		}
+		select {
+		case w.events <- ev:
+		default:
+			delete(f.watchers, w)
+			close(w.dropped)
+		}
+	}
+}
+
+// watch registers a watcher for the events of the given author, or of every
+// author when authorID is empty. The events published after resumeToken are
+// queued up first.
+func (f *eventFeed) watch(authorID, resumeToken string) (*feedWatcher, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w := &feedWatcher{
+		authorID: authorID,
+		events:   make(chan blogEvent, feedBuffer+feedBacklog),
+		dropped:  make(chan struct{}),
+	}
+
+	if resumeToken != "" {
+		after, err := strconv.ParseInt(resumeToken, 10, 64)
+		if err != nil || after < 0 || after > f.seq {
+			return nil, errInvalidResumeToken
+		}
+		// the backlog must still hold every event after the token
+		oldest := f.seq - int64(len(f.backlog)) + 1
+		if after+1 < oldest {
+			return nil, errResumeTokenExpired
+		}
+		for _, ev := range f.backlog {
+			seq, _ := strconv.ParseInt(ev.ResumeToken, 10, 64)
+			if seq > after && w.matches(&ev) {
+				w.events <- ev
+			}
+		}
+	}
+
+	f.watchers[w] = struct{}{}
+	return w, nil
+}
+
+// next calls fn for every event w gets until ctx is done, fn fails or w is
+// dropped.
+func (w *feedWatcher) next(ctx context.Context, fn func(*blogEvent) error) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case ev := <-w.events:
+			if err := fn(&ev); err != nil {
+				return err
+			}
+		case <-w.dropped:
+			// hand out what was queued before the drop, then give up
+			for {
+				select {
+				case ev := <-w.events:
+					if err := fn(&ev); err != nil {
+						return err
+					}
+				default:
+					return errWatchTooSlow
+				}
+			}
+		}
+	}
+}
+
+// stop unregisters w.
+func (f *eventFeed) stop(w *feedWatcher) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.watchers, w)
+}
+
+func (w *feedWatcher) matches(ev *blogEvent) bool {
+	return w.authorID == "" || ev.Blog.AuthorID == w.authorID
+}
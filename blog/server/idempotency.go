@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	bpb "blog/pb"
+)
+
+// createOnce creates the blog of a CreateBlog call sent with a request id. A
+// retry of an earlier call gets the blog that call created.
+func (s *server) createOnce(ctx context.Context, req *bpb.CreateBlogRequest, authorID string) (*blogItem, error) {
+	hash, err := requestHash(req.GetBlog(), authorID)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			fmt.Sprintf("internal error: %v", err),
+		)
+	}
+
+	key := requestKey(ctx, req.GetRequestId())
+	stored, err := s.requests.Reserve(ctx, &requestItem{Key: key, Hash: hash, CreateTime: now()})
+	if err == errRequestExists {
+		return s.replayCreate(ctx, stored, hash)
+	}
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			fmt.Sprintf("cannot reserve request id: %v", err),
+		)
+	}
+
+	data, err := s.store.Create(ctx, newBlogItem(req.GetBlog(), authorID))
+	if err != nil {
+		if err := s.requests.Release(ctx, key); err != nil {
+			log.Printf("cannot release request id %q: %v", key, err)
+		}
+		return nil, status.Errorf(
+			codes.Internal,
+			fmt.Sprintf("internal error: %v", err),
+		)
+	}
+
+	// the blog exists, so the call succeeds even if it cannot be replayed,
+	// but the request id is released so retries are not aborted forever
+	created := *data
+	if err := s.requests.Complete(ctx, key, &created); err != nil {
+		log.Printf("cannot record blog %s for request id %q: %v", data.ID.Hex(), key, err)
+		if err := s.requests.Release(ctx, key); err != nil {
+			log.Printf("cannot release request id %q: %v", key, err)
+		}
+	}
+	return data, nil
+}
+
+// replayCreate returns the blog created by the earlier call recorded as stored,
+// as it was when that call created it.
+func (s *server) replayCreate(ctx context.Context, stored *requestItem, hash string) (*blogItem, error) {
+	if stored.Hash != hash {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			fmt.Sprintf("request_id was already used to create another blog"),
+		)
+	}
+	if stored.Blog == nil {
+		return nil, status.Errorf(
+			codes.Aborted,
+			fmt.Sprintf("a call with the same request_id is still running, retry later"),
+		)
+	}
+	return stored.Blog, nil
+}
+
+// requestKey scopes a request id to the caller, so callers cannot see each
+// other's blogs by guessing request ids.
+func requestKey(ctx context.Context, requestID string) string {
+	if c := callerFromContext(ctx); c != nil {
+		return c.AuthorID + "/" + requestID
+	}
+	return "/" + requestID
+}
+
+// requestHash identifies the blog a CreateBlog call asks for.
+func requestHash(blog *bpb.Blog, authorID string) (string, error) {
+	b, err := proto.Marshal(blog)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	h.Write([]byte(authorID))
+	h.Write([]byte{0})
+	h.Write(b)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
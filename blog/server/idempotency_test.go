@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	bpb "blog/pb"
+)
+
+// countBlogs returns the number of blogs in the store of s.
+func countBlogs(t *testing.T, s *server) int {
+	t.Helper()
+	n := 0
+	err := s.store.List(context.Background(), listQuery{}, func(*blogItem) error {
+		n++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	return n
+}
+
+func TestCreateBlogRetry(t *testing.T) {
+	s := newTestServer()
+	req := &bpb.CreateBlogRequest{
+		RequestId: "retry-1",
+		Blog:      &bpb.Blog{AuthorId: "alice", Title: "title", Content: "content"},
+	}
+
+	// the reply of the first call is lost, so the client retries
+	if _, err := s.CreateBlog(context.Background(), req); err != nil {
+		t.Fatalf("CreateBlog: %v", err)
+	}
+	res, err := s.CreateBlog(context.Background(), req)
+	if err != nil {
+		t.Fatalf("retried CreateBlog: %v", err)
+	}
+	first := res.GetBlog()
+
+	// a blog changed since it was created is replayed as it was created
+	_, err = s.UpdateBlog(context.Background(), &bpb.UpdateBlogRequest{
+		Blog: &bpb.Blog{Id: first.GetId(), AuthorId: "alice", Title: "changed", Content: "content"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateBlog: %v", err)
+	}
+	res, err = s.CreateBlog(context.Background(), req)
+	if err != nil {
+		t.Fatalf("retried CreateBlog: %v", err)
+	}
+	if res.GetBlog().GetId() != first.GetId() {
+		t.Errorf("retry returned blog %s, want %s", res.GetBlog().GetId(), first.GetId())
+	}
+	if res.GetBlog().GetTitle() != "title" {
+		t.Errorf("retry returned title %q, want the created %q", res.GetBlog().GetTitle(), "title")
+	}
+
+	if n := countBlogs(t, s); n != 1 {
+		t.Errorf("store holds %d blogs, want 1", n)
+	}
+}
+
+func TestCreateBlogReusedRequestID(t *testing.T) {
+	s := newTestServer()
+	req := &bpb.CreateBlogRequest{
+		RequestId: "reused-1",
+		Blog:      &bpb.Blog{AuthorId: "alice", Title: "title", Content: "content"},
+	}
+	if _, err := s.CreateBlog(context.Background(), req); err != nil {
+		t.Fatalf("CreateBlog: %v", err)
+	}
+
+	other := &bpb.CreateBlogRequest{
+		RequestId: req.GetRequestId(),
+		Blog:      &bpb.Blog{AuthorId: "alice", Title: "another title", Content: "content"},
+	}
+	_, err := s.CreateBlog(context.Background(), other)
+	if code := status.Code(err); code != codes.FailedPrecondition {
+		t.Errorf("CreateBlog with a reused request_id failed with %v, want %v: %v", code, codes.FailedPrecondition, err)
+	}
+
+	if n := countBlogs(t, s); n != 1 {
+		t.Errorf("store holds %d blogs, want 1", n)
+	}
+}
+
+// failingRequestStore is a RequestStore that fails to complete requests.
+type failingRequestStore struct {
+	RequestStore
+}
+
+func (failingRequestStore) Complete(ctx context.Context, key string, blog *blogItem) error {
+	return errors.New("complete failed")
+}
+
+func TestCreateBlogReleasesUncompletedRequestID(t *testing.T) {
+	s := newTestServer()
+	s.requests = failingRequestStore{newMemoryRequestStore(time.Hour)}
+	req := &bpb.CreateBlogRequest{
+		RequestId: "uncompleted-1",
+		Blog:      &bpb.Blog{AuthorId: "alice", Title: "title", Content: "content"},
+	}
+
+	if _, err := s.CreateBlog(context.Background(), req); err != nil {
+		t.Fatalf("CreateBlog: %v", err)
+	}
+	// the request id was not recorded, so it must not stay reserved
+	if _, err := s.CreateBlog(context.Background(), req); err != nil {
+		t.Errorf("retried CreateBlog: %v", err)
+	}
+}
+
+func TestMemoryRequestStoreExpires(t *testing.T) {
+	m := newMemoryRequestStore(time.Hour)
+	ctx := context.Background()
+	start := time.Now()
+
+	reserve := func(key string, at time.Time) error {
+		_, err := m.Reserve(ctx, &requestItem{Key: key, Hash: "h", CreateTime: at})
+		return err
+	}
+	if err := reserve("a", start); err != nil {
+		t.Fatalf("Reserve(a): %v", err)
+	}
+	if err := reserve("b", start.Add(30*time.Minute)); err != nil {
+		t.Fatalf("Reserve(b): %v", err)
+	}
+
+	// released and reserved again, a's first reservation must not expire
+	// the second one
+	if err := m.Release(ctx, "a"); err != nil {
+		t.Fatalf("Release(a): %v", err)
+	}
+	if err := reserve("a", start.Add(40*time.Minute)); err != nil {
+		t.Fatalf("Reserve(a) again: %v", err)
+	}
+
+	at := start.Add(61 * time.Minute)
+	if err := reserve("a", at); err != errRequestExists {
+		t.Errorf("Reserve(a) at %v = %v, want %v", at.Sub(start), err, errRequestExists)
+	}
+	if err := reserve("b", at); err != errRequestExists {
+		t.Errorf("Reserve(b) at %v = %v, want %v", at.Sub(start), err, errRequestExists)
+	}
+
+	at = start.Add(91 * time.Minute)
+	if err := reserve("b", at); err != nil {
+		t.Errorf("Reserve(b) at %v = %v, want it expired", at.Sub(start), err)
+	}
+	if len(m.requests) != 2 || len(m.reserved) != 2 {
+		t.Errorf("store holds %d requests and %d reservations, want 2 and 2", len(m.requests), len(m.reserved))
+	}
+}
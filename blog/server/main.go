@@ -2,67 +2,64 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net"
 	"os"
-	"os/signal"
+	"strings"
+	"time"
 
-	"go.mongodb.org/mongo-driver/bson"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/genproto/protobuf/field_mask"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 
 	bpb "blog/pb"
+	"blog/render"
+	"lifecycle"
 )
 
-var collection *mongo.Collection
-
-type server struct{}
-
-type blogItem struct {
-	ID       primitive.ObjectID `bson:"_id,omitempty"`
-	AuthorID string             `bson:"author_id"`
-	Content  string             `bson:"content"`
-	Title    string             `bson:"title"`
+type server struct {
+	store    BlogStore
+	comments CommentStore
+	requests RequestStore
+	// stopping is done once the server starts shutting down, which ends the
+	// streams that would otherwise never end.
+	stopping context.Context
 }
 
 func (s *server) CreateBlog(ctx context.Context, req *bpb.CreateBlogRequest) (*bpb.CreateBlogResponse, error) {
 	fmt.Println("create blog request")
 
-	blog := req.GetBlog()
-
-	data := blogItem{
-		AuthorID: blog.GetAuthorId(),
-		Content:  blog.GetTitle(),
-		Title:    blog.GetTitle(),
-	}
-
-	res, err := collection.InsertOne(ctx, data)
+	authorID, err := authorOf(ctx, "blog.author_id", req.GetBlog().GetAuthorId())
 	if err != nil {
-		return nil, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("internal error: %v", err))
+		return nil, err
 	}
 
-	oid, ok := res.InsertedID.(primitive.ObjectID)
-	if !ok {
-		return nil, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("cannot convert to oid: %v", err))
+	var data *blogItem
+	if req.GetRequestId() != "" {
+		data, err = s.createOnce(ctx, req, authorID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		data, err = s.store.Create(ctx, newBlogItem(req.GetBlog(), authorID))
+		if err != nil {
+			return nil, status.Errorf(
+				codes.Internal,
+				fmt.Sprintf("internal error: %v", err))
+		}
 	}
 
 	return &bpb.CreateBlogResponse{
-		Blog: &bpb.Blog{
-			Id:       oid.Hex(),
-			AuthorId: blog.GetAuthorId(),
-			Content:  blog.GetContent(),
-			Title:    blog.GetTitle(),
-		},
+		Blog: dataToBlogPb(data),
 	}, nil
 }
 
@@ -77,19 +74,33 @@ func (s *server) ReadBlog(ctx context.Context, req *bpb.ReadBlogRequest) (*bpb.R
 		)
 	}
 
-	data := &blogItem{}
-	filter := bson.M{"_id": oid}
-
-	res := collection.FindOne(context.Background(), filter)
-	if err := res.Decode(data); err != nil {
+	data, err := s.store.Read(ctx, oid)
+	if err != nil {
+		return nil, storeError(err, "cannot find blog with specified id")
+	}
+	if data.DeleteTime != nil {
+		return nil, status.Errorf(
+			codes.NotFound,
+			fmt.Sprintf("cannot find blog with specified id: blog is in the trash"),
+		)
+	}
+	if !canSee(ctx, data) {
 		return nil, status.Errorf(
 			codes.NotFound,
-			fmt.Sprintf("cannot find blog with specified id: %v", err),
+			fmt.Sprintf("cannot find blog with specified id"),
 		)
 	}
 
+	blog, err := s.blogToPb(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetRenderHtml() {
+		blog.ContentHtml = render.HTML(data.Content, data.ContentFormat)
+	}
+
 	return &bpb.ReadBlogResponse{
-		Blog: dataToBlogPb(data),
+		Blog: blog,
 	}, nil
 }
 
@@ -105,31 +116,57 @@ func (s *server) UpdateBlog(ctx context.Context, req *bpb.UpdateBlogRequest) (*b
 		)
 	}
 
-	data := &blogItem{}
-	filter := bson.M{"_id": oid}
+	paths, err := updatePaths(req.GetUpdateMask())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
 
-	res := collection.FindOne(context.Background(), filter)
-	if err := res.Decode(data); err != nil {
+	data, err := s.readForWrite(ctx, oid, req.GetExpectedRevision())
+	if err != nil {
+		return nil, err
+	}
+	if data.DeleteTime != nil {
 		return nil, status.Errorf(
 			codes.NotFound,
-			fmt.Sprintf("cannot find blog with specified id: %v", err),
+			fmt.Sprintf("cannot find blog with specified id: blog is in the trash"),
 		)
 	}
 
-	data.AuthorID = blog.GetAuthorId()
-	data.Content = blog.GetContent()
-	data.Title = blog.GetTitle()
+	readRevision := data.Revision
+	for _, path := range paths {
+		switch path {
+		case "author_id":
+			// handing a blog over to another author needs the same rights
+			// as changing one of theirs
+			if err := checkCanWrite(ctx, blog.GetAuthorId()); err != nil {
+				return nil, err
+			}
+			data.AuthorID = blog.GetAuthorId()
+		case "content":
+			data.Content = blog.GetContent()
+		case "content_format":
+			data.ContentFormat = contentFormats[blog.GetContentFormat()]
+		case "title":
+			data.Title = blog.GetTitle()
+		case "tags":
+			data.Tags = normalizeTags(blog.GetTags())
+		}
+	}
+	data.UpdateTime = now()
+	data.Revision++
 
-	_, err = collection.ReplaceOne(context.Background(), filter, data)
+	data, err = s.writeBlog(ctx, data, readRevision, req.GetExpectedRevision())
 	if err != nil {
-		return nil, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("cannot update object in mongodb: %v", err),
-		)
+		return nil, err
+	}
+
+	updated, err := s.blogToPb(ctx, data)
+	if err != nil {
+		return nil, err
 	}
 
 	return &bpb.UpdateBlogResponse{
-		Blog: dataToBlogPb(data),
+		Blog: updated,
 	}, nil
 }
 
@@ -145,29 +182,85 @@ func (s *server) DeleteBlog(ctx context.Context, req *bpb.DeleteBlogRequest) (*b
 		)
 	}
 
-	filter := bson.M{"_id": oid}
-	res, err := collection.DeleteOne(context.Background(), filter)
+	data, err := s.readForWrite(ctx, oid, req.GetExpectedRevision())
 	if err != nil {
-		return nil, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("cannot delete object in mongodb: %v", err),
-		)
+		return nil, err
 	}
-
-	if res.DeletedCount == 0 {
+	if data.DeleteTime != nil {
 		return nil, status.Errorf(
 			codes.NotFound,
-			fmt.Sprintf("cannot find blog in mongodb: %v", err),
+			fmt.Sprintf("cannot find blog with specified id: blog is already in the trash"),
 		)
 	}
 
+	readRevision := data.Revision
+	deleteTime := now()
+	data.DeleteTime = &deleteTime
+	data.Revision++
+
+	if _, err := s.writeBlog(ctx, data, readRevision, req.GetExpectedRevision()); err != nil {
+		return nil, err
+	}
+
 	return &bpb.DeleteBlogResponse{BlogId: req.GetBlogId()}, nil
 }
 
 func (s *server) ListBlog(req *bpb.ListBlogRequest, stream bpb.BlogService_ListBlogServer) error {
 	fmt.Println("list blog request")
 
-	cur, err := collection.Find(context.Background(), primitive.D{{}})
+	size, err := pageSize(req.GetPageSize())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	order, err := parseOrderBy(req.GetOrderBy())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	after, err := decodePageToken(req.GetPageToken(), order)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	states := []blogState{statePublished}
+	if len(req.GetStates()) > 0 {
+		states = states[:0]
+		for _, st := range req.GetStates() {
+			states = append(states, blogStates[st])
+		}
+	}
+	for _, st := range states {
+		if st != statePublished {
+			if err := checkCanListHidden(stream.Context(), req.GetAuthorId(), "unpublished"); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	if req.GetShowDeleted() {
+		if err := checkCanListHidden(stream.Context(), req.GetAuthorId(), "deleted"); err != nil {
+			return err
+		}
+	}
+
+	// fetch one blog past the page to find out whether there is a next page
+	var items []*blogItem
+	q := listQuery{
+		AuthorID:    req.GetAuthorId(),
+		Text:        req.GetQuery(),
+		AnyTags:     normalizeTags(req.GetAnyTags()),
+		AllTags:     normalizeTags(req.GetAllTags()),
+		States:      states,
+		Order:       order,
+		After:       after,
+		ShowDeleted: req.GetShowDeleted(),
+		Limit:       size + 1,
+	}
+	err = s.store.List(stream.Context(), q, func(data *blogItem) error {
+		items = append(items, data)
+		return nil
+	})
 	if err != nil {
 		return status.Errorf(
 			codes.Internal,
@@ -175,81 +268,414 @@ func (s *server) ListBlog(req *bpb.ListBlogRequest, stream bpb.BlogService_ListB
 		)
 	}
 
-	defer cur.Close(context.Background())
-	for cur.Next(context.Background()) {
-		data := &blogItem{}
-		err := cur.Decode(data)
-		if err != nil {
-			return status.Errorf(
-				codes.Internal,
-				fmt.Sprintf("error while decoding data from mongodb: %v", err),
-			)
+	more := len(items) > size
+	if more {
+		items = items[:size]
+	}
+
+	// the comment counts of a whole page are looked up at once
+	blogs, err := s.blogsToPb(stream.Context(), items)
+	if err != nil {
+		return err
+	}
+
+	for i, blog := range blogs {
+		if req.GetRenderHtml() {
+			blog.ContentHtml = render.HTML(items[i].Content, items[i].ContentFormat)
+		}
+		res := &bpb.ListBlogResponse{Blog: blog}
+		if more && i == len(blogs)-1 {
+			token, err := encodePageToken(newPageCursor(order, items[i]))
+			if err != nil {
+				return status.Errorf(
+					codes.Internal,
+					fmt.Sprintf("cannot create page token: %v", err),
+				)
+			}
+			res.NextPageToken = token
+		}
+		if err := stream.Send(res); err != nil {
+			return err
 		}
-		stream.Send(&bpb.ListBlogResponse{Blog: dataToBlogPb(data)})
 	}
-	if err := cur.Err(); err != nil {
-		return status.Errorf(
+	return nil
+}
+
+// updatablePaths are the blog fields UpdateBlog can change.
+var updatablePaths = []string{"author_id", "title", "content", "content_format", "tags"}
+
+// contentFormats maps the content formats of the api to the stored ones.
+var contentFormats = map[bpb.Blog_ContentFormat]render.Format{
+	bpb.Blog_CONTENT_FORMAT_UNSPECIFIED: render.Plain,
+	bpb.Blog_PLAIN:                      render.Plain,
+	bpb.Blog_MARKDOWN:                   render.Markdown,
+}
+
+// blogStates maps the blog states of the api to the stored ones. Blogs created
+// without a state are published, as they were before blogs had states.
+var blogStates = map[bpb.Blog_State]blogState{
+	bpb.Blog_STATE_UNSPECIFIED: statePublished,
+	bpb.Blog_DRAFT:             stateDraft,
+	bpb.Blog_SCHEDULED:         stateScheduled,
+	bpb.Blog_PUBLISHED:         statePublished,
+	bpb.Blog_ARCHIVED:          stateArchived,
+}
+
+// stateToPb is the inverse of blogStates.
+func stateToPb(s blogState) bpb.Blog_State {
+	switch s {
+	case stateDraft:
+		return bpb.Blog_DRAFT
+	case stateScheduled:
+		return bpb.Blog_SCHEDULED
+	case statePublished:
+		return bpb.Blog_PUBLISHED
+	case stateArchived:
+		return bpb.Blog_ARCHIVED
+	}
+	return bpb.Blog_STATE_UNSPECIFIED
+}
+
+// contentFormatToPb is the inverse of contentFormats.
+func contentFormatToPb(f render.Format) bpb.Blog_ContentFormat {
+	if f == render.Markdown {
+		return bpb.Blog_MARKDOWN
+	}
+	return bpb.Blog_PLAIN
+}
+
+// updatePaths returns the blog fields listed in mask, or every updatable field
+// when the mask is empty.
+func updatePaths(mask *field_mask.FieldMask) ([]string, error) {
+	if len(mask.GetPaths()) == 0 {
+		return updatablePaths, nil
+	}
+
+	for _, path := range mask.GetPaths() {
+		known := false
+		for _, p := range updatablePaths {
+			if path == p {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown path %q, expected one of %s", path, strings.Join(updatablePaths, ", "))
+		}
+	}
+	return mask.GetPaths(), nil
+}
+
+// normalizeTags trims and lower-cases tags and drops the duplicates, so tags
+// match regardless of how they were typed.
+func normalizeTags(tags []string) []string {
+	var normalized []string
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// storeError converts an error returned by a BlogStore or CommentStore into a
+// grpc status.
+func storeError(err error, msg string) error {
+	switch err {
+	case errBlogNotFound, errRevisionNotFound, errCommentNotFound:
+		return status.Errorf(codes.NotFound, fmt.Sprintf("%s: %v", msg, err))
+	case errRevisionMismatch:
+		return status.Errorf(codes.FailedPrecondition, fmt.Sprintf("%s: %v", msg, err))
+	}
+	return status.Errorf(codes.Internal, fmt.Sprintf("%s: %v", msg, err))
+}
+
+// newBlogItem returns the first revision of a new blog written by authorID.
+// The server manages the id, timestamps and revision, so they are not taken
+// from blog. The publish time is only taken from a scheduled blog.
+func newBlogItem(blog *bpb.Blog, authorID string) *blogItem {
+	now := now()
+	data := &blogItem{
+		AuthorID:      authorID,
+		Content:       blog.GetContent(),
+		ContentFormat: contentFormats[blog.GetContentFormat()],
+		Title:         blog.GetTitle(),
+		Tags:          normalizeTags(blog.GetTags()),
+		CreateTime:    now,
+		UpdateTime:    now,
+		Revision:      1,
+		State:         blogStates[blog.GetState()],
+	}
+	switch data.State {
+	case statePublished:
+		data.PublishTime = &now
+	case stateScheduled:
+		// validated to be in the future
+		publishTime, _ := ptypes.Timestamp(blog.GetPublishTime())
+		publishTime = publishTime.UTC().Truncate(time.Millisecond)
+		data.PublishTime = &publishTime
+	}
+	return data
+}
+
+// readVisible reads the blog with the given id, which is NOT_FOUND unless the
+// caller can see it.
+func (s *server) readVisible(ctx context.Context, oid primitive.ObjectID) (*blogItem, error) {
+	data, err := s.store.Read(ctx, oid)
+	if err != nil {
+		return nil, storeError(err, "cannot find blog with specified id")
+	}
+	if !canSee(ctx, data) {
+		return nil, status.Errorf(
+			codes.NotFound,
+			fmt.Sprintf("cannot find blog with specified id"),
+		)
+	}
+	return data, nil
+}
+
+// readForWrite reads the blog with the given id ahead of a write, checks that
+// the caller may change it and that it is at the expected revision unless
+// expected is 0.
+func (s *server) readForWrite(ctx context.Context, oid primitive.ObjectID, expected int64) (*blogItem, error) {
+	data, err := s.store.Read(ctx, oid)
+	if err != nil {
+		return nil, storeError(err, "cannot find blog with specified id")
+	}
+
+	// a blog the caller cannot see is not found, as when reading it, so
+	// writing to it does not tell it exists or who wrote it
+	if !canSee(ctx, data) {
+		return nil, status.Errorf(
+			codes.NotFound,
+			fmt.Sprintf("cannot find blog with specified id"),
+		)
+	}
+	if err := checkCanWrite(ctx, data.AuthorID); err != nil {
+		return nil, err
+	}
+
+	if expected != 0 && expected != data.Revision {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			fmt.Sprintf("blog is at revision %d, expected %d", data.Revision, expected),
+		)
+	}
+	return data, nil
+}
+
+// writeBlog stores a blog previously loaded with readForWrite. The write only
+// goes through if nobody else changed the blog since it was read at
+// readRevision, otherwise their changes would be silently overwritten.
+func (s *server) writeBlog(ctx context.Context, data *blogItem, readRevision, expected int64) (*blogItem, error) {
+	data, err := s.store.Update(ctx, data, readRevision)
+	if err == errRevisionMismatch && expected == 0 {
+		return nil, status.Errorf(
+			codes.Aborted,
+			fmt.Sprintf("blog was updated concurrently, read it again and retry"),
+		)
+	}
+	if err != nil {
+		return nil, storeError(err, "cannot update blog")
+	}
+	return data, nil
+}
+
+// blogsToPb converts blogs and fills in their comment counts.
+func (s *server) blogsToPb(ctx context.Context, items []*blogItem) ([]*bpb.Blog, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]primitive.ObjectID, len(items))
+	for i, data := range items {
+		ids[i] = data.ID
+	}
+	counts, err := s.comments.Count(ctx, ids)
+	if err != nil {
+		return nil, status.Errorf(
 			codes.Internal,
-			fmt.Sprintf("unknown internal error: %v", err),
+			fmt.Sprintf("cannot count comments: %v", err),
 		)
 	}
-	return nil
+
+	blogs := make([]*bpb.Blog, len(items))
+	for i, data := range items {
+		blogs[i] = dataToBlogPb(data)
+		blogs[i].CommentCount = counts[data.ID]
+	}
+	return blogs, nil
+}
+
+// blogToPb converts a blog and fills in its comment count.
+func (s *server) blogToPb(ctx context.Context, data *blogItem) (*bpb.Blog, error) {
+	blogs, err := s.blogsToPb(ctx, []*blogItem{data})
+	if err != nil {
+		return nil, err
+	}
+	return blogs[0], nil
 }
 
 func dataToBlogPb(data *blogItem) *bpb.Blog {
-	return &bpb.Blog{
-		Id:       data.ID.Hex(),
-		AuthorId: data.AuthorID,
-		Content:  data.Content,
-		Title:    data.Title,
+	blog := &bpb.Blog{
+		Id:            data.ID.Hex(),
+		AuthorId:      data.AuthorID,
+		Content:       data.Content,
+		ContentFormat: contentFormatToPb(data.ContentFormat),
+		Title:         data.Title,
+		CreateTime:    timestampProto(data.CreateTime),
+		UpdateTime:    timestampProto(data.UpdateTime),
+		Revision:      data.Revision,
+		Tags:          data.Tags,
+		State:         stateToPb(data.state()),
+	}
+	if data.DeleteTime != nil {
+		blog.DeleteTime = timestampProto(*data.DeleteTime)
 	}
+	if data.PublishTime != nil {
+		blog.PublishTime = timestampProto(*data.PublishTime)
+	}
+	return blog
+}
+
+// timestampProto converts t to a protobuf timestamp. Blogs stored before
+// timestamps were tracked have a zero time, which converts to nil.
+func timestampProto(t time.Time) *timestamp.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	ts, err := ptypes.TimestampProto(t)
+	if err != nil {
+		return nil
+	}
+	return ts
+}
+
+// now returns the current time rounded to what mongodb can store.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
 }
 
 func main() {
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 
-	fmt.Println("Blot Service Started")
-
-	fmt.Println("connecting to mongodb")
-	client, err := mongo.NewClient(options.Client().ApplyURI("mongodb://localhost:27017/blog_mongo"))
+	cfg, run, err := loadConfig(os.Args, os.LookupEnv)
+	if err == flag.ErrHelp {
+		return
+	}
 	if err != nil {
-		log.Fatalf("failed to create new mongodb client: %v", err)
+		log.Fatalf("failed to load configuration: %v", err)
+	}
+	if run.printConfig {
+		if err := cfg.print(); err != nil {
+			log.Fatalf("failed to print configuration: %v", err)
+		}
+		return
 	}
 
-	err = client.Connect(context.TODO())
+	auth, err := loadAuthenticator(cfg.Auth.HMACSecretFile, cfg.Auth.RSAPublicKeyFile, cfg.Auth.NoAuth)
 	if err != nil {
-		log.Fatalf("failed to connect to mongo db: %v", err)
+		log.Fatalf("failed to set up authentication: %v", err)
+	}
+	if auth == nil {
+		fmt.Println("authentication is disabled")
 	}
 
-	lis, err := net.Listen("tcp", "0.0.0.0:50051")
+	fmt.Println("Blot Service Started")
+
+	var store BlogStore
+	var comments CommentStore
+	var requests RequestStore
+	var client *mongo.Client
+	switch cfg.Store {
+	case "mongo":
+		fmt.Println("connecting to mongodb")
+		client, err = mongo.NewClient(options.Client().ApplyURI(cfg.Mongo.URI))
+		if err != nil {
+			log.Fatalf("failed to create new mongodb client: %v", err)
+		}
+
+		err = client.Connect(context.TODO())
+		if err != nil {
+			log.Fatalf("failed to connect to mongo db: %v", err)
+		}
+
+		db := client.Database(cfg.Mongo.Database)
+		mongoStore := newMongoStore(db.Collection(cfg.Mongo.Collection), db.Collection(cfg.Mongo.collection("revisions")))
+		if err := mongoStore.ensureIndexes(context.TODO()); err != nil {
+			log.Fatalf("failed to create mongo db indexes: %v", err)
+		}
+		store = mongoStore
+
+		commentStore := newMongoCommentStore(db.Collection(cfg.Mongo.collection("comments")))
+		if err := commentStore.ensureIndexes(context.TODO()); err != nil {
+			log.Fatalf("failed to create mongo db indexes: %v", err)
+		}
+		comments = commentStore
+
+		requestStore := newMongoRequestStore(db.Collection(cfg.Mongo.collection("requests")), time.Duration(cfg.IdempotencyWindow))
+		if err := requestStore.ensureIndexes(context.TODO()); err != nil {
+			log.Fatalf("failed to create mongo db indexes: %v", err)
+		}
+		requests = requestStore
+	case "memory":
+		fmt.Println("using in-memory store")
+		store = newMemoryStore()
+		comments = newMemoryCommentStore()
+		requests = newMemoryRequestStore(time.Duration(cfg.IdempotencyWindow))
+	}
+
+	lis, err := net.Listen("tcp", cfg.Listen)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	collection = client.Database("blog_mongo").Collection("blog")
+	unary := []grpc.UnaryServerInterceptor{validationUnaryInterceptor}
+	stream := []grpc.StreamServerInterceptor{validationStreamInterceptor}
+	if auth != nil {
+		// callers are authenticated before their requests are validated
+		unary = append([]grpc.UnaryServerInterceptor{auth.unaryInterceptor}, unary...)
+		stream = append([]grpc.StreamServerInterceptor{auth.streamInterceptor}, stream...)
+	}
 
-	opts := []grpc.ServerOption{}
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+	stopping, stop := context.WithCancel(context.Background())
 	s := grpc.NewServer(opts...)
-	bpb.RegisterBlogServiceServer(s, &server{})
+	bpb.RegisterBlogServiceServer(s, &server{store: store, comments: comments, requests: requests, stopping: stopping})
+	bpb.RegisterCommentServiceServer(s, &commentServer{blogs: store, comments: comments})
 
 	reflection.Register(s)
 
-	go func() {
-		fmt.Println("Starting Server...")
-		if err := s.Serve(lis); err != nil {
-			log.Fatalf("failed to serve: %v", err)
+	srv := lifecycle.New(s, lis, time.Duration(cfg.DrainTimeout))
+	srv.OnShutdown(stop)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sched := newScheduler(store, systemClock{}, time.Duration(cfg.PublishInterval))
+	go sched.run(ctx)
+	// the store is only closed once the scheduler is done writing to it
+	srv.OnStop("scheduler", func(ctx context.Context) error {
+		cancel()
+		select {
+		case <-sched.done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
 		}
-	}()
-
-	ch := make(chan os.Signal, 1)
-	signal.Notify(ch, os.Interrupt)
+	})
+	if client != nil {
+		srv.OnStop("mongo db connection", client.Disconnect)
+	}
 
-	<-ch
-	fmt.Println("stopping the server")
-	s.Stop()
-	fmt.Println("close the listener")
-	lis.Close()
-	fmt.Println("closing mongo db connection")
-	client.Disconnect(context.TODO())
+	fmt.Println("Starting Server...")
+	if err := srv.Run(); err != nil {
+		log.Fatalf("failed to serve: %v", err)
+	}
 	fmt.Println("end of program")
 }
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	bpb "blog/pb"
+)
+
+// newTestServer returns a server backed by the in-memory stores, with
+// authentication disabled.
+func newTestServer() *server {
+	return &server{
+		store:    newMemoryStore(),
+		comments: newMemoryCommentStore(),
+		requests: newMemoryRequestStore(time.Hour),
+		stopping: context.Background(),
+	}
+}
+
+func createTestBlog(t *testing.T, s *server, blog *bpb.Blog) *bpb.Blog {
+	t.Helper()
+	res, err := s.CreateBlog(context.Background(), &bpb.CreateBlogRequest{Blog: blog})
+	if err != nil {
+		t.Fatalf("CreateBlog: %v", err)
+	}
+	return res.GetBlog()
+}
+
+func TestUpdateBlogExpectedRevisionRace(t *testing.T) {
+	const n = 20
+	s := newTestServer()
+	blog := createTestBlog(t, s, &bpb.Blog{AuthorId: "alice", Title: "title", Content: "content"})
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	errs := make([]error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			_, errs[i] = s.UpdateBlog(context.Background(), &bpb.UpdateBlogRequest{
+				Blog:             &bpb.Blog{Id: blog.GetId(), AuthorId: "alice", Title: "update", Content: "content"},
+				ExpectedRevision: blog.GetRevision(),
+			})
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		// a stale expected_revision is FAILED_PRECONDITION, whether it is
+		// caught when the blog is read or when it is written
+		if code := status.Code(err); code != codes.FailedPrecondition {
+			t.Errorf("UpdateBlog failed with %v, want %v: %v", code, codes.FailedPrecondition, err)
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("%d updates succeeded, want 1", succeeded)
+	}
+
+	res, err := s.ReadBlog(context.Background(), &bpb.ReadBlogRequest{BlogId: blog.GetId()})
+	if err != nil {
+		t.Fatalf("ReadBlog: %v", err)
+	}
+	if got, want := res.GetBlog().GetRevision(), blog.GetRevision()+1; got != want {
+		t.Errorf("blog is at revision %d, want %d", got, want)
+	}
+}
+
+func TestWritesToHiddenBlogsAreNotFound(t *testing.T) {
+	s := newTestServer()
+	draft := createTestBlog(t, s, &bpb.Blog{AuthorId: "alice", Title: "draft", Content: "content", State: bpb.Blog_DRAFT})
+	published := createTestBlog(t, s, &bpb.Blog{AuthorId: "alice", Title: "published", Content: "content", State: bpb.Blog_PUBLISHED})
+
+	writes := []struct {
+		name  string
+		write func(ctx context.Context, id string) error
+	}{
+		{"UpdateBlog", func(ctx context.Context, id string) error {
+			_, err := s.UpdateBlog(ctx, &bpb.UpdateBlogRequest{Blog: &bpb.Blog{Id: id, AuthorId: "bob", Title: "mine", Content: "content"}})
+			return err
+		}},
+		{"DeleteBlog", func(ctx context.Context, id string) error {
+			_, err := s.DeleteBlog(ctx, &bpb.DeleteBlogRequest{BlogId: id})
+			return err
+		}},
+		{"PublishBlog", func(ctx context.Context, id string) error {
+			_, err := s.PublishBlog(ctx, &bpb.PublishBlogRequest{BlogId: id})
+			return err
+		}},
+		{"UnpublishBlog", func(ctx context.Context, id string) error {
+			_, err := s.UnpublishBlog(ctx, &bpb.UnpublishBlogRequest{BlogId: id})
+			return err
+		}},
+		{"RollbackBlog", func(ctx context.Context, id string) error {
+			_, err := s.RollbackBlog(ctx, &bpb.RollbackBlogRequest{BlogId: id, Revision: 1})
+			return err
+		}},
+	}
+	for _, w := range writes {
+		// another author's draft is not found, as when reading it, while their
+		// published blog is found but cannot be changed
+		for _, tt := range []struct {
+			blog *bpb.Blog
+			want codes.Code
+		}{
+			{draft, codes.NotFound},
+			{published, codes.PermissionDenied},
+		} {
+			err := w.write(as("bob"), tt.blog.GetId())
+			if status.Code(err) != tt.want {
+				t.Errorf("%s of %s blog by another author = %v, want %v", w.name, tt.blog.GetTitle(), err, tt.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryCommentStore is a CommentStore that keeps comments in process memory.
+// It is safe for concurrent use.
+type memoryCommentStore struct {
+	mu       sync.RWMutex
+	comments map[primitive.ObjectID]commentItem
+}
+
+func newMemoryCommentStore() *memoryCommentStore {
+	return &memoryCommentStore{
+		comments: make(map[primitive.ObjectID]commentItem),
+	}
+}
+
+func (m *memoryCommentStore) Create(ctx context.Context, data *commentItem) (*commentItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	created := *data
+	created.ID = primitive.NewObjectID()
+	m.comments[created.ID] = created
+	return &created, nil
+}
+
+func (m *memoryCommentStore) Read(ctx context.Context, id primitive.ObjectID) (*commentItem, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	data, ok := m.comments[id]
+	if !ok {
+		return nil, errCommentNotFound
+	}
+	return &data, nil
+}
+
+func (m *memoryCommentStore) Update(ctx context.Context, data *commentItem) (*commentItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.comments[data.ID]; !ok {
+		return nil, errCommentNotFound
+	}
+	m.comments[data.ID] = *data
+	updated := *data
+	return &updated, nil
+}
+
+func (m *memoryCommentStore) Delete(ctx context.Context, id primitive.ObjectID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.comments[id]; !ok {
+		return errCommentNotFound
+	}
+	delete(m.comments, id)
+	return nil
+}
+
+func (m *memoryCommentStore) DeleteBlog(ctx context.Context, blogID primitive.ObjectID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, data := range m.comments {
+		if data.BlogID == blogID {
+			delete(m.comments, id)
+		}
+	}
+	return nil
+}
+
+func (m *memoryCommentStore) List(ctx context.Context, blogID, after primitive.ObjectID, limit int, fn func(*commentItem) error) error {
+	m.mu.RLock()
+	var items []commentItem
+	for _, data := range m.comments {
+		if data.BlogID != blogID {
+			continue
+		}
+		if !after.IsZero() && bytes.Compare(data.ID[:], after[:]) <= 0 {
+			continue
+		}
+		items = append(items, data)
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(items, func(i, j int) bool {
+		return bytes.Compare(items[i].ID[:], items[j].ID[:]) < 0
+	})
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+
+	for i := range items {
+		if err := fn(&items[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memoryCommentStore) Count(ctx context.Context, blogIDs []primitive.ObjectID) (map[primitive.ObjectID]int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	wanted := make(map[primitive.ObjectID]bool, len(blogIDs))
+	for _, id := range blogIDs {
+		wanted[id] = true
+	}
+
+	counts := make(map[primitive.ObjectID]int64)
+	for _, data := range m.comments {
+		if wanted[data.BlogID] {
+			counts[data.BlogID]++
+		}
+	}
+	return counts, nil
+}
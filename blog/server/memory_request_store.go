@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryRequestStore is a RequestStore that keeps request ids in process
+// memory for window. It is safe for concurrent use.
+type memoryRequestStore struct {
+	mu       sync.Mutex
+	window   time.Duration
+	requests map[string]requestItem
+	// reserved lists the reservations in the order they were made, so the
+	// expired ones are found at its front.
+	reserved []reservation
+}
+
+// reservation is an entry of memoryRequestStore.reserved.
+type reservation struct {
+	key        string
+	createTime time.Time
+}
+
+func newMemoryRequestStore(window time.Duration) *memoryRequestStore {
+	return &memoryRequestStore{
+		window:   window,
+		requests: make(map[string]requestItem),
+	}
+}
+
+func (m *memoryRequestStore) Reserve(ctx context.Context, data *requestItem) (*requestItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.expire(data.CreateTime)
+	if stored, ok := m.requests[data.Key]; ok {
+		return &stored, errRequestExists
+	}
+	m.requests[data.Key] = *data
+	m.reserved = append(m.reserved, reservation{key: data.Key, createTime: data.CreateTime})
+	reserved := *data
+	return &reserved, nil
+}
+
+func (m *memoryRequestStore) Complete(ctx context.Context, key string, blog *blogItem) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if stored, ok := m.requests[key]; ok {
+		stored.Blog = blog
+		m.requests[key] = stored
+	}
+	return nil
+}
+
+// Release forgets the request id right away. Its entry in m.reserved is left
+// to expire, and does not match a later reservation of the same id.
+func (m *memoryRequestStore) Release(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.requests, key)
+	return nil
+}
+
+// expire forgets the request ids older than the window. The caller must hold
+// m.mu.
+func (m *memoryRequestStore) expire(now time.Time) {
+	n := 0
+	for ; n < len(m.reserved); n++ {
+		r := m.reserved[n]
+		if now.Sub(r.createTime) <= m.window {
+			break
+		}
+		// the id may have been released and reserved again since
+		if stored, ok := m.requests[r.key]; ok && stored.CreateTime.Equal(r.createTime) {
+			delete(m.requests, r.key)
+		}
+	}
+	// the dropped front is freed once append moves the queue to a new array
+	m.reserved = m.reserved[n:]
+}
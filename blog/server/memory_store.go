@@ -0,0 +1,329 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryStore is a BlogStore that keeps blogs in process memory. It is safe
+// for concurrent use and is meant for tests and local development.
+type memoryStore struct {
+	mu    sync.RWMutex
+	blogs map[primitive.ObjectID]blogItem
+	// revisions holds the revisions of every blog, oldest first
+	revisions map[primitive.ObjectID][]revisionItem
+	// index holds the blogs that are not in the trash
+	index *searchIndex
+	feed  *eventFeed
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		blogs:     make(map[primitive.ObjectID]blogItem),
+		revisions: make(map[primitive.ObjectID][]revisionItem),
+		index:     newSearchIndex(),
+		feed:      newEventFeed(),
+	}
+}
+
+func (m *memoryStore) Create(ctx context.Context, data *blogItem) (*blogItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	created := *data
+	created.ID = primitive.NewObjectID()
+	m.blogs[created.ID] = created
+	m.index.add(&created)
+	m.addRevision(&created)
+	m.feed.publish(eventCreated, &created)
+	return &created, nil
+}
+
+func (m *memoryStore) CreateMany(ctx context.Context, data []*blogItem) ([]*blogItem, []error) {
+	created := make([]*blogItem, len(data))
+	errs := make([]error, len(data))
+	for i := range data {
+		created[i], errs[i] = m.Create(ctx, data[i])
+	}
+	return created, errs
+}
+
+func (m *memoryStore) Read(ctx context.Context, id primitive.ObjectID) (*blogItem, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	data, ok := m.blogs[id]
+	if !ok {
+		return nil, errBlogNotFound
+	}
+	return &data, nil
+}
+
+func (m *memoryStore) ReadMany(ctx context.Context, ids []primitive.ObjectID) ([]*blogItem, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var items []*blogItem
+	for _, id := range ids {
+		if data, ok := m.blogs[id]; ok {
+			items = append(items, &data)
+		}
+	}
+	return items, nil
+}
+
+func (m *memoryStore) Update(ctx context.Context, data *blogItem, ifRevision int64) (*blogItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.blogs[data.ID]
+	if !ok {
+		return nil, errBlogNotFound
+	}
+	if stored.Revision != ifRevision {
+		return nil, errRevisionMismatch
+	}
+	m.blogs[data.ID] = *data
+	if data.DeleteTime == nil {
+		m.index.add(data)
+	} else {
+		m.index.remove(data.ID)
+	}
+	m.addRevision(data)
+	m.feed.publish(watchEventType(data, false), data)
+	updated := *data
+	return &updated, nil
+}
+
+func (m *memoryStore) Delete(ctx context.Context, id primitive.ObjectID, ifRevision int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.blogs[id]
+	if !ok {
+		return errBlogNotFound
+	}
+	if ifRevision != 0 && stored.Revision != ifRevision {
+		return errRevisionMismatch
+	}
+	delete(m.blogs, id)
+	delete(m.revisions, id)
+	m.index.remove(id)
+	m.feed.publish(eventPurged, &blogItem{ID: id})
+	return nil
+}
+
+func (m *memoryStore) List(ctx context.Context, q listQuery, fn func(*blogItem) error) error {
+	// copy the blogs out so fn can call back into the store
+	m.mu.RLock()
+	items := make([]blogItem, 0, len(m.blogs))
+	for _, data := range m.blogs {
+		if matchesQuery(&data, q) {
+			items = append(items, data)
+		}
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(items, func(i, j int) bool {
+		return compareBlogs(&items[i], &items[j], q.Order) < 0
+	})
+	if q.Limit > 0 && len(items) > q.Limit {
+		items = items[:q.Limit]
+	}
+
+	for i := range items {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(&items[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memoryStore) ReadRevision(ctx context.Context, blogID primitive.ObjectID, revision int64) (*revisionItem, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, rev := range m.revisions[blogID] {
+		if rev.Revision == revision {
+			return &rev, nil
+		}
+	}
+	return nil, errRevisionNotFound
+}
+
+func (m *memoryStore) ListRevisions(ctx context.Context, blogID primitive.ObjectID, before int64, limit int, fn func(*revisionItem) error) error {
+	m.mu.RLock()
+	var items []revisionItem
+	revs := m.revisions[blogID]
+	for i := len(revs) - 1; i >= 0; i-- {
+		if before != 0 && revs[i].Revision >= before {
+			continue
+		}
+		if limit > 0 && len(items) == limit {
+			break
+		}
+		items = append(items, revs[i])
+	}
+	m.mu.RUnlock()
+
+	for i := range items {
+		if err := fn(&items[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memoryStore) Watch(ctx context.Context, q watchQuery, fn func(*blogEvent) error) error {
+	w, err := m.feed.watch(q.AuthorID, q.ResumeToken)
+	if err != nil {
+		return err
+	}
+	defer m.feed.stop(w)
+
+	return w.next(ctx, fn)
+}
+
+func (m *memoryStore) ListTags(ctx context.Context) ([]tagCount, error) {
+	m.mu.RLock()
+	counts := make(map[string]int64)
+	for _, data := range m.blogs {
+		if data.DeleteTime != nil || data.state() != statePublished {
+			continue
+		}
+		for _, tag := range data.Tags {
+			counts[tag]++
+		}
+	}
+	m.mu.RUnlock()
+
+	tags := make([]tagCount, 0, len(counts))
+	for tag, count := range counts {
+		tags = append(tags, tagCount{Tag: tag, Count: count})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Count != tags[j].Count {
+			return tags[i].Count > tags[j].Count
+		}
+		return tags[i].Tag < tags[j].Tag
+	})
+	return tags, nil
+}
+
+func (m *memoryStore) Search(ctx context.Context, q searchQuery) ([]searchHit, error) {
+	m.mu.RLock()
+	scores := m.index.search(q.Words)
+	hits := make([]searchHit, 0, len(scores))
+	for id, score := range scores {
+		data := m.blogs[id]
+		if data.state() != statePublished {
+			continue
+		}
+		hits = append(hits, searchHit{Blog: &data, Score: score})
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return bytes.Compare(hits[i].Blog.ID[:], hits[j].Blog.ID[:]) < 0
+	})
+	if q.Skip >= len(hits) {
+		return nil, nil
+	}
+	hits = hits[q.Skip:]
+	if q.Limit > 0 && len(hits) > q.Limit {
+		hits = hits[:q.Limit]
+	}
+	return hits, nil
+}
+
+// addRevision records a revision of data. The caller must hold m.mu.
+func (m *memoryStore) addRevision(data *blogItem) {
+	m.revisions[data.ID] = append(m.revisions[data.ID], *newRevisionItem(data))
+}
+
+// matchesQuery reports whether data passes the filters and cursor of q.
+func matchesQuery(data *blogItem, q listQuery) bool {
+	if !q.ShowDeleted && data.DeleteTime != nil {
+		return false
+	}
+	if q.AuthorID != "" && data.AuthorID != q.AuthorID {
+		return false
+	}
+	if len(q.States) > 0 && !hasState(data, q.States) {
+		return false
+	}
+	if !q.PublishBefore.IsZero() && (data.PublishTime == nil || data.PublishTime.After(q.PublishBefore)) {
+		return false
+	}
+	if len(q.AnyTags) > 0 && !hasAnyTag(data.Tags, q.AnyTags) {
+		return false
+	}
+	for _, tag := range q.AllTags {
+		if !hasAnyTag(data.Tags, []string{tag}) {
+			return false
+		}
+	}
+	if q.Text != "" {
+		text := strings.ToLower(q.Text)
+		if !strings.Contains(strings.ToLower(data.Title), text) &&
+			!strings.Contains(strings.ToLower(data.Content), text) {
+			return false
+		}
+	}
+	if q.After != nil {
+		after := &blogItem{ID: q.After.ID, Title: q.After.Title}
+		if compareBlogs(data, after, q.Order) <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// hasState reports whether data is in one of states.
+func hasState(data *blogItem, states []blogState) bool {
+	for _, s := range states {
+		if data.state() == s {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAnyTag reports whether tags holds at least one of want.
+func hasAnyTag(tags, want []string) bool {
+	for _, t := range tags {
+		for _, w := range want {
+			if t == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// compareBlogs compares a and b in the given order, the same way mongoStore
+// sorts them.
+func compareBlogs(a, b *blogItem, o listOrder) int {
+	c := 0
+	if o.Field == sortTitle {
+		c = strings.Compare(a.Title, b.Title)
+	}
+	if c == 0 {
+		c = bytes.Compare(a.ID[:], b.ID[:])
+	}
+	if o.Desc {
+		return -c
+	}
+	return c
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoCommentStore is a CommentStore backed by a mongodb collection.
+type mongoCommentStore struct {
+	collection *mongo.Collection
+}
+
+func newMongoCommentStore(collection *mongo.Collection) *mongoCommentStore {
+	return &mongoCommentStore{collection: collection}
+}
+
+// ensureIndexes creates the index used by List, Count and DeleteBlog.
+func (m *mongoCommentStore) ensureIndexes(ctx context.Context) error {
+	_, err := m.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "blog_id", Value: 1}, {Key: "_id", Value: 1}},
+	})
+	return err
+}
+
+func (m *mongoCommentStore) Create(ctx context.Context, data *commentItem) (*commentItem, error) {
+	res, err := m.collection.InsertOne(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	oid, ok := res.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return nil, fmt.Errorf("cannot convert to oid: %v", res.InsertedID)
+	}
+
+	created := *data
+	created.ID = oid
+	return &created, nil
+}
+
+func (m *mongoCommentStore) Read(ctx context.Context, id primitive.ObjectID) (*commentItem, error) {
+	data := &commentItem{}
+
+	res := m.collection.FindOne(ctx, bson.M{"_id": id})
+	if err := res.Decode(data); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errCommentNotFound
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+func (m *mongoCommentStore) Update(ctx context.Context, data *commentItem) (*commentItem, error) {
+	res, err := m.collection.ReplaceOne(ctx, bson.M{"_id": data.ID}, data)
+	if err != nil {
+		return nil, err
+	}
+	if res.MatchedCount == 0 {
+		return nil, errCommentNotFound
+	}
+	return data, nil
+}
+
+func (m *mongoCommentStore) Delete(ctx context.Context, id primitive.ObjectID) error {
+	res, err := m.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return errCommentNotFound
+	}
+	return nil
+}
+
+func (m *mongoCommentStore) DeleteBlog(ctx context.Context, blogID primitive.ObjectID) error {
+	_, err := m.collection.DeleteMany(ctx, bson.M{"blog_id": blogID})
+	return err
+}
+
+func (m *mongoCommentStore) List(ctx context.Context, blogID, after primitive.ObjectID, limit int, fn func(*commentItem) error) error {
+	filter := bson.M{"blog_id": blogID}
+	if !after.IsZero() {
+		filter["_id"] = bson.M{"$gt": after}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	cur, err := m.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return err
+	}
+
+	defer cur.Close(ctx)
+	for cur.Next(ctx) {
+		data := &commentItem{}
+		if err := cur.Decode(data); err != nil {
+			return err
+		}
+		if err := fn(data); err != nil {
+			return err
+		}
+	}
+	return cur.Err()
+}
+
+func (m *mongoCommentStore) Count(ctx context.Context, blogIDs []primitive.ObjectID) (map[primitive.ObjectID]int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"blog_id": bson.M{"$in": blogIDs}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$blog_id", "count": bson.M{"$sum": 1}}}},
+	}
+
+	cur, err := m.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []struct {
+		BlogID primitive.ObjectID `bson:"_id"`
+		Count  int64              `bson:"count"`
+	}
+	if err := cur.All(ctx, &groups); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[primitive.ObjectID]int64, len(groups))
+	for _, g := range groups {
+		counts[g.BlogID] = g.Count
+	}
+	return counts, nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// requestTTLIndex is the name of the index that expires request ids.
+const requestTTLIndex = "create_time_ttl"
+
+// mongoRequestStore is a RequestStore backed by a mongodb collection. A TTL
+// index removes the request ids older than window.
+type mongoRequestStore struct {
+	collection *mongo.Collection
+	window     time.Duration
+}
+
+func newMongoRequestStore(collection *mongo.Collection, window time.Duration) *mongoRequestStore {
+	return &mongoRequestStore{collection: collection, window: window}
+}
+
+// ensureIndexes creates the TTL index, replacing the one left by a server that
+// ran with another window.
+func (m *mongoRequestStore) ensureIndexes(ctx context.Context) error {
+	model := mongo.IndexModel{
+		Keys: bson.D{{Key: "create_time", Value: 1}},
+		Options: options.Index().
+			SetName(requestTTLIndex).
+			SetExpireAfterSeconds(int32(m.window.Seconds())),
+	}
+
+	_, err := m.collection.Indexes().CreateOne(ctx, model)
+	if cmdErr, ok := err.(mongo.CommandError); ok && cmdErr.Name == "IndexOptionsConflict" {
+		if _, err := m.collection.Indexes().DropOne(ctx, requestTTLIndex); err != nil {
+			return err
+		}
+		_, err = m.collection.Indexes().CreateOne(ctx, model)
+	}
+	return err
+}
+
+func (m *mongoRequestStore) Reserve(ctx context.Context, data *requestItem) (*requestItem, error) {
+	_, err := m.collection.InsertOne(ctx, data)
+	if err == nil {
+		reserved := *data
+		return &reserved, nil
+	}
+	if !isDuplicateKey(err) {
+		return nil, err
+	}
+
+	stored := &requestItem{}
+	if err := m.collection.FindOne(ctx, bson.M{"_id": data.Key}).Decode(stored); err != nil {
+		return nil, err
+	}
+
+	// the TTL monitor only runs every minute, so take over an expired
+	// request id it has not removed yet
+	if data.CreateTime.Sub(stored.CreateTime) > m.window {
+		filter := bson.M{"_id": data.Key, "create_time": stored.CreateTime}
+		res, err := m.collection.ReplaceOne(ctx, filter, data)
+		if err != nil {
+			return nil, err
+		}
+		if res.MatchedCount == 1 {
+			reserved := *data
+			return &reserved, nil
+		}
+		// somebody else took it over first
+		if err := m.collection.FindOne(ctx, bson.M{"_id": data.Key}).Decode(stored); err != nil {
+			return nil, err
+		}
+	}
+	return stored, errRequestExists
+}
+
+func (m *mongoRequestStore) Complete(ctx context.Context, key string, blog *blogItem) error {
+	_, err := m.collection.UpdateOne(ctx, bson.M{"_id": key}, bson.M{"$set": bson.M{"blog": blog}})
+	return err
+}
+
+func (m *mongoRequestStore) Release(ctx context.Context, key string) error {
+	_, err := m.collection.DeleteOne(ctx, bson.M{"_id": key})
+	return err
+}
+
+// isDuplicateKey reports whether err is a write error on a unique key.
+func isDuplicateKey(err error) bool {
+	we, ok := err.(mongo.WriteException)
+	if !ok {
+		return false
+	}
+	for _, e := range we.WriteErrors {
+		if e.Code == 11000 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,479 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoStore is a BlogStore backed by mongodb collections, one for the blogs
+// and one for their revisions.
+type mongoStore struct {
+	collection *mongo.Collection
+	revisions  *mongo.Collection
+}
+
+func newMongoStore(collection, revisions *mongo.Collection) *mongoStore {
+	return &mongoStore{collection: collection, revisions: revisions}
+}
+
+// ensureIndexes creates the indexes used by List, ListTags, Search, the
+// publishing scheduler and ListRevisions.
+func (m *mongoStore) ensureIndexes(ctx context.Context) error {
+	_, err := m.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "author_id", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "title", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "tags", Value: 1}}},
+		{Keys: bson.D{{Key: "state", Value: 1}, {Key: "publish_time", Value: 1}}},
+		{
+			Keys: bson.D{{Key: "title", Value: "text"}, {Key: "content", Value: "text"}},
+			Options: options.Index().
+				SetName("title_content_text").
+				SetWeights(bson.M{"title": titleWeight, "content": 1}),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = m.revisions.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "blog_id", Value: 1}, {Key: "revision", Value: -1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+func (m *mongoStore) Create(ctx context.Context, data *blogItem) (*blogItem, error) {
+	res, err := m.collection.InsertOne(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	oid, ok := res.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return nil, fmt.Errorf("cannot convert to oid: %v", res.InsertedID)
+	}
+
+	created := *data
+	created.ID = oid
+	if err := m.addRevision(ctx, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (m *mongoStore) CreateMany(ctx context.Context, data []*blogItem) ([]*blogItem, []error) {
+	created := make([]*blogItem, len(data))
+	errs := make([]error, len(data))
+
+	// ids are assigned up front so they are known even for a partial insert
+	docs := make([]interface{}, len(data))
+	for i := range data {
+		c := *data[i]
+		c.ID = primitive.NewObjectID()
+		created[i] = &c
+		docs[i] = &c
+	}
+
+	_, err := m.collection.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	if bwe, ok := err.(mongo.BulkWriteException); ok {
+		for _, we := range bwe.WriteErrors {
+			created[we.Index] = nil
+			errs[we.Index] = we
+		}
+	} else if err != nil {
+		for i := range data {
+			created[i] = nil
+			errs[i] = err
+		}
+		return created, errs
+	}
+
+	var revs []interface{}
+	for _, c := range created {
+		if c != nil {
+			revs = append(revs, newRevisionItem(c))
+		}
+	}
+	if len(revs) == 0 {
+		return created, errs
+	}
+	// as with addRevision, a failure here leaves the blogs without revisions
+	if _, err := m.revisions.InsertMany(ctx, revs, options.InsertMany().SetOrdered(false)); err != nil {
+		for i, c := range created {
+			if c != nil {
+				created[i] = nil
+				errs[i] = err
+			}
+		}
+	}
+	return created, errs
+}
+
+func (m *mongoStore) Read(ctx context.Context, id primitive.ObjectID) (*blogItem, error) {
+	data := &blogItem{}
+	filter := bson.M{"_id": id}
+
+	res := m.collection.FindOne(ctx, filter)
+	if err := res.Decode(data); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errBlogNotFound
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+func (m *mongoStore) ReadMany(ctx context.Context, ids []primitive.ObjectID) ([]*blogItem, error) {
+	cur, err := m.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+
+	defer cur.Close(ctx)
+	var items []*blogItem
+	for cur.Next(ctx) {
+		data := &blogItem{}
+		if err := cur.Decode(data); err != nil {
+			return nil, err
+		}
+		items = append(items, data)
+	}
+	return items, cur.Err()
+}
+
+func (m *mongoStore) Update(ctx context.Context, data *blogItem, ifRevision int64) (*blogItem, error) {
+	filter := bson.M{"_id": data.ID, "revision": revisionFilter(ifRevision)}
+
+	res, err := m.collection.ReplaceOne(ctx, filter, data)
+	if err != nil {
+		return nil, err
+	}
+	if res.MatchedCount == 0 {
+		return nil, m.missError(ctx, data.ID)
+	}
+	if err := m.addRevision(ctx, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (m *mongoStore) Delete(ctx context.Context, id primitive.ObjectID, ifRevision int64) error {
+	filter := bson.M{"_id": id}
+	if ifRevision != 0 {
+		filter["revision"] = ifRevision
+	}
+
+	res, err := m.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return m.missError(ctx, id)
+	}
+
+	_, err = m.revisions.DeleteMany(ctx, bson.M{"blog_id": id})
+	return err
+}
+
+func (m *mongoStore) ReadRevision(ctx context.Context, blogID primitive.ObjectID, revision int64) (*revisionItem, error) {
+	rev := &revisionItem{}
+	filter := bson.M{"blog_id": blogID, "revision": revision}
+
+	res := m.revisions.FindOne(ctx, filter)
+	if err := res.Decode(rev); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errRevisionNotFound
+		}
+		return nil, err
+	}
+	return rev, nil
+}
+
+func (m *mongoStore) ListRevisions(ctx context.Context, blogID primitive.ObjectID, before int64, limit int, fn func(*revisionItem) error) error {
+	filter := bson.M{"blog_id": blogID}
+	if before != 0 {
+		filter["revision"] = bson.M{"$lt": before}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "revision", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	cur, err := m.revisions.Find(ctx, filter, opts)
+	if err != nil {
+		return err
+	}
+
+	defer cur.Close(ctx)
+	for cur.Next(ctx) {
+		rev := &revisionItem{}
+		if err := cur.Decode(rev); err != nil {
+			return err
+		}
+		if err := fn(rev); err != nil {
+			return err
+		}
+	}
+	return cur.Err()
+}
+
+func (m *mongoStore) ListTags(ctx context.Context) ([]tagCount, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"delete_time": nil, "state": stateFilter(statePublished)}}},
+		{{Key: "$unwind", Value: "$tags"}},
+		{{Key: "$group", Value: bson.M{"_id": "$tags", "count": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+	}
+
+	cur, err := m.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []tagCount
+	if err := cur.All(ctx, &tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func (m *mongoStore) Search(ctx context.Context, q searchQuery) ([]searchHit, error) {
+	// the words are plain, so the mongodb phrase and negation syntax never
+	// applies
+	filter := bson.M{
+		"$text":       bson.M{"$search": strings.Join(q.Words, " ")},
+		"delete_time": nil,
+		"state":       stateFilter(statePublished),
+	}
+	score := bson.M{"$meta": "textScore"}
+	opts := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(q.Skip))
+	if q.Limit > 0 {
+		opts.SetLimit(int64(q.Limit))
+	}
+
+	cur, err := m.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []struct {
+		Blog  blogItem `bson:",inline"`
+		Score float64  `bson:"score"`
+	}
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	hits := make([]searchHit, len(docs))
+	for i := range docs {
+		hits[i] = searchHit{Blog: &docs[i].Blog, Score: docs[i].Score}
+	}
+	return hits, nil
+}
+
+// changeEvent is the part of a mongodb change stream event Watch looks at.
+type changeEvent struct {
+	OperationType string   `bson:"operationType"`
+	FullDocument  blogItem `bson:"fullDocument"`
+	DocumentKey   struct {
+		ID primitive.ObjectID `bson:"_id"`
+	} `bson:"documentKey"`
+}
+
+// Watch is backed by a change stream, which needs mongodb to run as a replica
+// set. Its resume tokens are the change stream resume tokens.
+func (m *mongoStore) Watch(ctx context.Context, q watchQuery, fn func(*blogEvent) error) error {
+	match := bson.M{"operationType": bson.M{"$in": bson.A{"insert", "replace", "update", "delete"}}}
+	if q.AuthorID != "" {
+		match["fullDocument.author_id"] = q.AuthorID
+	}
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: match}}}
+
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if q.ResumeToken != "" {
+		token, err := base64.RawURLEncoding.DecodeString(q.ResumeToken)
+		if err != nil {
+			return errInvalidResumeToken
+		}
+		if err := bson.Raw(token).Validate(); err != nil {
+			return errInvalidResumeToken
+		}
+		opts.SetResumeAfter(bson.Raw(token))
+	}
+
+	cs, err := m.collection.Watch(ctx, pipeline, opts)
+	if err != nil {
+		return err
+	}
+
+	defer cs.Close(ctx)
+	for cs.Next(ctx) {
+		change := &changeEvent{}
+		if err := cs.Decode(change); err != nil {
+			return err
+		}
+
+		ev := &blogEvent{ResumeToken: base64.RawURLEncoding.EncodeToString(cs.ResumeToken())}
+		switch change.OperationType {
+		case "delete":
+			ev.Type = eventPurged
+			ev.Blog = blogItem{ID: change.DocumentKey.ID}
+		case "insert":
+			ev.Type = eventCreated
+			ev.Blog = change.FullDocument
+		default:
+			// the blog can be gone by the time an update is looked up
+			if change.FullDocument.ID.IsZero() {
+				continue
+			}
+			ev.Type = watchEventType(&change.FullDocument, false)
+			ev.Blog = change.FullDocument
+		}
+
+		if err := fn(ev); err != nil {
+			return err
+		}
+	}
+	return cs.Err()
+}
+
+// addRevision records a revision of data. Blogs and revisions are written
+// separately, so a failure here leaves the blog written without its revision.
+func (m *mongoStore) addRevision(ctx context.Context, data *blogItem) error {
+	_, err := m.revisions.InsertOne(ctx, newRevisionItem(data))
+	return err
+}
+
+// missError tells apart why a conditional write on the blog with the given id
+// matched nothing: either the blog is gone or its revision has moved on.
+func (m *mongoStore) missError(ctx context.Context, id primitive.ObjectID) error {
+	n, err := m.collection.CountDocuments(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errBlogNotFound
+	}
+	return errRevisionMismatch
+}
+
+// stateFilter matches the state field against states. Blogs stored before
+// states were tracked have no state field, which counts as published.
+func stateFilter(states ...blogState) bson.M {
+	in := bson.A{}
+	for _, s := range states {
+		in = append(in, s)
+		if s == statePublished {
+			in = append(in, nil)
+		}
+	}
+	return bson.M{"$in": in}
+}
+
+// revisionFilter matches the revision field against rev. Blogs stored before
+// revisions were tracked have no revision field, which counts as revision 0.
+func revisionFilter(rev int64) interface{} {
+	if rev == 0 {
+		return bson.M{"$in": bson.A{0, nil}}
+	}
+	return rev
+}
+
+func (m *mongoStore) List(ctx context.Context, q listQuery, fn func(*blogItem) error) error {
+	filter := listFilter(q)
+	opts := options.Find().SetSort(listSort(q.Order))
+	if q.Limit > 0 {
+		opts.SetLimit(int64(q.Limit))
+	}
+
+	cur, err := m.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return err
+	}
+
+	defer cur.Close(ctx)
+	for cur.Next(ctx) {
+		data := &blogItem{}
+		if err := cur.Decode(data); err != nil {
+			return err
+		}
+		if err := fn(data); err != nil {
+			return err
+		}
+	}
+	return cur.Err()
+}
+
+// listFilter translates the filters and cursor of q into a mongodb query.
+func listFilter(q listQuery) bson.M {
+	var and []bson.M
+	if !q.ShowDeleted {
+		and = append(and, bson.M{"delete_time": nil})
+	}
+	if q.AuthorID != "" {
+		and = append(and, bson.M{"author_id": q.AuthorID})
+	}
+	if len(q.States) > 0 {
+		and = append(and, bson.M{"state": stateFilter(q.States...)})
+	}
+	if !q.PublishBefore.IsZero() {
+		and = append(and, bson.M{"publish_time": bson.M{"$lte": q.PublishBefore}})
+	}
+	if len(q.AnyTags) > 0 {
+		and = append(and, bson.M{"tags": bson.M{"$in": q.AnyTags}})
+	}
+	if len(q.AllTags) > 0 {
+		and = append(and, bson.M{"tags": bson.M{"$all": q.AllTags}})
+	}
+	if q.Text != "" {
+		pattern := primitive.Regex{Pattern: regexp.QuoteMeta(q.Text), Options: "i"}
+		and = append(and, bson.M{"$or": []bson.M{
+			{"title": pattern},
+			{"content": pattern},
+		}})
+	}
+	if q.After != nil {
+		op := "$gt"
+		if q.Order.Desc {
+			op = "$lt"
+		}
+		switch q.Order.Field {
+		case sortTitle:
+			and = append(and, bson.M{"$or": []bson.M{
+				{"title": bson.M{op: q.After.Title}},
+				{"title": q.After.Title, "_id": bson.M{op: q.After.ID}},
+			}})
+		default:
+			and = append(and, bson.M{"_id": bson.M{op: q.After.ID}})
+		}
+	}
+
+	if len(and) == 0 {
+		return bson.M{}
+	}
+	return bson.M{"$and": and}
+}
+
+// listSort translates o into a mongodb sort document.
+func listSort(o listOrder) bson.D {
+	dir := 1
+	if o.Desc {
+		dir = -1
+	}
+	if o.Field == sortTitle {
+		return bson.D{{Key: "title", Value: dir}, {Key: "_id", Value: dir}}
+	}
+	return bson.D{{Key: "_id", Value: dir}}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// sortField is a blog field that ListBlog can order by.
+type sortField string
+
+const (
+	// sortCreateTime orders by creation time. Object ids start with their
+	// creation timestamp, so this is the same as ordering by id.
+	sortCreateTime sortField = "create_time"
+	sortTitle      sortField = "title"
+)
+
+// listOrder is the order in which ListBlog returns blogs. Ties are always
+// broken by id in the same direction, so every order is total.
+type listOrder struct {
+	Field sortField
+	Desc  bool
+}
+
+// String returns the order in the order_by syntax accepted by parseOrderBy.
+func (o listOrder) String() string {
+	if o.Desc {
+		return string(o.Field) + " desc"
+	}
+	return string(o.Field)
+}
+
+// parseOrderBy parses the order_by field of a ListBlogRequest, which is a field
+// name optionally followed by "asc" or "desc". An empty string orders by
+// ascending creation time.
+func parseOrderBy(orderBy string) (listOrder, error) {
+	parts := strings.Fields(orderBy)
+	if len(parts) == 0 {
+		return listOrder{Field: sortCreateTime}, nil
+	}
+	if len(parts) > 2 {
+		return listOrder{}, fmt.Errorf("invalid order_by %q", orderBy)
+	}
+
+	var o listOrder
+	switch field := sortField(parts[0]); field {
+	case sortCreateTime, sortTitle:
+		o.Field = field
+	default:
+		return listOrder{}, fmt.Errorf("unknown sort key %q, expected %s or %s", parts[0], sortCreateTime, sortTitle)
+	}
+
+	if len(parts) == 2 {
+		switch strings.ToLower(parts[1]) {
+		case "asc":
+		case "desc":
+			o.Desc = true
+		default:
+			return listOrder{}, fmt.Errorf("unknown sort direction %q, expected asc or desc", parts[1])
+		}
+	}
+	return o, nil
+}
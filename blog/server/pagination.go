@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 1000
+)
+
+// pageCursor is the position of the last blog of a page in a given order. It
+// holds the value of every field the listing is ordered by.
+type pageCursor struct {
+	Order string             `bson:"o"`
+	ID    primitive.ObjectID `bson:"i"`
+	Title string             `bson:"t,omitempty"`
+}
+
+func newPageCursor(order listOrder, last *blogItem) *pageCursor {
+	c := &pageCursor{Order: order.String(), ID: last.ID}
+	if order.Field == sortTitle {
+		c.Title = last.Title
+	}
+	return c
+}
+
+// encodePageToken returns an opaque token that resumes a listing right after
+// the cursor.
+func encodePageToken(c *pageCursor) (string, error) {
+	b, err := bson.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// decodePageToken is the inverse of encodePageToken. An empty token decodes to
+// a nil cursor, which starts the listing from the beginning. A token can only
+// resume the order it was created for.
+func decodePageToken(token string, order listOrder) (*pageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.New("malformed page token")
+	}
+
+	c := &pageCursor{}
+	if err := bson.Unmarshal(b, c); err != nil {
+		return nil, errors.New("malformed page token")
+	}
+	if c.Order != order.String() {
+		return nil, errors.New("page token does not match order_by")
+	}
+	return c, nil
+}
+
+// pageSize returns the number of blogs to send for a requested page size.
+func pageSize(requested int32) (int, error) {
+	switch {
+	case requested < 0:
+		return 0, errors.New("page size must not be negative")
+	case requested == 0:
+		return defaultPageSize, nil
+	case requested > maxPageSize:
+		return maxPageSize, nil
+	}
+	return int(requested), nil
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	bpb "blog/pb"
+)
+
+func (s *server) PublishBlog(ctx context.Context, req *bpb.PublishBlogRequest) (*bpb.PublishBlogResponse, error) {
+	fmt.Println("publish blog request")
+
+	oid, err := primitive.ObjectIDFromHex(req.GetBlogId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("cannot parse id"),
+		)
+	}
+
+	data, err := s.readForWrite(ctx, oid, req.GetExpectedRevision())
+	if err != nil {
+		return nil, err
+	}
+	if data.DeleteTime != nil {
+		return nil, status.Errorf(
+			codes.NotFound,
+			fmt.Sprintf("cannot find blog with specified id: blog is in the trash"),
+		)
+	}
+	if data.state() == statePublished {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			fmt.Sprintf("blog is already published"),
+		)
+	}
+
+	readRevision := data.Revision
+	publishTime := now()
+	data.State = statePublished
+	if req.GetPublishTime() != nil {
+		// validated to be a valid timestamp
+		t, _ := ptypes.Timestamp(req.GetPublishTime())
+		if t.After(publishTime) {
+			data.State = stateScheduled
+			publishTime = t.UTC().Truncate(time.Millisecond)
+		}
+	}
+	data.PublishTime = &publishTime
+	data.UpdateTime = now()
+	data.Revision++
+
+	data, err = s.writeBlog(ctx, data, readRevision, req.GetExpectedRevision())
+	if err != nil {
+		return nil, err
+	}
+
+	blog, err := s.blogToPb(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bpb.PublishBlogResponse{
+		Blog: blog,
+	}, nil
+}
+
+func (s *server) UnpublishBlog(ctx context.Context, req *bpb.UnpublishBlogRequest) (*bpb.UnpublishBlogResponse, error) {
+	fmt.Println("unpublish blog request")
+
+	oid, err := primitive.ObjectIDFromHex(req.GetBlogId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("cannot parse id"),
+		)
+	}
+
+	data, err := s.readForWrite(ctx, oid, req.GetExpectedRevision())
+	if err != nil {
+		return nil, err
+	}
+	if data.DeleteTime != nil {
+		return nil, status.Errorf(
+			codes.NotFound,
+			fmt.Sprintf("cannot find blog with specified id: blog is in the trash"),
+		)
+	}
+
+	target := stateDraft
+	if req.GetArchive() {
+		target = stateArchived
+	}
+	if data.state() == target {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			fmt.Sprintf("blog is already %s", target),
+		)
+	}
+
+	readRevision := data.Revision
+	// an archived blog remembers when it was published, a draft was never
+	// published as far as readers are concerned
+	if target == stateDraft || data.state() != statePublished {
+		data.PublishTime = nil
+	}
+	data.State = target
+	data.UpdateTime = now()
+	data.Revision++
+
+	data, err = s.writeBlog(ctx, data, readRevision, req.GetExpectedRevision())
+	if err != nil {
+		return nil, err
+	}
+
+	blog, err := s.blogToPb(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bpb.UnpublishBlogResponse{
+		Blog: blog,
+	}, nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// errRequestExists is returned by RequestStore.Reserve when the request id is
+// already known.
+var errRequestExists = errors.New("request id already used")
+
+// requestItem remembers a CreateBlog call made with a request id.
+type requestItem struct {
+	// Key is the request id, scoped to the caller that sent it.
+	Key string `bson:"_id"`
+	// Hash identifies the blog the call asked for, so a request id reused
+	// for another blog is told apart from a retry.
+	Hash string `bson:"hash"`
+	// Blog is the blog as it was created, nil until it is. Retries get it
+	// even if the blog was changed since.
+	Blog       *blogItem `bson:"blog,omitempty"`
+	CreateTime time.Time `bson:"create_time"`
+}
+
+// RequestStore remembers the request ids of CreateBlog calls for a while, so a
+// retried call does not create its blog twice.
+type RequestStore interface {
+	// Reserve records a new request id. When the id is already known, it
+	// returns what is recorded for it together with errRequestExists.
+	Reserve(ctx context.Context, data *requestItem) (*requestItem, error)
+	// Complete records the blog created for a reserved request id.
+	Complete(ctx context.Context, key string, blog *blogItem) error
+	// Release forgets a reserved request id whose blog was not created, so
+	// the call can be retried.
+	Release(ctx context.Context, key string) error
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	bpb "blog/pb"
+)
+
+func (s *server) ListBlogRevisions(ctx context.Context, req *bpb.ListBlogRevisionsRequest) (*bpb.ListBlogRevisionsResponse, error) {
+	fmt.Println("list blog revisions request")
+
+	oid, err := primitive.ObjectIDFromHex(req.GetBlogId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("cannot parse id"),
+		)
+	}
+
+	size, err := pageSize(req.GetPageSize())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	before, err := decodeRevisionPageToken(req.GetPageToken())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	seeAll, err := s.checkCanReadHistory(ctx, oid)
+	if err != nil {
+		return nil, err
+	}
+
+	// fetch one revision past the page, it tells whether there is a next page
+	// and what the last revision of the page changed
+	var revs []*revisionItem
+	err = s.listRevisions(ctx, oid, before, size+1, seeAll, func(rev *revisionItem) error {
+		revs = append(revs, rev)
+		return nil
+	})
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			fmt.Sprintf("cannot list blog revisions: %v", err),
+		)
+	}
+
+	res := &bpb.ListBlogRevisionsResponse{}
+	for i, rev := range revs {
+		if i == size {
+			res.NextPageToken = encodeRevisionPageToken(revs[i-1].Revision)
+			break
+		}
+
+		var prev *revisionItem
+		if i+1 < len(revs) {
+			prev = revs[i+1]
+		}
+		res.Revisions = append(res.Revisions, revisionToPb(rev, prev))
+	}
+	return res, nil
+}
+
+func (s *server) GetBlogRevision(ctx context.Context, req *bpb.GetBlogRevisionRequest) (*bpb.GetBlogRevisionResponse, error) {
+	fmt.Println("get blog revision request")
+
+	oid, err := primitive.ObjectIDFromHex(req.GetBlogId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("cannot parse id"),
+		)
+	}
+
+	seeAll, err := s.checkCanReadHistory(ctx, oid)
+	if err != nil {
+		return nil, err
+	}
+
+	rev, err := s.store.ReadRevision(ctx, oid, req.GetRevision())
+	if err != nil {
+		return nil, storeError(err, "cannot find blog revision")
+	}
+	if !seeAll && !revisionPublic(rev) {
+		return nil, status.Errorf(
+			codes.NotFound,
+			fmt.Sprintf("cannot find blog revision"),
+		)
+	}
+
+	prev, err := s.previousRevision(ctx, rev, seeAll)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bpb.GetBlogRevisionResponse{
+		Revision: revisionToPb(rev, prev),
+	}, nil
+}
+
+func (s *server) RollbackBlog(ctx context.Context, req *bpb.RollbackBlogRequest) (*bpb.RollbackBlogResponse, error) {
+	fmt.Println("rollback blog request")
+
+	oid, err := primitive.ObjectIDFromHex(req.GetBlogId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("cannot parse id"),
+		)
+	}
+
+	// the caller must be able to change the blog before its history is read
+	data, err := s.readForWrite(ctx, oid, req.GetExpectedRevision())
+	if err != nil {
+		return nil, err
+	}
+	if data.DeleteTime != nil {
+		return nil, status.Errorf(
+			codes.NotFound,
+			fmt.Sprintf("cannot find blog with specified id: blog is in the trash"),
+		)
+	}
+
+	rev, err := s.store.ReadRevision(ctx, oid, req.GetRevision())
+	if err != nil {
+		return nil, storeError(err, "cannot find blog revision")
+	}
+
+	// rolling back can hand the blog back to an earlier author
+	if err := checkCanWrite(ctx, rev.Blog.AuthorID); err != nil {
+		return nil, err
+	}
+
+	readRevision := data.Revision
+	data.AuthorID = rev.Blog.AuthorID
+	data.Title = rev.Blog.Title
+	data.Content = rev.Blog.Content
+	data.ContentFormat = rev.Blog.ContentFormat
+	data.Tags = rev.Blog.Tags
+	data.UpdateTime = now()
+	data.Revision++
+
+	data, err = s.writeBlog(ctx, data, readRevision, req.GetExpectedRevision())
+	if err != nil {
+		return nil, err
+	}
+
+	blog, err := s.blogToPb(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bpb.RollbackBlogResponse{
+		Blog: blog,
+	}, nil
+}
+
+// checkCanReadHistory returns NOT_FOUND unless the caller may read the
+// revisions of the blog with the given id. Everyone reads the history of
+// published blogs, the history of unpublished and trashed blogs is read only
+// by those who may change them, as it shows what they hide. seeAll reports
+// whether the caller may change the blog, and so read its revisions that were
+// not public.
+func (s *server) checkCanReadHistory(ctx context.Context, oid primitive.ObjectID) (seeAll bool, err error) {
+	data, err := s.store.Read(ctx, oid)
+	if err != nil {
+		return false, storeError(err, "cannot find blog with specified id")
+	}
+	if checkCanWrite(ctx, data.AuthorID) == nil {
+		return true, nil
+	}
+	if data.state() != statePublished || data.DeleteTime != nil {
+		return false, status.Errorf(
+			codes.NotFound,
+			fmt.Sprintf("cannot find blog with specified id"),
+		)
+	}
+	return false, nil
+}
+
+// revisionPublic reports whether everyone could read the blog at rev, so
+// drafts and scheduled or trashed revisions of a blog published since stay
+// hidden.
+func revisionPublic(rev *revisionItem) bool {
+	return rev.Blog.state() == statePublished && rev.Blog.DeleteTime == nil
+}
+
+// errEnoughRevisions stops listing revisions once enough public ones are
+// found.
+var errEnoughRevisions = errors.New("enough revisions")
+
+// listRevisions is store.ListRevisions leaving out the revisions that were not
+// public unless seeAll is set. limit counts the revisions fn is called with.
+func (s *server) listRevisions(ctx context.Context, oid primitive.ObjectID, before int64, limit int, seeAll bool, fn func(*revisionItem) error) error {
+	if seeAll {
+		return s.store.ListRevisions(ctx, oid, before, limit, fn)
+	}
+
+	n := 0
+	err := s.store.ListRevisions(ctx, oid, before, 0, func(rev *revisionItem) error {
+		if !revisionPublic(rev) {
+			return nil
+		}
+		if err := fn(rev); err != nil {
+			return err
+		}
+		n++
+		if n == limit {
+			return errEnoughRevisions
+		}
+		return nil
+	})
+	if err == errEnoughRevisions {
+		return nil
+	}
+	return err
+}
+
+// previousRevision returns the revision recorded right before rev, or nil if
+// there is none. Unless seeAll is set, it is the public revision before rev,
+// so the changed fields do not tell what the hidden revisions changed.
+func (s *server) previousRevision(ctx context.Context, rev *revisionItem, seeAll bool) (*revisionItem, error) {
+	var prev *revisionItem
+	err := s.listRevisions(ctx, rev.BlogID, rev.Revision, 1, seeAll, func(r *revisionItem) error {
+		prev = r
+		return nil
+	})
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			fmt.Sprintf("cannot list blog revisions: %v", err),
+		)
+	}
+	return prev, nil
+}
+
+// revisionToPb converts rev, listing the fields that changed since prev.
+func revisionToPb(rev, prev *revisionItem) *bpb.BlogRevision {
+	res := &bpb.BlogRevision{
+		BlogId:     rev.BlogID.Hex(),
+		Revision:   rev.Revision,
+		Blog:       dataToBlogPb(&rev.Blog),
+		CreateTime: timestampProto(rev.CreateTime),
+	}
+	if prev == nil {
+		return res
+	}
+
+	if rev.Blog.AuthorID != prev.Blog.AuthorID {
+		res.ChangedFields = append(res.ChangedFields, "author_id")
+	}
+	if rev.Blog.Title != prev.Blog.Title {
+		res.ChangedFields = append(res.ChangedFields, "title")
+	}
+	if rev.Blog.Content != prev.Blog.Content {
+		res.ChangedFields = append(res.ChangedFields, "content")
+	}
+	if contentFormatToPb(rev.Blog.ContentFormat) != contentFormatToPb(prev.Blog.ContentFormat) {
+		res.ChangedFields = append(res.ChangedFields, "content_format")
+	}
+	if strings.Join(rev.Blog.Tags, ",") != strings.Join(prev.Blog.Tags, ",") {
+		res.ChangedFields = append(res.ChangedFields, "tags")
+	}
+	if rev.Blog.state() != prev.Blog.state() {
+		res.ChangedFields = append(res.ChangedFields, "state")
+	}
+	if (rev.Blog.DeleteTime == nil) != (prev.Blog.DeleteTime == nil) {
+		res.ChangedFields = append(res.ChangedFields, "delete_time")
+	}
+	return res
+}
+
+// encodeRevisionPageToken returns a token that resumes a revision listing
+// right after the given revision.
+func encodeRevisionPageToken(last int64) string {
+	return strconv.FormatInt(last, 10)
+}
+
+// decodeRevisionPageToken is the inverse of encodeRevisionPageToken. An empty
+// token decodes to 0, which lists from the newest revision.
+func decodeRevisionPageToken(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	rev, err := strconv.ParseInt(token, 10, 64)
+	if err != nil || rev <= 0 {
+		return 0, errors.New("malformed page token")
+	}
+	return rev, nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	bpb "blog/pb"
+)
+
+// as returns a context of a call made by authorID, or by an anonymous caller
+// when authorID is empty, as the auth interceptors set it up.
+func as(authorID string) context.Context {
+	return context.WithValue(context.Background(), callerKey{}, &caller{AuthorID: authorID})
+}
+
+func TestRevisionsHideUnpublishedHistory(t *testing.T) {
+	s := newTestServer()
+	alice := as("alice")
+
+	res, err := s.CreateBlog(alice, &bpb.CreateBlogRequest{Blog: &bpb.Blog{
+		AuthorId: "alice",
+		Title:    "secret draft",
+		Content:  "embargoed numbers",
+		State:    bpb.Blog_DRAFT,
+	}})
+	if err != nil {
+		t.Fatalf("CreateBlog: %v", err)
+	}
+	id := res.GetBlog().GetId()
+	_, err = s.UpdateBlog(alice, &bpb.UpdateBlogRequest{Blog: &bpb.Blog{
+		Id:       id,
+		AuthorId: "alice",
+		Title:    "announcement",
+		Content:  "public numbers",
+	}})
+	if err != nil {
+		t.Fatalf("UpdateBlog: %v", err)
+	}
+	if _, err := s.PublishBlog(alice, &bpb.PublishBlogRequest{BlogId: id}); err != nil {
+		t.Fatalf("PublishBlog: %v", err)
+	}
+
+	for _, tt := range []struct {
+		name string
+		ctx  context.Context
+		want []int64
+	}{
+		{"anonymous", as(""), []int64{3}},
+		{"another author", as("bob"), []int64{3}},
+		{"author", alice, []int64{3, 2, 1}},
+	} {
+		list, err := s.ListBlogRevisions(tt.ctx, &bpb.ListBlogRevisionsRequest{BlogId: id})
+		if err != nil {
+			t.Fatalf("%s: ListBlogRevisions: %v", tt.name, err)
+		}
+		var got []int64
+		for _, rev := range list.GetRevisions() {
+			got = append(got, rev.GetRevision())
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: ListBlogRevisions listed revisions %v, want %v", tt.name, got, tt.want)
+		}
+
+		for rev := int64(1); rev <= 3; rev++ {
+			visible := false
+			for _, w := range tt.want {
+				visible = visible || w == rev
+			}
+			res, err := s.GetBlogRevision(tt.ctx, &bpb.GetBlogRevisionRequest{BlogId: id, Revision: rev})
+			switch {
+			case visible && err != nil:
+				t.Errorf("%s: GetBlogRevision(%d): %v", tt.name, rev, err)
+			case !visible && status.Code(err) != codes.NotFound:
+				t.Errorf("%s: GetBlogRevision(%d) = %v, %v, want %v", tt.name, rev, res.GetRevision().GetBlog(), err, codes.NotFound)
+			}
+		}
+	}
+
+	// the first public revision is not compared with the hidden ones
+	rev3, err := s.GetBlogRevision(as(""), &bpb.GetBlogRevisionRequest{BlogId: id, Revision: 3})
+	if err != nil {
+		t.Fatalf("GetBlogRevision: %v", err)
+	}
+	if changed := rev3.GetRevision().GetChangedFields(); len(changed) != 0 {
+		t.Errorf("first public revision changed %v, want nothing", changed)
+	}
+}
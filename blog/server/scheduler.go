@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// clock tells the time to the scheduler. Tests replace it with a fake clock
+// to drive the scheduler without waiting.
+type clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// systemClock is the clock of the machine.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// scheduler publishes scheduled blogs once their publish time comes.
+type scheduler struct {
+	store    BlogStore
+	clock    clock
+	interval time.Duration
+	// done is closed once run returns
+	done chan struct{}
+}
+
+func newScheduler(store BlogStore, clock clock, interval time.Duration) *scheduler {
+	return &scheduler{store: store, clock: clock, interval: interval, done: make(chan struct{})}
+}
+
+// run publishes the blogs that are due every interval, until ctx is done.
+func (s *scheduler) run(ctx context.Context) {
+	defer close(s.done)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.clock.After(s.interval):
+		}
+
+		n, err := s.publishDue(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("cannot publish scheduled blogs: %v", err)
+		}
+		if n > 0 {
+			fmt.Printf("published %d scheduled blogs\n", n)
+		}
+	}
+}
+
+// publishDue publishes the scheduled blogs whose publish time has come and
+// returns how many it published. Blogs in the trash wait until they are
+// restored.
+func (s *scheduler) publishDue(ctx context.Context) (int, error) {
+	var due []*blogItem
+	q := listQuery{
+		States:        []blogState{stateScheduled},
+		PublishBefore: s.clock.Now(),
+	}
+	err := s.store.List(ctx, q, func(data *blogItem) error {
+		due = append(due, data)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, data := range due {
+		readRevision := data.Revision
+		data.State = statePublished
+		data.UpdateTime = s.clock.Now().UTC().Truncate(time.Millisecond)
+		data.Revision++
+
+		// a blog changed since it was listed is looked at again next time
+		_, err := s.store.Update(ctx, data, readRevision)
+		switch err {
+		case nil:
+			published++
+		case errRevisionMismatch, errBlogNotFound:
+		default:
+			return published, err
+		}
+	}
+	return published, nil
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	bpb "blog/pb"
+)
+
+// fakeClock is a clock that only moves when told to.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	timers  []fakeTimer
+	waiting chan struct{}
+}
+
+type fakeTimer struct {
+	at time.Time
+	c  chan time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now, waiting: make(chan struct{}, 1)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel that gets the time once the clock is advanced by d,
+// and tells waitForTimer that somebody waits on the clock.
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := fakeTimer{at: c.now.Add(d), c: make(chan time.Time, 1)}
+	c.timers = append(c.timers, t)
+	select {
+	case c.waiting <- struct{}{}:
+	default:
+	}
+	return t.c
+}
+
+// Advance moves the clock forward by d and fires the timers that are due.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if t.at.After(c.now) {
+			pending = append(pending, t)
+			continue
+		}
+		t.c <- c.now
+	}
+	c.timers = pending
+}
+
+// waitForTimer blocks until somebody calls After.
+func (c *fakeClock) waitForTimer(t *testing.T) {
+	t.Helper()
+	select {
+	case <-c.waiting:
+	case <-time.After(5 * time.Second):
+		t.Fatal("nobody waits on the clock")
+	}
+}
+
+// createScheduledBlog creates a blog to be published at the given time.
+func createScheduledBlog(t *testing.T, s *server, at time.Time) *bpb.Blog {
+	t.Helper()
+	publishTime, err := ptypes.TimestampProto(at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return createTestBlog(t, s, &bpb.Blog{
+		AuthorId:    "alice",
+		Title:       "title",
+		Content:     "content",
+		State:       bpb.Blog_SCHEDULED,
+		PublishTime: publishTime,
+	})
+}
+
+func readState(t *testing.T, s *server, id string) bpb.Blog_State {
+	t.Helper()
+	res, err := s.ReadBlog(context.Background(), &bpb.ReadBlogRequest{BlogId: id})
+	if err != nil {
+		t.Fatalf("ReadBlog: %v", err)
+	}
+	return res.GetBlog().GetState()
+}
+
+func TestSchedulerPublishesDueBlogs(t *testing.T) {
+	s := newTestServer()
+	start := time.Now()
+	clock := newFakeClock(start)
+	sched := newScheduler(s.store, clock, time.Minute)
+
+	blog := createScheduledBlog(t, s, start.Add(time.Hour))
+	if got := readState(t, s, blog.GetId()); got != bpb.Blog_SCHEDULED {
+		t.Fatalf("new blog is %v, want %v", got, bpb.Blog_SCHEDULED)
+	}
+
+	steps := []struct {
+		advance time.Duration
+		want    bpb.Blog_State
+	}{
+		{0, bpb.Blog_SCHEDULED},
+		{59 * time.Minute, bpb.Blog_SCHEDULED},
+		{2 * time.Minute, bpb.Blog_PUBLISHED},
+	}
+	for _, step := range steps {
+		clock.Advance(step.advance)
+		if _, err := sched.publishDue(context.Background()); err != nil {
+			t.Fatalf("publishDue: %v", err)
+		}
+		if got := readState(t, s, blog.GetId()); got != step.want {
+			t.Errorf("%v after the start, blog is %v, want %v", clock.Now().Sub(start), got, step.want)
+		}
+	}
+}
+
+func TestSchedulerRespectsUnpublish(t *testing.T) {
+	s := newTestServer()
+	start := time.Now()
+	clock := newFakeClock(start)
+	sched := newScheduler(s.store, clock, time.Minute)
+
+	blog := createScheduledBlog(t, s, start.Add(time.Hour))
+	_, err := s.UnpublishBlog(context.Background(), &bpb.UnpublishBlogRequest{BlogId: blog.GetId()})
+	if err != nil {
+		t.Fatalf("UnpublishBlog: %v", err)
+	}
+
+	clock.Advance(2 * time.Hour)
+	n, err := sched.publishDue(context.Background())
+	if err != nil {
+		t.Fatalf("publishDue: %v", err)
+	}
+	if n != 0 {
+		t.Errorf("publishDue published %d blogs, want 0", n)
+	}
+	if got := readState(t, s, blog.GetId()); got != bpb.Blog_DRAFT {
+		t.Errorf("blog is %v, want %v", got, bpb.Blog_DRAFT)
+	}
+}
+
+func TestSchedulerRespectsReschedule(t *testing.T) {
+	s := newTestServer()
+	start := time.Now()
+	clock := newFakeClock(start)
+	sched := newScheduler(s.store, clock, time.Minute)
+
+	blog := createScheduledBlog(t, s, start.Add(time.Hour))
+	later, err := ptypes.TimestampProto(start.Add(3 * time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.PublishBlog(context.Background(), &bpb.PublishBlogRequest{BlogId: blog.GetId(), PublishTime: later})
+	if err != nil {
+		t.Fatalf("PublishBlog: %v", err)
+	}
+
+	steps := []struct {
+		advance time.Duration
+		want    bpb.Blog_State
+	}{
+		{2 * time.Hour, bpb.Blog_SCHEDULED},
+		{time.Hour + time.Minute, bpb.Blog_PUBLISHED},
+	}
+	for _, step := range steps {
+		clock.Advance(step.advance)
+		if _, err := sched.publishDue(context.Background()); err != nil {
+			t.Fatalf("publishDue: %v", err)
+		}
+		if got := readState(t, s, blog.GetId()); got != step.want {
+			t.Errorf("%v after the start, blog is %v, want %v", clock.Now().Sub(start), got, step.want)
+		}
+	}
+}
+
+func TestSchedulerRun(t *testing.T) {
+	s := newTestServer()
+	start := time.Now()
+	clock := newFakeClock(start)
+	sched := newScheduler(s.store, clock, time.Minute)
+	blog := createScheduledBlog(t, s, start.Add(90*time.Second))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go sched.run(ctx)
+
+	// the first check, a minute in, is too early
+	clock.waitForTimer(t)
+	clock.Advance(time.Minute)
+	clock.waitForTimer(t)
+	if got := readState(t, s, blog.GetId()); got != bpb.Blog_SCHEDULED {
+		t.Errorf("after a minute, blog is %v, want %v", got, bpb.Blog_SCHEDULED)
+	}
+
+	clock.Advance(time.Minute)
+	clock.waitForTimer(t)
+	if got := readState(t, s, blog.GetId()); got != bpb.Blog_PUBLISHED {
+		t.Errorf("after two minutes, blog is %v, want %v", got, bpb.Blog_PUBLISHED)
+	}
+
+	cancel()
+	select {
+	case <-sched.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("scheduler did not stop when its context was cancelled")
+	}
+}
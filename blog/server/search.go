@@ -0,0 +1,274 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	bpb "blog/pb"
+)
+
+const (
+	// titleWeight is how much more a word in the title counts than a word in
+	// the content when search results are ranked.
+	titleWeight = 10
+	// snippetLength is the most characters a search snippet holds.
+	snippetLength = 160
+	// snippetLead is how many characters a snippet shows before the first
+	// match it highlights.
+	snippetLead = 40
+)
+
+// stopWords are left out of searches, they are in nearly every blog.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "but": true, "by": true, "for": true, "if": true, "in": true,
+	"into": true, "is": true, "it": true, "no": true, "not": true, "of": true,
+	"on": true, "or": true, "s": true, "such": true, "that": true, "the": true,
+	"their": true, "then": true, "there": true, "these": true, "they": true,
+	"this": true, "to": true, "was": true, "will": true, "with": true,
+}
+
+// searchQuery is a search run by BlogStore.Search.
+type searchQuery struct {
+	// Words are the lower case words to look for, without stop words.
+	Words []string
+	// Skip leaves out this many of the best results.
+	Skip int
+	// Limit caps the number of results, 0 means no limit.
+	Limit int
+}
+
+// searchHit is a blog found by BlogStore.Search.
+type searchHit struct {
+	Blog  *blogItem
+	Score float64
+}
+
+// token is a word of a text, start and end are its position in the text
+// counted in runes.
+type token struct {
+	word       string
+	start, end int
+}
+
+// tokenize splits text into lower case words made of letters and digits.
+func tokenize(text []rune) []token {
+	var tokens []token
+	start := -1
+	for i := 0; i <= len(text); i++ {
+		if i < len(text) && (unicode.IsLetter(text[i]) || unicode.IsDigit(text[i])) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			tokens = append(tokens, token{
+				word:  strings.ToLower(string(text[start:i])),
+				start: start,
+				end:   i,
+			})
+			start = -1
+		}
+	}
+	return tokens
+}
+
+// stem reduces a word to the form it is indexed under, so plurals match their
+// singular.
+func stem(word string) string {
+	switch {
+	case len(word) > 4 && strings.HasSuffix(word, "ies"):
+		return word[:len(word)-3] + "y"
+	case strings.HasSuffix(word, "sses"):
+		return word[:len(word)-2]
+	case len(word) > 3 && strings.HasSuffix(word, "s") &&
+		!strings.HasSuffix(word, "ss") && !strings.HasSuffix(word, "us") && !strings.HasSuffix(word, "is"):
+		return word[:len(word)-1]
+	}
+	return word
+}
+
+// searchWords returns the words of query that are searched for.
+func searchWords(query string) []string {
+	var words []string
+	seen := make(map[string]bool)
+	for _, t := range tokenize([]rune(query)) {
+		if stopWords[t.word] || seen[t.word] {
+			continue
+		}
+		seen[t.word] = true
+		words = append(words, t.word)
+	}
+	return words
+}
+
+// highlight is the part of a snippet from start up to end, in runes.
+type highlight struct {
+	start, end int
+}
+
+// snippet returns the part of the content of data holding the most words whose
+// stem is in stems, or the title when the content holds none.
+func snippet(data *blogItem, stems map[string]bool) (string, []highlight) {
+	text := []rune(data.Content)
+	tokens := tokenize(text)
+
+	var matches []token
+	for _, t := range tokens {
+		if stems[stem(t.word)] {
+			matches = append(matches, t)
+		}
+	}
+	if len(matches) == 0 {
+		title := []rune(data.Title)
+		var hs []highlight
+		for _, t := range tokenize(title) {
+			if stems[stem(t.word)] {
+				hs = append(hs, highlight{t.start, t.end})
+			}
+		}
+		return string(title), hs
+	}
+
+	// pick the window starting at a match that holds the most matches
+	best, bestCount := 0, 0
+	for i := range matches {
+		count := 0
+		for _, m := range matches[i:] {
+			if m.end-matches[i].start > snippetLength-snippetLead {
+				break
+			}
+			count++
+		}
+		if count > bestCount {
+			best, bestCount = i, count
+		}
+	}
+
+	// widen the window to whole words around the matches
+	start, end := 0, len(text)
+	for _, t := range tokens {
+		if t.start >= matches[best].start-snippetLead {
+			start = t.start
+			break
+		}
+	}
+	if end-start > snippetLength {
+		end = start
+		for _, t := range tokens {
+			if t.start >= start && t.end-start <= snippetLength {
+				end = t.end
+			}
+		}
+	}
+
+	var b strings.Builder
+	offset := -start
+	if start > 0 {
+		b.WriteString("…")
+		offset++
+	}
+	b.WriteString(string(text[start:end]))
+	if end < len(text) {
+		b.WriteString("…")
+	}
+
+	var hs []highlight
+	for _, m := range matches {
+		if m.start >= start && m.end <= end {
+			hs = append(hs, highlight{m.start + offset, m.end + offset})
+		}
+	}
+	return b.String(), hs
+}
+
+func (s *server) SearchBlogs(ctx context.Context, req *bpb.SearchBlogsRequest) (*bpb.SearchBlogsResponse, error) {
+	fmt.Println("search blogs request")
+
+	size, err := pageSize(req.GetPageSize())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	skip, err := decodeSearchPageToken(req.GetPageToken())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	res := &bpb.SearchBlogsResponse{}
+	words := searchWords(req.GetQuery())
+	if len(words) == 0 {
+		return res, nil
+	}
+
+	// fetch one result past the page to find out whether there is a next page
+	hits, err := s.store.Search(ctx, searchQuery{Words: words, Skip: skip, Limit: size + 1})
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			fmt.Sprintf("cannot search blogs: %v", err),
+		)
+	}
+	if len(hits) > size {
+		hits = hits[:size]
+		res.NextPageToken = encodeSearchPageToken(skip + size)
+	}
+
+	items := make([]*blogItem, len(hits))
+	for i, hit := range hits {
+		items[i] = hit.Blog
+	}
+	blogs, err := s.blogsToPb(ctx, items)
+	if err != nil {
+		return nil, err
+	}
+
+	stems := make(map[string]bool, len(words))
+	for _, w := range words {
+		stems[stem(w)] = true
+	}
+	for i, hit := range hits {
+		text, hs := snippet(hit.Blog, stems)
+		result := &bpb.SearchBlogsResponse_Result{
+			Blog:    blogs[i],
+			Score:   hit.Score,
+			Snippet: text,
+		}
+		for _, h := range hs {
+			result.Highlights = append(result.Highlights, &bpb.SearchBlogsResponse_Highlight{
+				Start: int32(h.start),
+				End:   int32(h.end),
+			})
+		}
+		res.Results = append(res.Results, result)
+	}
+	return res, nil
+}
+
+// encodeSearchPageToken returns a token that resumes a search after skipping
+// the given number of results.
+func encodeSearchPageToken(skip int) string {
+	return strconv.Itoa(skip)
+}
+
+// decodeSearchPageToken is the inverse of encodeSearchPageToken. An empty
+// token decodes to 0, which starts from the best result.
+func decodeSearchPageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	skip, err := strconv.Atoi(token)
+	if err != nil || skip <= 0 {
+		return 0, errors.New("malformed page token")
+	}
+	return skip, nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"math"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// termCount is how often a term appears in the title and the content of a
+// blog.
+type termCount struct {
+	title, content int
+}
+
+// searchIndex is an inverted index of the words in the title and content of
+// blogs, the in-memory counterpart of the mongodb text index. It is not safe
+// for concurrent use.
+type searchIndex struct {
+	// postings maps the stem of a word to the blogs containing it
+	postings map[string]map[primitive.ObjectID]termCount
+	// stems maps a blog to the stems it was indexed under
+	stems map[primitive.ObjectID][]string
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{
+		postings: make(map[string]map[primitive.ObjectID]termCount),
+		stems:    make(map[primitive.ObjectID][]string),
+	}
+}
+
+// add indexes data, replacing what was indexed for it before.
+func (x *searchIndex) add(data *blogItem) {
+	x.remove(data.ID)
+
+	counts := make(map[string]termCount)
+	for _, t := range tokenize([]rune(data.Title)) {
+		if !stopWords[t.word] {
+			c := counts[stem(t.word)]
+			c.title++
+			counts[stem(t.word)] = c
+		}
+	}
+	for _, t := range tokenize([]rune(data.Content)) {
+		if !stopWords[t.word] {
+			c := counts[stem(t.word)]
+			c.content++
+			counts[stem(t.word)] = c
+		}
+	}
+
+	stems := make([]string, 0, len(counts))
+	for s, c := range counts {
+		if x.postings[s] == nil {
+			x.postings[s] = make(map[primitive.ObjectID]termCount)
+		}
+		x.postings[s][data.ID] = c
+		stems = append(stems, s)
+	}
+	x.stems[data.ID] = stems
+}
+
+// remove drops the blog with the given id from the index.
+func (x *searchIndex) remove(id primitive.ObjectID) {
+	for _, s := range x.stems[id] {
+		delete(x.postings[s], id)
+		if len(x.postings[s]) == 0 {
+			delete(x.postings, s)
+		}
+	}
+	delete(x.stems, id)
+}
+
+// search scores the blogs that contain any of words. A word counts more the
+// more often it appears in a blog and the fewer blogs it appears in.
+func (x *searchIndex) search(words []string) map[primitive.ObjectID]float64 {
+	scores := make(map[primitive.ObjectID]float64)
+	seen := make(map[string]bool)
+	for _, w := range words {
+		s := stem(w)
+		if seen[s] {
+			continue
+		}
+		seen[s] = true
+
+		blogs := x.postings[s]
+		idf := math.Log(1 + float64(len(x.stems))/float64(len(blogs)))
+		for id, c := range blogs {
+			scores[id] += idf * float64(titleWeight*c.title+c.content)
+		}
+	}
+	return scores
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"blog/render"
+)
+
+var (
+	// errBlogNotFound is returned by a BlogStore when no blog matches the given id.
+	errBlogNotFound = errors.New("blog not found")
+	// errRevisionMismatch is returned by a BlogStore when a conditional write
+	// finds the blog at another revision than expected.
+	errRevisionMismatch = errors.New("blog revision does not match")
+	// errRevisionNotFound is returned by a BlogStore when a blog has no record
+	// of the requested revision.
+	errRevisionNotFound = errors.New("blog revision not found")
+)
+
+// blogState is the publishing state of a blog.
+type blogState string
+
+const (
+	stateDraft     blogState = "draft"
+	stateScheduled blogState = "scheduled"
+	statePublished blogState = "published"
+	stateArchived  blogState = "archived"
+)
+
+type blogItem struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	AuthorID   string             `bson:"author_id"`
+	Content    string             `bson:"content"`
+	Title      string             `bson:"title"`
+	CreateTime time.Time          `bson:"create_time"`
+	UpdateTime time.Time          `bson:"update_time"`
+	Revision   int64              `bson:"revision"`
+	// DeleteTime is set while the blog is in the trash.
+	DeleteTime *time.Time `bson:"delete_time,omitempty"`
+	Tags       []string   `bson:"tags,omitempty"`
+	// ContentFormat is empty for blogs stored before formats were tracked,
+	// which are plain.
+	ContentFormat render.Format `bson:"content_format,omitempty"`
+	// State is empty for blogs stored before states were tracked, which are
+	// published. Use state to read it.
+	State       blogState  `bson:"state,omitempty"`
+	PublishTime *time.Time `bson:"publish_time,omitempty"`
+}
+
+// state returns the publishing state of b.
+func (b *blogItem) state() blogState {
+	if b.State == "" {
+		return statePublished
+	}
+	return b.State
+}
+
+// revisionItem is an immutable snapshot of a blog, recorded every time the
+// blog is written.
+type revisionItem struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	BlogID     primitive.ObjectID `bson:"blog_id"`
+	Revision   int64              `bson:"revision"`
+	Blog       blogItem           `bson:"blog"`
+	CreateTime time.Time          `bson:"create_time"`
+}
+
+func newRevisionItem(data *blogItem) *revisionItem {
+	return &revisionItem{
+		BlogID:     data.ID,
+		Revision:   data.Revision,
+		Blog:       *data,
+		CreateTime: now(),
+	}
+}
+
+// listQuery narrows down and orders the blogs returned by BlogStore.List.
+type listQuery struct {
+	// AuthorID keeps only the blogs written by this author, unless it is empty.
+	AuthorID string
+	// Text keeps only the blogs whose title or content contains it, ignoring
+	// case, unless it is empty.
+	Text string
+	// Order is the order in which blogs are listed.
+	Order listOrder
+	// After skips every blog up to and including the one the cursor points at,
+	// unless it is nil.
+	After *pageCursor
+	// AnyTags keeps only the blogs that have at least one of these tags.
+	AnyTags []string
+	// AllTags keeps only the blogs that have all of these tags.
+	AllTags []string
+	// States keeps only the blogs in one of these states, unless it is empty.
+	States []blogState
+	// PublishBefore keeps only the blogs whose publish time is not after it,
+	// unless it is zero.
+	PublishBefore time.Time
+	// ShowDeleted also lists the blogs in the trash.
+	ShowDeleted bool
+	// Limit caps the number of blogs listed, 0 means no limit.
+	Limit int
+}
+
+// tagCount is the number of blogs with a tag.
+type tagCount struct {
+	Tag   string `bson:"_id"`
+	Count int64  `bson:"count"`
+}
+
+// watchQuery narrows down the events reported by BlogStore.Watch.
+type watchQuery struct {
+	// AuthorID keeps only the events about blogs of this author, unless it is
+	// empty. Purge events carry no author and are dropped by this filter.
+	AuthorID string
+	// ResumeToken starts the watch right after the event that carried it,
+	// instead of at the next change, unless it is empty.
+	ResumeToken string
+}
+
+// BlogStore is the storage backend used by the blog server.
+type BlogStore interface {
+	// Create stores a new blog and returns it with its assigned id. Create and
+	// Update record a revision of every blog they write.
+	Create(ctx context.Context, data *blogItem) (*blogItem, error)
+	// CreateMany stores several new blogs at once. It returns the created
+	// blogs and the errors of the blogs that could not be created, both in
+	// the order of data.
+	CreateMany(ctx context.Context, data []*blogItem) ([]*blogItem, []error)
+	// Read returns the blog with the given id, whether it is in the trash or not.
+	Read(ctx context.Context, id primitive.ObjectID) (*blogItem, error)
+	// ReadMany returns the blogs with the given ids, in no particular order.
+	// Ids without a blog are skipped.
+	ReadMany(ctx context.Context, ids []primitive.ObjectID) ([]*blogItem, error)
+	// Update replaces the stored blog that has the same id as data, provided
+	// the stored blog is still at revision ifRevision.
+	Update(ctx context.Context, data *blogItem, ifRevision int64) (*blogItem, error)
+	// Delete removes the blog with the given id and its revisions for good.
+	// Unless ifRevision is 0, the blog is only removed while it is at revision
+	// ifRevision.
+	Delete(ctx context.Context, id primitive.ObjectID, ifRevision int64) error
+	// List calls fn for every blog matching q until fn returns an error.
+	List(ctx context.Context, q listQuery, fn func(*blogItem) error) error
+	// ReadRevision returns the given revision of a blog.
+	ReadRevision(ctx context.Context, blogID primitive.ObjectID, revision int64) (*revisionItem, error)
+	// ListRevisions calls fn for the revisions of a blog, newest first, until
+	// fn returns an error. Only revisions older than before are listed unless
+	// before is 0, and at most limit revisions unless limit is 0.
+	ListRevisions(ctx context.Context, blogID primitive.ObjectID, before int64, limit int, fn func(*revisionItem) error) error
+	// ListTags returns every tag of the published blogs that are not in the
+	// trash, most used first, and ties ordered by tag.
+	ListTags(ctx context.Context) ([]tagCount, error)
+	// Search returns the published blogs that are not in the trash and contain
+	// any of the words of q in their title or content, most relevant first.
+	Search(ctx context.Context, q searchQuery) ([]searchHit, error)
+	// Watch calls fn for every change to the blogs matching q, in the order
+	// they happened, until ctx is done or fn returns an error.
+	Watch(ctx context.Context, q watchQuery, fn func(*blogEvent) error) error
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	bpb "blog/pb"
+)
+
+func (s *server) ListTags(ctx context.Context, req *bpb.ListTagsRequest) (*bpb.ListTagsResponse, error) {
+	fmt.Println("list tags request")
+
+	tags, err := s.store.ListTags(ctx)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			fmt.Sprintf("cannot list tags: %v", err),
+		)
+	}
+
+	res := &bpb.ListTagsResponse{}
+	for _, t := range tags {
+		res.Tags = append(res.Tags, &bpb.ListTagsResponse_Tag{
+			Tag:       t.Tag,
+			BlogCount: t.Count,
+		})
+	}
+	return res, nil
+}
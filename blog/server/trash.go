@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	bpb "blog/pb"
+)
+
+func (s *server) RestoreBlog(ctx context.Context, req *bpb.RestoreBlogRequest) (*bpb.RestoreBlogResponse, error) {
+	fmt.Println("restore blog request")
+
+	oid, err := primitive.ObjectIDFromHex(req.GetBlogId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("cannot parse id"),
+		)
+	}
+
+	data, err := s.readForWrite(ctx, oid, req.GetExpectedRevision())
+	if err != nil {
+		return nil, err
+	}
+	if data.DeleteTime == nil {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			fmt.Sprintf("blog is not in the trash"),
+		)
+	}
+
+	readRevision := data.Revision
+	data.DeleteTime = nil
+	data.Revision++
+
+	data, err = s.writeBlog(ctx, data, readRevision, req.GetExpectedRevision())
+	if err != nil {
+		return nil, err
+	}
+
+	blog, err := s.blogToPb(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bpb.RestoreBlogResponse{
+		Blog: blog,
+	}, nil
+}
+
+func (s *server) PurgeBlog(ctx context.Context, req *bpb.PurgeBlogRequest) (*bpb.PurgeBlogResponse, error) {
+	fmt.Println("purge blog request")
+
+	oid, err := primitive.ObjectIDFromHex(req.GetBlogId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("cannot parse id"),
+		)
+	}
+
+	data, err := s.readForWrite(ctx, oid, req.GetExpectedRevision())
+	if err != nil {
+		return nil, err
+	}
+	if data.DeleteTime == nil {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			fmt.Sprintf("blog must be deleted before it is purged"),
+		)
+	}
+
+	// only purge the blog as it was checked, a concurrent restore must win
+	if err := s.store.Delete(ctx, oid, data.Revision); err != nil {
+		return nil, storeError(err, "cannot purge blog")
+	}
+
+	// the comments cannot be reached without their blog, so a failure here
+	// leaves them behind without being seen
+	if err := s.comments.DeleteBlog(ctx, oid); err != nil {
+		log.Printf("cannot delete the comments of purged blog %s: %v", oid.Hex(), err)
+	}
+
+	return &bpb.PurgeBlogResponse{BlogId: req.GetBlogId()}, nil
+}
@@ -0,0 +1,463 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	bpb "blog/pb"
+)
+
+const (
+	maxAuthorIDLength  = 64
+	maxTitleLength     = 200
+	maxContentLength   = 64 * 1024
+	maxTags            = 20
+	maxTagLength       = 50
+	maxCommentLength   = 4 * 1024
+	maxRequestIDLength = 128
+)
+
+// check validates a single field value and returns a description of what is
+// wrong with it, or an empty string when the value is fine.
+type check func(value string) string
+
+// fieldRule lists the checks a request field must pass.
+type fieldRule struct {
+	field  string
+	value  string
+	checks []check
+}
+
+func required(value string) string {
+	if value == "" {
+		return "must not be empty"
+	}
+	return ""
+}
+
+func absent(value string) string {
+	if value != "" {
+		return "must be empty"
+	}
+	return ""
+}
+
+func objectID(value string) string {
+	if value == "" {
+		return ""
+	}
+	if _, err := primitive.ObjectIDFromHex(value); err != nil {
+		return "must be a valid id"
+	}
+	return ""
+}
+
+func contentFormat(value string) string {
+	if _, ok := bpb.Blog_ContentFormat_value[value]; !ok {
+		return "must be PLAIN or MARKDOWN"
+	}
+	return ""
+}
+
+func createState(value string) string {
+	switch value {
+	case "STATE_UNSPECIFIED", "DRAFT", "SCHEDULED", "PUBLISHED":
+		return ""
+	}
+	return "must be DRAFT, SCHEDULED or PUBLISHED"
+}
+
+func listState(value string) string {
+	if _, ok := bpb.Blog_State_value[value]; !ok || value == "STATE_UNSPECIFIED" {
+		return "must be DRAFT, SCHEDULED, PUBLISHED or ARCHIVED"
+	}
+	return ""
+}
+
+func maxLength(n int) check {
+	return func(value string) string {
+		if utf8.RuneCountInString(value) > n {
+			return fmt.Sprintf("must be at most %d characters long", n)
+		}
+		return ""
+	}
+}
+
+func createBlogRules(req *bpb.CreateBlogRequest) []fieldRule {
+	blog := req.GetBlog()
+	rules := []fieldRule{
+		{"blog.id", blog.GetId(), []check{absent}},
+		// the author defaults to the caller, see authorOf
+		{"blog.author_id", blog.GetAuthorId(), []check{maxLength(maxAuthorIDLength)}},
+		{"blog.title", blog.GetTitle(), []check{required, maxLength(maxTitleLength)}},
+		{"blog.content", blog.GetContent(), []check{maxLength(maxContentLength)}},
+		{"blog.content_format", blog.GetContentFormat().String(), []check{contentFormat}},
+		{"blog.state", blog.GetState().String(), []check{createState}},
+		{"request_id", req.GetRequestId(), []check{maxLength(maxRequestIDLength)}},
+	}
+	if blog.GetState() == bpb.Blog_SCHEDULED {
+		rules = append(rules, fieldRule{"blog.publish_time", "", []check{futureTime(blog.GetPublishTime())}})
+	}
+	return append(rules, tagRules("blog.tags", blog.GetTags())...)
+}
+
+func readBlogRules(req *bpb.ReadBlogRequest) []fieldRule {
+	return []fieldRule{
+		{"blog_id", req.GetBlogId(), []check{required, objectID}},
+	}
+}
+
+func updateBlogRules(req *bpb.UpdateBlogRequest) []fieldRule {
+	blog := req.GetBlog()
+	rules := []fieldRule{
+		{"blog.id", blog.GetId(), []check{required, objectID}},
+		{"expected_revision", "", []check{nonNegative(req.GetExpectedRevision())}},
+	}
+
+	paths, err := updatePaths(req.GetUpdateMask())
+	if err != nil {
+		return append(rules, fieldRule{"update_mask", "", []check{fails(err)}})
+	}
+	// only the fields being changed have to be valid
+	for _, path := range paths {
+		switch path {
+		case "author_id":
+			rules = append(rules, fieldRule{"blog.author_id", blog.GetAuthorId(), []check{required, maxLength(maxAuthorIDLength)}})
+		case "title":
+			rules = append(rules, fieldRule{"blog.title", blog.GetTitle(), []check{required, maxLength(maxTitleLength)}})
+		case "content":
+			rules = append(rules, fieldRule{"blog.content", blog.GetContent(), []check{maxLength(maxContentLength)}})
+		case "content_format":
+			rules = append(rules, fieldRule{"blog.content_format", blog.GetContentFormat().String(), []check{contentFormat}})
+		case "tags":
+			rules = append(rules, tagRules("blog.tags", blog.GetTags())...)
+		}
+	}
+	return rules
+}
+
+func deleteBlogRules(req *bpb.DeleteBlogRequest) []fieldRule {
+	return []fieldRule{
+		{"blog_id", req.GetBlogId(), []check{required, objectID}},
+		{"expected_revision", "", []check{nonNegative(req.GetExpectedRevision())}},
+	}
+}
+
+func restoreBlogRules(req *bpb.RestoreBlogRequest) []fieldRule {
+	return []fieldRule{
+		{"blog_id", req.GetBlogId(), []check{required, objectID}},
+		{"expected_revision", "", []check{nonNegative(req.GetExpectedRevision())}},
+	}
+}
+
+func purgeBlogRules(req *bpb.PurgeBlogRequest) []fieldRule {
+	return []fieldRule{
+		{"blog_id", req.GetBlogId(), []check{required, objectID}},
+		{"expected_revision", "", []check{nonNegative(req.GetExpectedRevision())}},
+	}
+}
+
+func publishBlogRules(req *bpb.PublishBlogRequest) []fieldRule {
+	rules := []fieldRule{
+		{"blog_id", req.GetBlogId(), []check{required, objectID}},
+		{"expected_revision", "", []check{nonNegative(req.GetExpectedRevision())}},
+	}
+	if req.GetPublishTime() != nil {
+		rules = append(rules, fieldRule{"publish_time", "", []check{validTime(req.GetPublishTime())}})
+	}
+	return rules
+}
+
+func unpublishBlogRules(req *bpb.UnpublishBlogRequest) []fieldRule {
+	return []fieldRule{
+		{"blog_id", req.GetBlogId(), []check{required, objectID}},
+		{"expected_revision", "", []check{nonNegative(req.GetExpectedRevision())}},
+	}
+}
+
+func listBlogRevisionsRules(req *bpb.ListBlogRevisionsRequest) []fieldRule {
+	return []fieldRule{
+		{"blog_id", req.GetBlogId(), []check{required, objectID}},
+		{"page_size", "", []check{nonNegative(int64(req.GetPageSize()))}},
+	}
+}
+
+func getBlogRevisionRules(req *bpb.GetBlogRevisionRequest) []fieldRule {
+	return []fieldRule{
+		{"blog_id", req.GetBlogId(), []check{required, objectID}},
+		{"revision", "", []check{positive(req.GetRevision())}},
+	}
+}
+
+func rollbackBlogRules(req *bpb.RollbackBlogRequest) []fieldRule {
+	return []fieldRule{
+		{"blog_id", req.GetBlogId(), []check{required, objectID}},
+		{"revision", "", []check{positive(req.GetRevision())}},
+		{"expected_revision", "", []check{nonNegative(req.GetExpectedRevision())}},
+	}
+}
+
+func batchGetBlogsRules(req *bpb.BatchGetBlogsRequest) []fieldRule {
+	ids := req.GetBlogIds()
+	rules := []fieldRule{
+		{"blog_ids", "", []check{countBetween(len(ids), 1, maxBatchGetBlogs)}},
+	}
+	for i, id := range ids {
+		rules = append(rules, fieldRule{fmt.Sprintf("blog_ids[%d]", i), id, []check{required, objectID}})
+	}
+	return rules
+}
+
+func watchBlogsRules(req *bpb.WatchBlogsRequest) []fieldRule {
+	return []fieldRule{
+		{"author_id", req.GetAuthorId(), []check{maxLength(maxAuthorIDLength)}},
+	}
+}
+
+func listBlogRules(req *bpb.ListBlogRequest) []fieldRule {
+	rules := []fieldRule{
+		{"page_size", "", []check{nonNegative(int64(req.GetPageSize()))}},
+		{"author_id", req.GetAuthorId(), []check{maxLength(maxAuthorIDLength)}},
+		{"query", req.GetQuery(), []check{maxLength(maxTitleLength)}},
+	}
+	for i, st := range req.GetStates() {
+		rules = append(rules, fieldRule{fmt.Sprintf("states[%d]", i), st.String(), []check{listState}})
+	}
+	rules = append(rules, tagRules("any_tags", req.GetAnyTags())...)
+	rules = append(rules, tagRules("all_tags", req.GetAllTags())...)
+	if _, err := parseOrderBy(req.GetOrderBy()); err != nil {
+		rules = append(rules, fieldRule{"order_by", req.GetOrderBy(), []check{fails(err)}})
+	}
+	return rules
+}
+
+func createCommentRules(req *bpb.CreateCommentRequest) []fieldRule {
+	comment := req.GetComment()
+	return []fieldRule{
+		{"comment.id", comment.GetId(), []check{absent}},
+		{"comment.blog_id", comment.GetBlogId(), []check{required, objectID}},
+		// the author defaults to the caller, see authorOf
+		{"comment.author_id", comment.GetAuthorId(), []check{maxLength(maxAuthorIDLength)}},
+		{"comment.content", comment.GetContent(), []check{required, maxLength(maxCommentLength)}},
+	}
+}
+
+func listCommentsRules(req *bpb.ListCommentsRequest) []fieldRule {
+	return []fieldRule{
+		{"blog_id", req.GetBlogId(), []check{required, objectID}},
+		{"page_size", "", []check{nonNegative(int64(req.GetPageSize()))}},
+	}
+}
+
+func updateCommentRules(req *bpb.UpdateCommentRequest) []fieldRule {
+	comment := req.GetComment()
+	return []fieldRule{
+		{"comment.id", comment.GetId(), []check{required, objectID}},
+		{"comment.content", comment.GetContent(), []check{required, maxLength(maxCommentLength)}},
+	}
+}
+
+func deleteCommentRules(req *bpb.DeleteCommentRequest) []fieldRule {
+	return []fieldRule{
+		{"comment_id", req.GetCommentId(), []check{required, objectID}},
+	}
+}
+
+func searchBlogsRules(req *bpb.SearchBlogsRequest) []fieldRule {
+	return []fieldRule{
+		{"query", req.GetQuery(), []check{required, maxLength(maxTitleLength)}},
+		{"page_size", "", []check{nonNegative(int64(req.GetPageSize()))}},
+	}
+}
+
+// tagRules returns the rules for a repeated tags field.
+func tagRules(field string, tags []string) []fieldRule {
+	rules := []fieldRule{
+		{field, "", []check{countBetween(len(tags), 0, maxTags)}},
+	}
+	for i, tag := range tags {
+		rules = append(rules, fieldRule{fmt.Sprintf("%s[%d]", field, i), strings.TrimSpace(tag), []check{required, maxLength(maxTagLength)}})
+	}
+	return rules
+}
+
+// nonNegative returns a check that fails when n is negative. It ignores the
+// string value, which lets numeric fields share the fieldRule table.
+func nonNegative(n int64) check {
+	return func(string) string {
+		if n < 0 {
+			return "must not be negative"
+		}
+		return ""
+	}
+}
+
+// countBetween returns a check that fails unless a repeated field holds between
+// min and max values.
+func countBetween(n, min, max int) check {
+	return func(string) string {
+		if n < min || n > max {
+			return fmt.Sprintf("must hold between %d and %d values", min, max)
+		}
+		return ""
+	}
+}
+
+// positive returns a check that fails unless n is greater than zero.
+func positive(n int64) check {
+	return func(string) string {
+		if n <= 0 {
+			return "must be greater than zero"
+		}
+		return ""
+	}
+}
+
+// validTime returns a check that fails unless ts is a valid timestamp.
+func validTime(ts *timestamp.Timestamp) check {
+	return func(string) string {
+		if _, err := ptypes.Timestamp(ts); err != nil {
+			return "must be a valid timestamp"
+		}
+		return ""
+	}
+}
+
+// futureTime returns a check that fails unless ts is set to a time in the
+// future.
+func futureTime(ts *timestamp.Timestamp) check {
+	return func(string) string {
+		if ts == nil {
+			return "must not be empty"
+		}
+		t, err := ptypes.Timestamp(ts)
+		if err != nil {
+			return "must be a valid timestamp"
+		}
+		if !t.After(time.Now()) {
+			return "must be in the future"
+		}
+		return ""
+	}
+}
+
+// fails returns a check that always reports err.
+func fails(err error) check {
+	return func(string) string {
+		return err.Error()
+	}
+}
+
+// requestRules returns the validation rules for a request, or nil
+// for requests that have none. BulkCreateBlogs checks each blog it gets on its
+// own, so a bad blog does not end the stream.
+func requestRules(req interface{}) []fieldRule {
+	switch req := req.(type) {
+	case *bpb.CreateBlogRequest:
+		return createBlogRules(req)
+	case *bpb.ReadBlogRequest:
+		return readBlogRules(req)
+	case *bpb.UpdateBlogRequest:
+		return updateBlogRules(req)
+	case *bpb.DeleteBlogRequest:
+		return deleteBlogRules(req)
+	case *bpb.ListBlogRequest:
+		return listBlogRules(req)
+	case *bpb.RestoreBlogRequest:
+		return restoreBlogRules(req)
+	case *bpb.PurgeBlogRequest:
+		return purgeBlogRules(req)
+	case *bpb.ListBlogRevisionsRequest:
+		return listBlogRevisionsRules(req)
+	case *bpb.GetBlogRevisionRequest:
+		return getBlogRevisionRules(req)
+	case *bpb.RollbackBlogRequest:
+		return rollbackBlogRules(req)
+	case *bpb.BatchGetBlogsRequest:
+		return batchGetBlogsRules(req)
+	case *bpb.WatchBlogsRequest:
+		return watchBlogsRules(req)
+	case *bpb.SearchBlogsRequest:
+		return searchBlogsRules(req)
+	case *bpb.PublishBlogRequest:
+		return publishBlogRules(req)
+	case *bpb.UnpublishBlogRequest:
+		return unpublishBlogRules(req)
+	case *bpb.CreateCommentRequest:
+		return createCommentRules(req)
+	case *bpb.ListCommentsRequest:
+		return listCommentsRules(req)
+	case *bpb.UpdateCommentRequest:
+		return updateCommentRules(req)
+	case *bpb.DeleteCommentRequest:
+		return deleteCommentRules(req)
+	}
+	return nil
+}
+
+// validateRequest runs the rules for req and returns an INVALID_ARGUMENT status
+// carrying a BadRequest detail with one violation per failed check.
+func validateRequest(req interface{}) error {
+	br := &errdetails.BadRequest{}
+	for _, rule := range requestRules(req) {
+		for _, c := range rule.checks {
+			if desc := c(rule.value); desc != "" {
+				br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+					Field:       rule.field,
+					Description: desc,
+				})
+				break
+			}
+		}
+	}
+	if len(br.FieldViolations) == 0 {
+		return nil
+	}
+
+	msgs := make([]string, 0, len(br.FieldViolations))
+	for _, v := range br.FieldViolations {
+		msgs = append(msgs, fmt.Sprintf("%s %s", v.Field, v.Description))
+	}
+	st := status.New(codes.InvalidArgument, fmt.Sprintf("invalid request: %s", strings.Join(msgs, "; ")))
+	if withDetails, err := st.WithDetails(br); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// validationUnaryInterceptor rejects unary requests that break their rules
+// before they reach the handler.
+func validationUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// validationStreamInterceptor rejects every message received on a stream that
+// breaks its rules.
+func validationStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &validatingStream{ss})
+}
+
+type validatingStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatingStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return validateRequest(m)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	bpb "blog/pb"
+)
+
+func (s *server) WatchBlogs(req *bpb.WatchBlogsRequest, stream bpb.BlogService_WatchBlogsServer) error {
+	fmt.Println("watch blogs request")
+
+	q := watchQuery{
+		AuthorID:    req.GetAuthorId(),
+		ResumeToken: req.GetResumeToken(),
+	}
+	// the watch never ends on its own, so it ends when the server stops
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.stopping.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	err := s.store.Watch(ctx, q, func(ev *blogEvent) error {
+		if ev.Type != eventPurged && !canSee(stream.Context(), &ev.Blog) {
+			return nil
+		}
+		return stream.Send(&bpb.WatchBlogsResponse{
+			EventType:   eventTypeToPb(ev.Type),
+			Blog:        dataToBlogPb(&ev.Blog),
+			ResumeToken: ev.ResumeToken,
+		})
+	})
+
+	// the client went away, there is nobody left to tell
+	if stream.Context().Err() != nil {
+		return nil
+	}
+	if s.stopping.Err() != nil {
+		return status.Errorf(
+			codes.Unavailable,
+			fmt.Sprintf("server is shutting down, resume from the last resume token"),
+		)
+	}
+
+	switch err {
+	case nil:
+		return nil
+	case errInvalidResumeToken:
+		return status.Errorf(codes.InvalidArgument, err.Error())
+	case errResumeTokenExpired:
+		return status.Errorf(
+			codes.FailedPrecondition,
+			fmt.Sprintf("%v, start a new watch without a resume token", err),
+		)
+	case errWatchTooSlow:
+		return status.Errorf(
+			codes.Aborted,
+			fmt.Sprintf("%v, resume from the last resume token", err),
+		)
+	}
+	return status.Errorf(
+		codes.Internal,
+		fmt.Sprintf("cannot watch blogs: %v", err),
+	)
+}
+
+func eventTypeToPb(t eventType) bpb.WatchBlogsResponse_EventType {
+	switch t {
+	case eventCreated:
+		return bpb.WatchBlogsResponse_CREATED
+	case eventUpdated:
+		return bpb.WatchBlogsResponse_UPDATED
+	case eventDeleted:
+		return bpb.WatchBlogsResponse_DELETED
+	case eventPurged:
+		return bpb.WatchBlogsResponse_PURGED
+	}
+	return bpb.WatchBlogsResponse_EVENT_TYPE_UNSPECIFIED
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// blogClaims mirrors the claims the blog server reads from a token.
+type blogClaims struct {
+	Roles []string `json:"roles,omitempty"`
+	jwt.StandardClaims
+}
+
+func main() {
+	hmacSecretFile := flag.String("jwt-hmac-secret-file", "", "file holding the secret that signs HS256 tokens")
+	rsaPrivateKeyFile := flag.String("jwt-rsa-private-key-file", "", "PEM file holding the private key that signs RS256 tokens")
+	authorID := flag.String("author", "", "author id the token is issued to")
+	admin := flag.Bool("admin", false, "let the token change blogs of every author")
+	ttl := flag.Duration("ttl", 24*time.Hour, "how long the token stays valid, at most 720h")
+	flag.Parse()
+
+	if *authorID == "" {
+		log.Fatalf("-author must be set")
+	}
+	// the server rejects tokens that expire more than 30 days from now
+	if *ttl <= 0 || *ttl > 30*24*time.Hour {
+		log.Fatalf("-ttl must be positive and at most 720h")
+	}
+
+	now := time.Now()
+	claims := blogClaims{
+		StandardClaims: jwt.StandardClaims{
+			Subject:   *authorID,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(*ttl).Unix(),
+		},
+	}
+	if *admin {
+		claims.Roles = []string{"admin"}
+	}
+
+	var signed string
+	switch {
+	case *hmacSecretFile != "":
+		secret, err := ioutil.ReadFile(*hmacSecretFile)
+		if err != nil {
+			log.Fatalf("failed to read hmac secret: %v", err)
+		}
+		signed, err = jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(strings.TrimSpace(string(secret))))
+		if err != nil {
+			log.Fatalf("failed to sign token: %v", err)
+		}
+	case *rsaPrivateKeyFile != "":
+		pem, err := ioutil.ReadFile(*rsaPrivateKeyFile)
+		if err != nil {
+			log.Fatalf("failed to read rsa private key: %v", err)
+		}
+		key, err := jwt.ParseRSAPrivateKeyFromPEM(pem)
+		if err != nil {
+			log.Fatalf("failed to parse rsa private key: %v", err)
+		}
+		signed, err = jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(key)
+		if err != nil {
+			log.Fatalf("failed to sign token: %v", err)
+		}
+	default:
+		log.Fatalf("either -jwt-hmac-secret-file or -jwt-rsa-private-key-file must be set")
+	}
+
+	fmt.Println(signed)
+}
@@ -0,0 +1,172 @@
+// Package bignum does exact arithmetic on decimal numbers of any size.
+//
+// Numbers are written in decimal, such as "-12", "3.25" or "6.02e23", and
+// are held as big.Rat values, so sums, differences and products are exact.
+// Quotients and negative powers that have no finite decimal form are rounded
+// to a given number of digits after the decimal point when formatted.
+package bignum
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MaxInputLength is the longest number Parse accepts.
+	MaxInputLength = 10000
+	// MaxExponent is the largest exponent, as in 1e10000, Parse accepts.
+	MaxExponent = 10000
+	// MaxResultDigits is about the most digits Pow computes.
+	MaxResultDigits = 100000
+)
+
+var (
+	// ErrDivisionByZero is returned by Quo and Pow for a zero divisor.
+	ErrDivisionByZero = errors.New("division by zero")
+	// ErrTooLarge is returned by Pow when the result would have more than
+	// MaxResultDigits digits.
+	ErrTooLarge = fmt.Errorf("result has more than %d digits", MaxResultDigits)
+)
+
+var decimalRE = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE]([+-]?\d+))?$`)
+
+// Parse parses a decimal number.
+func Parse(s string) (*big.Rat, error) {
+	if len(s) > MaxInputLength {
+		return nil, fmt.Errorf("number is longer than %d characters", MaxInputLength)
+	}
+	m := decimalRE.FindStringSubmatch(s)
+	if m == nil {
+		return nil, fmt.Errorf("%q is not a decimal number", s)
+	}
+	if m[3] != "" {
+		// big.Rat would build a number of any size the exponent asks for
+		exp, err := strconv.Atoi(m[3])
+		if err != nil || exp > MaxExponent || exp < -MaxExponent {
+			return nil, fmt.Errorf("exponent of %q is outside [-%d, %d]", s, MaxExponent, MaxExponent)
+		}
+	}
+	x, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a decimal number", s)
+	}
+	return x, nil
+}
+
+// Quo returns x / y.
+func Quo(x, y *big.Rat) (*big.Rat, error) {
+	if y.Sign() == 0 {
+		return nil, ErrDivisionByZero
+	}
+	return new(big.Rat).Quo(x, y), nil
+}
+
+// Pow returns x raised to the power y, which must be an integer.
+func Pow(x, y *big.Rat) (*big.Rat, error) {
+	if !y.IsInt() {
+		return nil, errors.New("exponent must be an integer")
+	}
+	if x.Sign() == 0 {
+		switch y.Sign() {
+		case -1:
+			return nil, ErrDivisionByZero
+		case 0:
+			return big.NewRat(1, 1), nil
+		}
+		return new(big.Rat), nil
+	}
+
+	n := new(big.Int).Abs(y.Num())
+	num := new(big.Int).Abs(x.Num())
+	denom := x.Denom()
+	// 1 and -1 stay small whatever the exponent
+	if num.Cmp(denom) == 0 {
+		if x.Sign() < 0 && n.Bit(0) == 1 {
+			return big.NewRat(-1, 1), nil
+		}
+		return big.NewRat(1, 1), nil
+	}
+
+	// the larger of the numerator and denominator of the result has n times
+	// as many digits as that of x
+	larger := num
+	if denom.Cmp(num) > 0 {
+		larger = denom
+	}
+	if !n.IsInt64() || float64(n.Int64())*log10(larger) > MaxResultDigits {
+		return nil, ErrTooLarge
+	}
+
+	z := new(big.Rat).SetFrac(
+		new(big.Int).Exp(x.Num(), n, nil),
+		new(big.Int).Exp(denom, n, nil),
+	)
+	if y.Sign() < 0 {
+		z.Inv(z)
+	}
+	return z, nil
+}
+
+// log10 returns the base 10 logarithm of x, which must be positive.
+func log10(x *big.Int) float64 {
+	// x = mant * 2^exp with mant in [0.5, 1)
+	mant := new(big.Float)
+	exp := new(big.Float).SetInt(x).MantExp(mant)
+	m, _ := mant.Float64()
+	return (float64(exp) + math.Log2(m)) * math.Log10(2)
+}
+
+// Format writes x in decimal. A number whose decimal form ends is written in
+// full, others are rounded to precision digits after the decimal point, with
+// halves rounded away from zero. exact reports whether the result is x.
+func Format(x *big.Rat, precision int) (s string, exact bool) {
+	digits, ok := decimalPlaces(x)
+	if !ok || digits > precision {
+		digits = precision
+	} else {
+		exact = true
+	}
+
+	s = x.FloatString(digits)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	if s == "-0" {
+		s = "0"
+	}
+	return s, exact
+}
+
+// decimalPlaces returns how many digits after the decimal point x has, and
+// false if its decimal form does not end.
+func decimalPlaces(x *big.Rat) (int, bool) {
+	d := new(big.Int).Set(x.Denom())
+
+	// x has a finite decimal form when its denominator is 2^a * 5^b, and then
+	// max(a, b) digits after the point
+	twos := int(d.TrailingZeroBits())
+	d.Rsh(d, uint(twos))
+	fives := 0
+	five := big.NewInt(5)
+	q, r := new(big.Int), new(big.Int)
+	for {
+		q.QuoRem(d, five, r)
+		if r.Sign() != 0 {
+			break
+		}
+		d, q = q, d
+		fives++
+	}
+	if d.Cmp(big.NewInt(1)) != 0 {
+		return 0, false
+	}
+	if twos > fives {
+		return twos, true
+	}
+	return fives, true
+}
@@ -0,0 +1,235 @@
+package bignum
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// rat parses a fraction such as "-1/8" for the tests.
+func rat(t *testing.T, s string) *big.Rat {
+	t.Helper()
+	x, ok := new(big.Rat).SetString(s)
+	if !ok {
+		t.Fatalf("bad test number %q", s)
+	}
+	return x
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"-12", "-12/1"},
+		{"+7", "7/1"},
+		{"3.25", "13/4"},
+		{".5", "1/2"},
+		{"1.", "1/1"},
+		{"6.02e23", "602000000000000000000000/1"},
+		{"25E-3", "1/40"},
+		{"1e10000", "1" + strings.Repeat("0", 10000) + "/1"},
+		{"1e-10000", "1/1" + strings.Repeat("0", 10000)},
+		{strings.Repeat("9", MaxInputLength), strings.Repeat("9", MaxInputLength) + "/1"},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.s)
+		if err != nil {
+			t.Errorf("Parse(%.20q): %v", tt.s, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("Parse(%.20q) = %.20s, want %.20s", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"abc",
+		"1/2",
+		"0x10",
+		"1e",
+		"1.2.3",
+		"- 1",
+		"Inf",
+		"1e10001",
+		"1e-10001",
+		"1e99999999999999999999",
+		strings.Repeat("9", MaxInputLength+1),
+	} {
+		if got, err := Parse(s); err == nil {
+			t.Errorf("Parse(%.20q) = %.20s, want an error", s, got)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		x         string
+		precision int
+		want      string
+		exact     bool
+	}{
+		{"100", 2, "100", true},
+		{"-12", 0, "-12", true},
+		{"3/2", 5, "1.5", true},
+		{"1/8", 10, "0.125", true},
+		{"1/1024", 10, "0.0009765625", true},
+		{"1/3", 5, "0.33333", false},
+		{"2/3", 5, "0.66667", false},
+		{"-2/3", 5, "-0.66667", false},
+
+		// halves are rounded away from zero
+		{"1/8", 2, "0.13", false},
+		{"-1/8", 2, "-0.13", false},
+		{"5/2", 0, "3", false},
+		{"-5/2", 0, "-3", false},
+		{"3/8", 2, "0.38", false},
+
+		// rounding away every digit leaves no negative zero
+		{"-1/1000", 2, "0", false},
+		{"-1/3", 0, "0", false},
+		{"0", 5, "0", true},
+
+		// trailing zeros are dropped
+		{"1/20", 3, "0.05", true},
+		{"199/200", 2, "1", false},
+	}
+	for _, tt := range tests {
+		s, exact := Format(rat(t, tt.x), tt.precision)
+		if s != tt.want || exact != tt.exact {
+			t.Errorf("Format(%s, %d) = %q, %v, want %q, %v", tt.x, tt.precision, s, exact, tt.want, tt.exact)
+		}
+	}
+}
+
+func TestDecimalPlaces(t *testing.T) {
+	tests := []struct {
+		x      string
+		places int
+		ends   bool
+	}{
+		{"7", 0, true},
+		{"1/2", 1, true},
+		{"1/5", 1, true},
+		{"1/10", 1, true},
+		{"1/1024", 10, true},
+		{"1/3125", 5, true},
+		// 2^3 * 5 and 2 * 5^3
+		{"1/40", 3, true},
+		{"1/250", 3, true},
+		// 2^4 * 5^2 and 2^2 * 5^6
+		{"1/400", 4, true},
+		{"1/62500", 6, true},
+		{"1/3", 0, false},
+		{"1/6", 0, false},
+		{"1/30", 0, false},
+		{"1/7000", 0, false},
+	}
+	for _, tt := range tests {
+		places, ends := decimalPlaces(rat(t, tt.x))
+		if places != tt.places || ends != tt.ends {
+			t.Errorf("decimalPlaces(%s) = %d, %v, want %d, %v", tt.x, places, ends, tt.places, tt.ends)
+		}
+	}
+}
+
+func TestQuo(t *testing.T) {
+	got, err := Quo(rat(t, "1"), rat(t, "3"))
+	if err != nil || got.Cmp(rat(t, "1/3")) != 0 {
+		t.Errorf("Quo(1, 3) = %v, %v, want 1/3", got, err)
+	}
+	for _, x := range []string{"1", "-1", "0"} {
+		if got, err := Quo(rat(t, x), new(big.Rat)); err != ErrDivisionByZero {
+			t.Errorf("Quo(%s, 0) = %v, %v, want %v", x, got, err, ErrDivisionByZero)
+		}
+	}
+}
+
+func TestPow(t *testing.T) {
+	tests := []struct {
+		x, y string
+		want string
+	}{
+		{"2", "10", "1024"},
+		{"-2", "3", "-8"},
+		{"3/2", "2", "9/4"},
+		{"2", "0", "1"},
+		{"-5", "0", "1"},
+		{"0", "0", "1"},
+		{"0", "5", "0"},
+
+		// negative exponents
+		{"2", "-2", "1/4"},
+		{"-2", "-3", "-1/8"},
+		{"1/2", "-3", "8"},
+		{"2/3", "-2", "9/4"},
+
+		// 1 and -1 to any power
+		{"1", "1000000000000000000000000000000", "1"},
+		{"-1", "1000000000000000000000000000000", "1"},
+		{"-1", "1000000000000000000000000000001", "-1"},
+		{"-1", "-3", "-1"},
+		{"1", "-1000000000000000000000000000000", "1"},
+	}
+	for _, tt := range tests {
+		got, err := Pow(rat(t, tt.x), rat(t, tt.y))
+		if err != nil {
+			t.Errorf("Pow(%s, %s): %v", tt.x, tt.y, err)
+			continue
+		}
+		if got.Cmp(rat(t, tt.want)) != 0 {
+			t.Errorf("Pow(%s, %s) = %v, want %s", tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestPowErrors(t *testing.T) {
+	tests := []struct {
+		x, y string
+		err  error
+	}{
+		{"0", "-1", ErrDivisionByZero},
+		{"3", "332000", ErrTooLarge},
+		{"3", "209600", ErrTooLarge},
+		{"1/3", "-332000", ErrTooLarge},
+		{"2/3", "300000", ErrTooLarge},
+		{"10", "100001", ErrTooLarge},
+		{"2", "1000000000000000000000000000000", ErrTooLarge},
+		{"2", "-1000000000000000000000000000000", ErrTooLarge},
+	}
+	for _, tt := range tests {
+		got, err := Pow(rat(t, tt.x), rat(t, tt.y))
+		if err != tt.err {
+			t.Errorf("Pow(%s, %s) = %.20v, %v, want %v", tt.x, tt.y, got, err, tt.err)
+		}
+	}
+
+	if got, err := Pow(rat(t, "2"), rat(t, "1/2")); err == nil {
+		t.Errorf("Pow(2, 1/2) = %v, want an error", got)
+	}
+}
+
+func TestPowSizeLimit(t *testing.T) {
+	// results just under the limit are computed in full
+	tests := []struct {
+		x, y   string
+		digits int
+	}{
+		{"10", "99999", 100000},
+		{"3", "209000", 99719},
+		{"1/3", "-209000", 99719},
+	}
+	for _, tt := range tests {
+		got, err := Pow(rat(t, tt.x), rat(t, tt.y))
+		if err != nil {
+			t.Errorf("Pow(%s, %s): %v", tt.x, tt.y, err)
+			continue
+		}
+		if digits := len(got.Num().String()); digits != tt.digits {
+			t.Errorf("Pow(%s, %s) has %d digits, want %d", tt.x, tt.y, digits, tt.digits)
+		}
+	}
+}
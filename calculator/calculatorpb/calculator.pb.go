@@ -7,6 +7,8 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -24,6 +26,81 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type BigArithmeticRequest_Operation int32
+
+const (
+	BigArithmeticRequest_OPERATION_UNSPECIFIED BigArithmeticRequest_Operation = 0
+	BigArithmeticRequest_ADD                   BigArithmeticRequest_Operation = 1
+	BigArithmeticRequest_SUBTRACT              BigArithmeticRequest_Operation = 2
+	BigArithmeticRequest_MULTIPLY              BigArithmeticRequest_Operation = 3
+	BigArithmeticRequest_DIVIDE                BigArithmeticRequest_Operation = 4
+	// raises a to the power b, which must be an integer
+	BigArithmeticRequest_POWER BigArithmeticRequest_Operation = 5
+)
+
+var BigArithmeticRequest_Operation_name = map[int32]string{
+	0: "OPERATION_UNSPECIFIED",
+	1: "ADD",
+	2: "SUBTRACT",
+	3: "MULTIPLY",
+	4: "DIVIDE",
+	5: "POWER",
+}
+
+var BigArithmeticRequest_Operation_value = map[string]int32{
+	"OPERATION_UNSPECIFIED": 0,
+	"ADD":                   1,
+	"SUBTRACT":              2,
+	"MULTIPLY":              3,
+	"DIVIDE":                4,
+	"POWER":                 5,
+}
+
+func (x BigArithmeticRequest_Operation) String() string {
+	return proto.EnumName(BigArithmeticRequest_Operation_name, int32(x))
+}
+
+func (BigArithmeticRequest_Operation) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_87e717c78a24322a, []int{13, 0}
+}
+
+type WindowedAggregateRequest_Aggregate int32
+
+const (
+	WindowedAggregateRequest_AGGREGATE_UNSPECIFIED WindowedAggregateRequest_Aggregate = 0
+	WindowedAggregateRequest_MIN                   WindowedAggregateRequest_Aggregate = 1
+	WindowedAggregateRequest_MAX                   WindowedAggregateRequest_Aggregate = 2
+	WindowedAggregateRequest_SUM                   WindowedAggregateRequest_Aggregate = 3
+	WindowedAggregateRequest_AVG                   WindowedAggregateRequest_Aggregate = 4
+	WindowedAggregateRequest_COUNT                 WindowedAggregateRequest_Aggregate = 5
+)
+
+var WindowedAggregateRequest_Aggregate_name = map[int32]string{
+	0: "AGGREGATE_UNSPECIFIED",
+	1: "MIN",
+	2: "MAX",
+	3: "SUM",
+	4: "AVG",
+	5: "COUNT",
+}
+
+var WindowedAggregateRequest_Aggregate_value = map[string]int32{
+	"AGGREGATE_UNSPECIFIED": 0,
+	"MIN":                   1,
+	"MAX":                   2,
+	"SUM":                   3,
+	"AVG":                   4,
+	"COUNT":                 5,
+}
+
+func (x WindowedAggregateRequest_Aggregate) String() string {
+	return proto.EnumName(WindowedAggregateRequest_Aggregate_name, int32(x))
+}
+
+func (WindowedAggregateRequest_Aggregate) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_87e717c78a24322a, []int{22, 0}
+}
+
 type SumRequest struct {
 	FirstNumber          int32    `protobuf:"varint,1,opt,name=firstNumber,proto3" json:"firstNumber,omitempty"`
 	SecondNumber         int32    `protobuf:"varint,2,opt,name=secondNumber,proto3" json:"secondNumber,omitempty"`
@@ -422,268 +499,1316 @@ func (m *SquareRootResponse) GetNumberRoot() float64 {
 	return 0
 }
 
-func init() {
-	proto.RegisterType((*SumRequest)(nil), "calculator.SumRequest")
-	proto.RegisterType((*SumResponse)(nil), "calculator.SumResponse")
-	proto.RegisterType((*PrimeNumberDecompositionRequest)(nil), "calculator.PrimeNumberDecompositionRequest")
-	proto.RegisterType((*PrimeNumberDecompositionResponse)(nil), "calculator.PrimeNumberDecompositionResponse")
-	proto.RegisterType((*ComputAverageRequest)(nil), "calculator.ComputAverageRequest")
-	proto.RegisterType((*ComputeAverageResponse)(nil), "calculator.ComputeAverageResponse")
-	proto.RegisterType((*FindMaximumRequest)(nil), "calculator.FindMaximumRequest")
-	proto.RegisterType((*FindMaximumResponse)(nil), "calculator.FindMaximumResponse")
-	proto.RegisterType((*SquareRootRequest)(nil), "calculator.SquareRootRequest")
-	proto.RegisterType((*SquareRootResponse)(nil), "calculator.SquareRootResponse")
+type EvaluateRequest struct {
+	// an infix expression such as "2 * (3 + 4) ^ 2 - sqrt(16)", made of
+	// numbers, + - * / % ^, parentheses, unary minus and the functions sqrt,
+	// abs, min, max and log
+	Expression           string   `protobuf:"bytes,1,opt,name=expression,proto3" json:"expression,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() {
-	proto.RegisterFile("calculatorpb/calculator.proto", fileDescriptor_87e717c78a24322a)
+func (m *EvaluateRequest) Reset()         { *m = EvaluateRequest{} }
+func (m *EvaluateRequest) String() string { return proto.CompactTextString(m) }
+func (*EvaluateRequest) ProtoMessage()    {}
+func (*EvaluateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_87e717c78a24322a, []int{10}
 }
 
-var fileDescriptor_87e717c78a24322a = []byte{
-	// 421 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x41, 0x8b, 0xda, 0x40,
-	0x18, 0xdd, 0x54, 0x76, 0x4b, 0xbf, 0xc8, 0xc2, 0x7e, 0x2d, 0x56, 0x42, 0x77, 0x4d, 0xe7, 0xb4,
-	0xe0, 0xa2, 0x62, 0x29, 0xb4, 0xc7, 0xd6, 0xd6, 0x9b, 0xa5, 0x24, 0x3d, 0x79, 0x91, 0x18, 0xc7,
-	0x12, 0x70, 0x32, 0x71, 0x32, 0x23, 0xed, 0x4f, 0xec, 0xbf, 0x2a, 0x26, 0x13, 0x67, 0xa2, 0x06,
-	0xf7, 0xf8, 0xbd, 0x79, 0xdf, 0x7b, 0xc3, 0xbc, 0xc7, 0xc0, 0x7d, 0x1c, 0x6d, 0x62, 0xb5, 0x89,
-	0x24, 0x17, 0xd9, 0x72, 0x68, 0x86, 0x41, 0x26, 0xb8, 0xe4, 0x08, 0x06, 0x21, 0x01, 0x40, 0xa8,
-	0x58, 0x40, 0xb7, 0x8a, 0xe6, 0x12, 0x7d, 0x70, 0xd7, 0x89, 0xc8, 0xe5, 0x0f, 0xc5, 0x96, 0x54,
-	0x74, 0x1d, 0xdf, 0x79, 0xbc, 0x0e, 0x6c, 0x08, 0x09, 0xb4, 0x73, 0x1a, 0xf3, 0x74, 0xa5, 0x29,
-	0x2f, 0x0a, 0x4a, 0x0d, 0x23, 0x7d, 0x70, 0x0b, 0xcd, 0x3c, 0xe3, 0x69, 0x4e, 0xf1, 0x1d, 0xbc,
-	0xca, 0x8b, 0x51, 0x6d, 0xa4, 0x96, 0x34, 0x00, 0xf9, 0x0c, 0xbd, 0x9f, 0x22, 0x61, 0xb4, 0xdc,
-	0xfd, 0x46, 0x63, 0xce, 0x32, 0x9e, 0x27, 0x32, 0xe1, 0x69, 0x75, 0xab, 0x0e, 0xdc, 0xa4, 0xe6,
-	0x42, 0xad, 0x40, 0x4f, 0xe4, 0x3b, 0xf8, 0xcd, 0xab, 0xda, 0xfc, 0x3d, 0xb4, 0xb3, 0x3d, 0x67,
-	0xb1, 0x8e, 0x62, 0xc9, 0x2b, 0x05, 0xb7, 0xc0, 0xa6, 0x05, 0x44, 0x06, 0xf0, 0x66, 0xc2, 0x59,
-	0xa6, 0xe4, 0x97, 0x1d, 0x15, 0xd1, 0x6f, 0x7a, 0xde, 0xf6, 0xfa, 0x60, 0x3b, 0x86, 0x4e, 0xc9,
-	0xa7, 0x87, 0x05, 0x6d, 0xd6, 0x85, 0x97, 0x51, 0x09, 0x15, 0x2b, 0x4e, 0x50, 0x8d, 0xe4, 0x09,
-	0x70, 0x9a, 0xa4, 0xab, 0x59, 0xf4, 0x27, 0x61, 0xe6, 0xb9, 0x9b, 0x1c, 0x86, 0xf0, 0xba, 0xc6,
-	0x36, 0xf2, 0xac, 0x84, 0x34, 0xbf, 0x1a, 0x49, 0x1f, 0xee, 0xc2, 0xad, 0x8a, 0x04, 0x0d, 0x38,
-	0x97, 0x97, 0xd4, 0x3f, 0x02, 0xda, 0x64, 0x2d, 0xde, 0x03, 0xb7, 0x3c, 0x5f, 0x08, 0xce, 0xa5,
-	0xbe, 0x3f, 0x94, 0xd0, 0x9e, 0x38, 0xfe, 0xd7, 0x82, 0xbb, 0xc9, 0xa1, 0x38, 0x21, 0x15, 0xbb,
-	0x24, 0xa6, 0xf8, 0x09, 0x5a, 0xa1, 0x62, 0xd8, 0x19, 0x58, 0x2d, 0x33, 0x85, 0xf2, 0xde, 0x9e,
-	0xe0, 0xa5, 0x1d, 0xb9, 0xc2, 0xbf, 0xd0, 0x6d, 0x4a, 0x0f, 0xfb, 0xf6, 0xda, 0x85, 0x7a, 0x78,
-	0x4f, 0xcf, 0x23, 0x57, 0xc6, 0x23, 0x07, 0xe7, 0x70, 0x5b, 0x4f, 0x10, 0x7d, 0x5b, 0xe3, 0x5c,
-	0x1b, 0x3c, 0x72, 0xca, 0x38, 0xce, 0x9f, 0x5c, 0x3d, 0x3a, 0xf8, 0x0b, 0x5c, 0x2b, 0x3b, 0x7c,
-	0xb0, 0xd7, 0x4e, 0x2b, 0xe0, 0xf5, 0x1a, 0xcf, 0x8d, 0xe6, 0xc8, 0xc1, 0x19, 0x80, 0xc9, 0x0c,
-	0xef, 0x6b, 0xaf, 0x7a, 0x1c, 0xbc, 0xf7, 0xd0, 0x74, 0x5c, 0x49, 0x7e, 0xbd, 0x9d, 0xb7, 0xed,
-	0x2f, 0x62, 0x79, 0x53, 0x7c, 0x0c, 0x1f, 0xfe, 0x07, 0x00, 0x00, 0xff, 0xff, 0x9a, 0x53, 0x9d,
-	0x3b, 0x39, 0x04, 0x00, 0x00,
+func (m *EvaluateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvaluateRequest.Unmarshal(m, b)
+}
+func (m *EvaluateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvaluateRequest.Marshal(b, m, deterministic)
+}
+func (m *EvaluateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvaluateRequest.Merge(m, src)
+}
+func (m *EvaluateRequest) XXX_Size() int {
+	return xxx_messageInfo_EvaluateRequest.Size(m)
+}
+func (m *EvaluateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvaluateRequest.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConnInterface
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
+var xxx_messageInfo_EvaluateRequest proto.InternalMessageInfo
 
-// CalculatorServiceClient is the client API for CalculatorService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type CalculatorServiceClient interface {
-	Sum(ctx context.Context, in *SumRequest, opts ...grpc.CallOption) (*SumResponse, error)
-	PrimeNumberDecomposition(ctx context.Context, in *PrimeNumberDecompositionRequest, opts ...grpc.CallOption) (CalculatorService_PrimeNumberDecompositionClient, error)
-	ComputeAverage(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_ComputeAverageClient, error)
-	FindMaximum(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_FindMaximumClient, error)
-	// error handling
-	// this RPC will throw an expection if the sent number is negative
-	// the error being sent is of type INVALID_ARGUMENT
-	SquareRoot(ctx context.Context, in *SquareRootRequest, opts ...grpc.CallOption) (*SquareRootResponse, error)
+func (m *EvaluateRequest) GetExpression() string {
+	if m != nil {
+		return m.Expression
+	}
+	return ""
 }
 
-type calculatorServiceClient struct {
-	cc grpc.ClientConnInterface
+type EvaluateResponse struct {
+	Result               float64  `protobuf:"fixed64,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func NewCalculatorServiceClient(cc grpc.ClientConnInterface) CalculatorServiceClient {
-	return &calculatorServiceClient{cc}
+func (m *EvaluateResponse) Reset()         { *m = EvaluateResponse{} }
+func (m *EvaluateResponse) String() string { return proto.CompactTextString(m) }
+func (*EvaluateResponse) ProtoMessage()    {}
+func (*EvaluateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_87e717c78a24322a, []int{11}
 }
 
-func (c *calculatorServiceClient) Sum(ctx context.Context, in *SumRequest, opts ...grpc.CallOption) (*SumResponse, error) {
-	out := new(SumResponse)
-	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/Sum", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *EvaluateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvaluateResponse.Unmarshal(m, b)
+}
+func (m *EvaluateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvaluateResponse.Marshal(b, m, deterministic)
+}
+func (m *EvaluateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvaluateResponse.Merge(m, src)
+}
+func (m *EvaluateResponse) XXX_Size() int {
+	return xxx_messageInfo_EvaluateResponse.Size(m)
+}
+func (m *EvaluateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvaluateResponse.DiscardUnknown(m)
 }
 
-func (c *calculatorServiceClient) PrimeNumberDecomposition(ctx context.Context, in *PrimeNumberDecompositionRequest, opts ...grpc.CallOption) (CalculatorService_PrimeNumberDecompositionClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[0], "/calculator.CalculatorService/PrimeNumberDecomposition", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &calculatorServicePrimeNumberDecompositionClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+var xxx_messageInfo_EvaluateResponse proto.InternalMessageInfo
+
+func (m *EvaluateResponse) GetResult() float64 {
+	if m != nil {
+		return m.Result
 	}
-	return x, nil
+	return 0
 }
 
-type CalculatorService_PrimeNumberDecompositionClient interface {
-	Recv() (*PrimeNumberDecompositionResponse, error)
-	grpc.ClientStream
+// a number of any size written in decimal, such as "-12", "3.25" or
+// "6.02e23"
+type Number struct {
+	Value                string   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type calculatorServicePrimeNumberDecompositionClient struct {
-	grpc.ClientStream
+func (m *Number) Reset()         { *m = Number{} }
+func (m *Number) String() string { return proto.CompactTextString(m) }
+func (*Number) ProtoMessage()    {}
+func (*Number) Descriptor() ([]byte, []int) {
+	return fileDescriptor_87e717c78a24322a, []int{12}
 }
 
-func (x *calculatorServicePrimeNumberDecompositionClient) Recv() (*PrimeNumberDecompositionResponse, error) {
-	m := new(PrimeNumberDecompositionResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+func (m *Number) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Number.Unmarshal(m, b)
+}
+func (m *Number) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Number.Marshal(b, m, deterministic)
+}
+func (m *Number) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Number.Merge(m, src)
+}
+func (m *Number) XXX_Size() int {
+	return xxx_messageInfo_Number.Size(m)
+}
+func (m *Number) XXX_DiscardUnknown() {
+	xxx_messageInfo_Number.DiscardUnknown(m)
 }
 
-func (c *calculatorServiceClient) ComputeAverage(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_ComputeAverageClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[1], "/calculator.CalculatorService/ComputeAverage", opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_Number proto.InternalMessageInfo
+
+func (m *Number) GetValue() string {
+	if m != nil {
+		return m.Value
 	}
-	x := &calculatorServiceComputeAverageClient{stream}
-	return x, nil
+	return ""
 }
 
-type CalculatorService_ComputeAverageClient interface {
-	Send(*ComputAverageRequest) error
-	CloseAndRecv() (*ComputeAverageResponse, error)
-	grpc.ClientStream
+type BigArithmeticRequest struct {
+	Operation BigArithmeticRequest_Operation `protobuf:"varint,1,opt,name=operation,proto3,enum=calculator.BigArithmeticRequest_Operation" json:"operation,omitempty"`
+	A         *Number                        `protobuf:"bytes,2,opt,name=a,proto3" json:"a,omitempty"`
+	B         *Number                        `protobuf:"bytes,3,opt,name=b,proto3" json:"b,omitempty"`
+	// digits kept after the decimal point of a result that has no finite
+	// decimal form, such as 1 / 3, rounding halves away from zero
+	// 0 keeps 50 digits
+	Precision            int32    `protobuf:"varint,4,opt,name=precision,proto3" json:"precision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type calculatorServiceComputeAverageClient struct {
-	grpc.ClientStream
+func (m *BigArithmeticRequest) Reset()         { *m = BigArithmeticRequest{} }
+func (m *BigArithmeticRequest) String() string { return proto.CompactTextString(m) }
+func (*BigArithmeticRequest) ProtoMessage()    {}
+func (*BigArithmeticRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_87e717c78a24322a, []int{13}
 }
 
-func (x *calculatorServiceComputeAverageClient) Send(m *ComputAverageRequest) error {
-	return x.ClientStream.SendMsg(m)
+func (m *BigArithmeticRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigArithmeticRequest.Unmarshal(m, b)
+}
+func (m *BigArithmeticRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigArithmeticRequest.Marshal(b, m, deterministic)
+}
+func (m *BigArithmeticRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigArithmeticRequest.Merge(m, src)
+}
+func (m *BigArithmeticRequest) XXX_Size() int {
+	return xxx_messageInfo_BigArithmeticRequest.Size(m)
+}
+func (m *BigArithmeticRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigArithmeticRequest.DiscardUnknown(m)
 }
 
-func (x *calculatorServiceComputeAverageClient) CloseAndRecv() (*ComputeAverageResponse, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+var xxx_messageInfo_BigArithmeticRequest proto.InternalMessageInfo
+
+func (m *BigArithmeticRequest) GetOperation() BigArithmeticRequest_Operation {
+	if m != nil {
+		return m.Operation
 	}
-	m := new(ComputeAverageResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+	return BigArithmeticRequest_OPERATION_UNSPECIFIED
+}
+
+func (m *BigArithmeticRequest) GetA() *Number {
+	if m != nil {
+		return m.A
 	}
-	return m, nil
+	return nil
 }
 
-func (c *calculatorServiceClient) FindMaximum(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_FindMaximumClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[2], "/calculator.CalculatorService/FindMaximum", opts...)
-	if err != nil {
-		return nil, err
+func (m *BigArithmeticRequest) GetB() *Number {
+	if m != nil {
+		return m.B
 	}
-	x := &calculatorServiceFindMaximumClient{stream}
-	return x, nil
+	return nil
 }
 
-type CalculatorService_FindMaximumClient interface {
-	Send(*FindMaximumRequest) error
-	Recv() (*FindMaximumResponse, error)
-	grpc.ClientStream
+func (m *BigArithmeticRequest) GetPrecision() int32 {
+	if m != nil {
+		return m.Precision
+	}
+	return 0
 }
 
-type calculatorServiceFindMaximumClient struct {
-	grpc.ClientStream
+type BigArithmeticResponse struct {
+	Result *Number `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	// false when the result was rounded to the requested precision
+	Exact                bool     `protobuf:"varint,2,opt,name=exact,proto3" json:"exact,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (x *calculatorServiceFindMaximumClient) Send(m *FindMaximumRequest) error {
-	return x.ClientStream.SendMsg(m)
+func (m *BigArithmeticResponse) Reset()         { *m = BigArithmeticResponse{} }
+func (m *BigArithmeticResponse) String() string { return proto.CompactTextString(m) }
+func (*BigArithmeticResponse) ProtoMessage()    {}
+func (*BigArithmeticResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_87e717c78a24322a, []int{14}
 }
 
-func (x *calculatorServiceFindMaximumClient) Recv() (*FindMaximumResponse, error) {
-	m := new(FindMaximumResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+func (m *BigArithmeticResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigArithmeticResponse.Unmarshal(m, b)
+}
+func (m *BigArithmeticResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigArithmeticResponse.Marshal(b, m, deterministic)
+}
+func (m *BigArithmeticResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigArithmeticResponse.Merge(m, src)
+}
+func (m *BigArithmeticResponse) XXX_Size() int {
+	return xxx_messageInfo_BigArithmeticResponse.Size(m)
+}
+func (m *BigArithmeticResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigArithmeticResponse.DiscardUnknown(m)
 }
 
-func (c *calculatorServiceClient) SquareRoot(ctx context.Context, in *SquareRootRequest, opts ...grpc.CallOption) (*SquareRootResponse, error) {
-	out := new(SquareRootResponse)
-	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/SquareRoot", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_BigArithmeticResponse proto.InternalMessageInfo
+
+func (m *BigArithmeticResponse) GetResult() *Number {
+	if m != nil {
+		return m.Result
 	}
-	return out, nil
+	return nil
 }
 
-// CalculatorServiceServer is the server API for CalculatorService service.
-type CalculatorServiceServer interface {
-	Sum(context.Context, *SumRequest) (*SumResponse, error)
-	PrimeNumberDecomposition(*PrimeNumberDecompositionRequest, CalculatorService_PrimeNumberDecompositionServer) error
-	ComputeAverage(CalculatorService_ComputeAverageServer) error
-	FindMaximum(CalculatorService_FindMaximumServer) error
-	// error handling
-	// this RPC will throw an expection if the sent number is negative
-	// the error being sent is of type INVALID_ARGUMENT
-	SquareRoot(context.Context, *SquareRootRequest) (*SquareRootResponse, error)
+func (m *BigArithmeticResponse) GetExact() bool {
+	if m != nil {
+		return m.Exact
+	}
+	return false
+}
+
+// statistics of a stream of values, all 0 when there are no values
+type Statistics struct {
+	Count int64   `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Sum   float64 `protobuf:"fixed64,2,opt,name=sum,proto3" json:"sum,omitempty"`
+	Min   float64 `protobuf:"fixed64,3,opt,name=min,proto3" json:"min,omitempty"`
+	Max   float64 `protobuf:"fixed64,4,opt,name=max,proto3" json:"max,omitempty"`
+	Mean  float64 `protobuf:"fixed64,5,opt,name=mean,proto3" json:"mean,omitempty"`
+	// population variance and standard deviation
+	Variance float64 `protobuf:"fixed64,6,opt,name=variance,proto3" json:"variance,omitempty"`
+	Stddev   float64 `protobuf:"fixed64,7,opt,name=stddev,proto3" json:"stddev,omitempty"`
+	// approximate quantiles, estimated with a t-digest
+	P50                  float64  `protobuf:"fixed64,8,opt,name=p50,proto3" json:"p50,omitempty"`
+	P90                  float64  `protobuf:"fixed64,9,opt,name=p90,proto3" json:"p90,omitempty"`
+	P99                  float64  `protobuf:"fixed64,10,opt,name=p99,proto3" json:"p99,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-// UnimplementedCalculatorServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedCalculatorServiceServer struct {
+func (m *Statistics) Reset()         { *m = Statistics{} }
+func (m *Statistics) String() string { return proto.CompactTextString(m) }
+func (*Statistics) ProtoMessage()    {}
+func (*Statistics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_87e717c78a24322a, []int{15}
 }
 
-func (*UnimplementedCalculatorServiceServer) Sum(ctx context.Context, req *SumRequest) (*SumResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Sum not implemented")
+func (m *Statistics) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Statistics.Unmarshal(m, b)
 }
-func (*UnimplementedCalculatorServiceServer) PrimeNumberDecomposition(req *PrimeNumberDecompositionRequest, srv CalculatorService_PrimeNumberDecompositionServer) error {
-	return status.Errorf(codes.Unimplemented, "method PrimeNumberDecomposition not implemented")
+func (m *Statistics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Statistics.Marshal(b, m, deterministic)
 }
-func (*UnimplementedCalculatorServiceServer) ComputeAverage(srv CalculatorService_ComputeAverageServer) error {
-	return status.Errorf(codes.Unimplemented, "method ComputeAverage not implemented")
+func (m *Statistics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Statistics.Merge(m, src)
 }
-func (*UnimplementedCalculatorServiceServer) FindMaximum(srv CalculatorService_FindMaximumServer) error {
-	return status.Errorf(codes.Unimplemented, "method FindMaximum not implemented")
+func (m *Statistics) XXX_Size() int {
+	return xxx_messageInfo_Statistics.Size(m)
 }
-func (*UnimplementedCalculatorServiceServer) SquareRoot(ctx context.Context, req *SquareRootRequest) (*SquareRootResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SquareRoot not implemented")
+func (m *Statistics) XXX_DiscardUnknown() {
+	xxx_messageInfo_Statistics.DiscardUnknown(m)
 }
 
-func RegisterCalculatorServiceServer(s *grpc.Server, srv CalculatorServiceServer) {
-	s.RegisterService(&_CalculatorService_serviceDesc, srv)
-}
+var xxx_messageInfo_Statistics proto.InternalMessageInfo
 
-func _CalculatorService_Sum_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SumRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(CalculatorServiceServer).Sum(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/calculator.CalculatorService/Sum",
+func (m *Statistics) GetCount() int64 {
+	if m != nil {
+		return m.Count
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CalculatorServiceServer).Sum(ctx, req.(*SumRequest))
+	return 0
+}
+
+func (m *Statistics) GetSum() float64 {
+	if m != nil {
+		return m.Sum
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _CalculatorService_PrimeNumberDecomposition_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(PrimeNumberDecompositionRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (m *Statistics) GetMin() float64 {
+	if m != nil {
+		return m.Min
 	}
-	return srv.(CalculatorServiceServer).PrimeNumberDecomposition(m, &calculatorServicePrimeNumberDecompositionServer{stream})
+	return 0
 }
 
-type CalculatorService_PrimeNumberDecompositionServer interface {
-	Send(*PrimeNumberDecompositionResponse) error
-	grpc.ServerStream
+func (m *Statistics) GetMax() float64 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
 }
 
-type calculatorServicePrimeNumberDecompositionServer struct {
-	grpc.ServerStream
+func (m *Statistics) GetMean() float64 {
+	if m != nil {
+		return m.Mean
+	}
+	return 0
+}
+
+func (m *Statistics) GetVariance() float64 {
+	if m != nil {
+		return m.Variance
+	}
+	return 0
+}
+
+func (m *Statistics) GetStddev() float64 {
+	if m != nil {
+		return m.Stddev
+	}
+	return 0
+}
+
+func (m *Statistics) GetP50() float64 {
+	if m != nil {
+		return m.P50
+	}
+	return 0
+}
+
+func (m *Statistics) GetP90() float64 {
+	if m != nil {
+		return m.P90
+	}
+	return 0
+}
+
+func (m *Statistics) GetP99() float64 {
+	if m != nil {
+		return m.P99
+	}
+	return 0
+}
+
+type ComputeStatisticsRequest struct {
+	Value                float64  `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ComputeStatisticsRequest) Reset()         { *m = ComputeStatisticsRequest{} }
+func (m *ComputeStatisticsRequest) String() string { return proto.CompactTextString(m) }
+func (*ComputeStatisticsRequest) ProtoMessage()    {}
+func (*ComputeStatisticsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_87e717c78a24322a, []int{16}
+}
+
+func (m *ComputeStatisticsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ComputeStatisticsRequest.Unmarshal(m, b)
+}
+func (m *ComputeStatisticsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ComputeStatisticsRequest.Marshal(b, m, deterministic)
+}
+func (m *ComputeStatisticsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ComputeStatisticsRequest.Merge(m, src)
+}
+func (m *ComputeStatisticsRequest) XXX_Size() int {
+	return xxx_messageInfo_ComputeStatisticsRequest.Size(m)
+}
+func (m *ComputeStatisticsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ComputeStatisticsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ComputeStatisticsRequest proto.InternalMessageInfo
+
+func (m *ComputeStatisticsRequest) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+type ComputeStatisticsResponse struct {
+	Statistics           *Statistics `protobuf:"bytes,1,opt,name=statistics,proto3" json:"statistics,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ComputeStatisticsResponse) Reset()         { *m = ComputeStatisticsResponse{} }
+func (m *ComputeStatisticsResponse) String() string { return proto.CompactTextString(m) }
+func (*ComputeStatisticsResponse) ProtoMessage()    {}
+func (*ComputeStatisticsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_87e717c78a24322a, []int{17}
+}
+
+func (m *ComputeStatisticsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ComputeStatisticsResponse.Unmarshal(m, b)
+}
+func (m *ComputeStatisticsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ComputeStatisticsResponse.Marshal(b, m, deterministic)
+}
+func (m *ComputeStatisticsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ComputeStatisticsResponse.Merge(m, src)
+}
+func (m *ComputeStatisticsResponse) XXX_Size() int {
+	return xxx_messageInfo_ComputeStatisticsResponse.Size(m)
+}
+func (m *ComputeStatisticsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ComputeStatisticsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ComputeStatisticsResponse proto.InternalMessageInfo
+
+func (m *ComputeStatisticsResponse) GetStatistics() *Statistics {
+	if m != nil {
+		return m.Statistics
+	}
+	return nil
+}
+
+type StreamStatisticsRequest struct {
+	Value float64 `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	// statistics are sent after every report_every values, the first request
+	// sets it and 0 sends them after every value
+	ReportEvery          int32    `protobuf:"varint,2,opt,name=report_every,json=reportEvery,proto3" json:"report_every,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamStatisticsRequest) Reset()         { *m = StreamStatisticsRequest{} }
+func (m *StreamStatisticsRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamStatisticsRequest) ProtoMessage()    {}
+func (*StreamStatisticsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_87e717c78a24322a, []int{18}
+}
+
+func (m *StreamStatisticsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamStatisticsRequest.Unmarshal(m, b)
+}
+func (m *StreamStatisticsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamStatisticsRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamStatisticsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamStatisticsRequest.Merge(m, src)
+}
+func (m *StreamStatisticsRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamStatisticsRequest.Size(m)
+}
+func (m *StreamStatisticsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamStatisticsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamStatisticsRequest proto.InternalMessageInfo
+
+func (m *StreamStatisticsRequest) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *StreamStatisticsRequest) GetReportEvery() int32 {
+	if m != nil {
+		return m.ReportEvery
+	}
+	return 0
+}
+
+type StreamStatisticsResponse struct {
+	Statistics           *Statistics `protobuf:"bytes,1,opt,name=statistics,proto3" json:"statistics,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *StreamStatisticsResponse) Reset()         { *m = StreamStatisticsResponse{} }
+func (m *StreamStatisticsResponse) String() string { return proto.CompactTextString(m) }
+func (*StreamStatisticsResponse) ProtoMessage()    {}
+func (*StreamStatisticsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_87e717c78a24322a, []int{19}
+}
+
+func (m *StreamStatisticsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamStatisticsResponse.Unmarshal(m, b)
+}
+func (m *StreamStatisticsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamStatisticsResponse.Marshal(b, m, deterministic)
+}
+func (m *StreamStatisticsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamStatisticsResponse.Merge(m, src)
+}
+func (m *StreamStatisticsResponse) XXX_Size() int {
+	return xxx_messageInfo_StreamStatisticsResponse.Size(m)
+}
+func (m *StreamStatisticsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamStatisticsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamStatisticsResponse proto.InternalMessageInfo
+
+func (m *StreamStatisticsResponse) GetStatistics() *Statistics {
+	if m != nil {
+		return m.Statistics
+	}
+	return nil
+}
+
+// a window over the last size values that slides every slide values
+// a slide of 0 makes the windows tumbling, one after the other
+type CountWindow struct {
+	Size                 int32    `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	Slide                int32    `protobuf:"varint,2,opt,name=slide,proto3" json:"slide,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CountWindow) Reset()         { *m = CountWindow{} }
+func (m *CountWindow) String() string { return proto.CompactTextString(m) }
+func (*CountWindow) ProtoMessage()    {}
+func (*CountWindow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_87e717c78a24322a, []int{20}
+}
+
+func (m *CountWindow) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CountWindow.Unmarshal(m, b)
+}
+func (m *CountWindow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CountWindow.Marshal(b, m, deterministic)
+}
+func (m *CountWindow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CountWindow.Merge(m, src)
+}
+func (m *CountWindow) XXX_Size() int {
+	return xxx_messageInfo_CountWindow.Size(m)
+}
+func (m *CountWindow) XXX_DiscardUnknown() {
+	xxx_messageInfo_CountWindow.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CountWindow proto.InternalMessageInfo
+
+func (m *CountWindow) GetSize() int32 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *CountWindow) GetSlide() int32 {
+	if m != nil {
+		return m.Slide
+	}
+	return 0
+}
+
+// a window over a span of time that slides every slide
+// windows end at multiples of the slide since the unix epoch, and hold the
+// values with a time from the end minus size up to but not including the end
+// a slide of 0 makes the windows tumbling, one after the other
+// size and slide are at most 100 years, and a window holds at most 100000
+// values at once, more is a RESOURCE_EXHAUSTED error
+type TimeWindow struct {
+	Size                 *duration.Duration `protobuf:"bytes,1,opt,name=size,proto3" json:"size,omitempty"`
+	Slide                *duration.Duration `protobuf:"bytes,2,opt,name=slide,proto3" json:"slide,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *TimeWindow) Reset()         { *m = TimeWindow{} }
+func (m *TimeWindow) String() string { return proto.CompactTextString(m) }
+func (*TimeWindow) ProtoMessage()    {}
+func (*TimeWindow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_87e717c78a24322a, []int{21}
+}
+
+func (m *TimeWindow) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TimeWindow.Unmarshal(m, b)
+}
+func (m *TimeWindow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TimeWindow.Marshal(b, m, deterministic)
+}
+func (m *TimeWindow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimeWindow.Merge(m, src)
+}
+func (m *TimeWindow) XXX_Size() int {
+	return xxx_messageInfo_TimeWindow.Size(m)
+}
+func (m *TimeWindow) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimeWindow.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TimeWindow proto.InternalMessageInfo
+
+func (m *TimeWindow) GetSize() *duration.Duration {
+	if m != nil {
+		return m.Size
+	}
+	return nil
+}
+
+func (m *TimeWindow) GetSlide() *duration.Duration {
+	if m != nil {
+		return m.Slide
+	}
+	return nil
+}
+
+type WindowedAggregateRequest struct {
+	// the aggregate and the window are read from the first request
+	Aggregate WindowedAggregateRequest_Aggregate `protobuf:"varint,1,opt,name=aggregate,proto3,enum=calculator.WindowedAggregateRequest_Aggregate" json:"aggregate,omitempty"`
+	// Types that are valid to be assigned to Window:
+	//	*WindowedAggregateRequest_CountWindow
+	//	*WindowedAggregateRequest_TimeWindow
+	Window isWindowedAggregateRequest_Window `protobuf_oneof:"window"`
+	Value  float64                           `protobuf:"fixed64,4,opt,name=value,proto3" json:"value,omitempty"`
+	// the time of the value, required for time windows
+	// values must be sent in the order of their times, between about the
+	// years 1678 and 2262
+	Time                 *timestamp.Timestamp `protobuf:"bytes,5,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *WindowedAggregateRequest) Reset()         { *m = WindowedAggregateRequest{} }
+func (m *WindowedAggregateRequest) String() string { return proto.CompactTextString(m) }
+func (*WindowedAggregateRequest) ProtoMessage()    {}
+func (*WindowedAggregateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_87e717c78a24322a, []int{22}
+}
+
+func (m *WindowedAggregateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WindowedAggregateRequest.Unmarshal(m, b)
+}
+func (m *WindowedAggregateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WindowedAggregateRequest.Marshal(b, m, deterministic)
+}
+func (m *WindowedAggregateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WindowedAggregateRequest.Merge(m, src)
+}
+func (m *WindowedAggregateRequest) XXX_Size() int {
+	return xxx_messageInfo_WindowedAggregateRequest.Size(m)
+}
+func (m *WindowedAggregateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WindowedAggregateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WindowedAggregateRequest proto.InternalMessageInfo
+
+func (m *WindowedAggregateRequest) GetAggregate() WindowedAggregateRequest_Aggregate {
+	if m != nil {
+		return m.Aggregate
+	}
+	return WindowedAggregateRequest_AGGREGATE_UNSPECIFIED
+}
+
+type isWindowedAggregateRequest_Window interface {
+	isWindowedAggregateRequest_Window()
+}
+
+type WindowedAggregateRequest_CountWindow struct {
+	CountWindow *CountWindow `protobuf:"bytes,2,opt,name=count_window,json=countWindow,proto3,oneof"`
+}
+
+type WindowedAggregateRequest_TimeWindow struct {
+	TimeWindow *TimeWindow `protobuf:"bytes,3,opt,name=time_window,json=timeWindow,proto3,oneof"`
+}
+
+func (*WindowedAggregateRequest_CountWindow) isWindowedAggregateRequest_Window() {}
+
+func (*WindowedAggregateRequest_TimeWindow) isWindowedAggregateRequest_Window() {}
+
+func (m *WindowedAggregateRequest) GetWindow() isWindowedAggregateRequest_Window {
+	if m != nil {
+		return m.Window
+	}
+	return nil
+}
+
+func (m *WindowedAggregateRequest) GetCountWindow() *CountWindow {
+	if x, ok := m.GetWindow().(*WindowedAggregateRequest_CountWindow); ok {
+		return x.CountWindow
+	}
+	return nil
+}
+
+func (m *WindowedAggregateRequest) GetTimeWindow() *TimeWindow {
+	if x, ok := m.GetWindow().(*WindowedAggregateRequest_TimeWindow); ok {
+		return x.TimeWindow
+	}
+	return nil
+}
+
+func (m *WindowedAggregateRequest) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *WindowedAggregateRequest) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*WindowedAggregateRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*WindowedAggregateRequest_CountWindow)(nil),
+		(*WindowedAggregateRequest_TimeWindow)(nil),
+	}
+}
+
+type WindowedAggregateResponse struct {
+	// the aggregate of the values in the window
+	Result float64 `protobuf:"fixed64,1,opt,name=result,proto3" json:"result,omitempty"`
+	// the number of values in the window
+	Count int64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// the position, counting from 1, of the last value received before the
+	// window closed
+	LastPosition int64 `protobuf:"varint,3,opt,name=last_position,json=lastPosition,proto3" json:"last_position,omitempty"`
+	// the bounds of a time window, unset for count windows
+	WindowStart          *timestamp.Timestamp `protobuf:"bytes,4,opt,name=window_start,json=windowStart,proto3" json:"window_start,omitempty"`
+	WindowEnd            *timestamp.Timestamp `protobuf:"bytes,5,opt,name=window_end,json=windowEnd,proto3" json:"window_end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *WindowedAggregateResponse) Reset()         { *m = WindowedAggregateResponse{} }
+func (m *WindowedAggregateResponse) String() string { return proto.CompactTextString(m) }
+func (*WindowedAggregateResponse) ProtoMessage()    {}
+func (*WindowedAggregateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_87e717c78a24322a, []int{23}
+}
+
+func (m *WindowedAggregateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WindowedAggregateResponse.Unmarshal(m, b)
+}
+func (m *WindowedAggregateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WindowedAggregateResponse.Marshal(b, m, deterministic)
+}
+func (m *WindowedAggregateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WindowedAggregateResponse.Merge(m, src)
+}
+func (m *WindowedAggregateResponse) XXX_Size() int {
+	return xxx_messageInfo_WindowedAggregateResponse.Size(m)
+}
+func (m *WindowedAggregateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WindowedAggregateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WindowedAggregateResponse proto.InternalMessageInfo
+
+func (m *WindowedAggregateResponse) GetResult() float64 {
+	if m != nil {
+		return m.Result
+	}
+	return 0
+}
+
+func (m *WindowedAggregateResponse) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *WindowedAggregateResponse) GetLastPosition() int64 {
+	if m != nil {
+		return m.LastPosition
+	}
+	return 0
+}
+
+func (m *WindowedAggregateResponse) GetWindowStart() *timestamp.Timestamp {
+	if m != nil {
+		return m.WindowStart
+	}
+	return nil
+}
+
+func (m *WindowedAggregateResponse) GetWindowEnd() *timestamp.Timestamp {
+	if m != nil {
+		return m.WindowEnd
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("calculator.BigArithmeticRequest_Operation", BigArithmeticRequest_Operation_name, BigArithmeticRequest_Operation_value)
+	proto.RegisterEnum("calculator.WindowedAggregateRequest_Aggregate", WindowedAggregateRequest_Aggregate_name, WindowedAggregateRequest_Aggregate_value)
+	proto.RegisterType((*SumRequest)(nil), "calculator.SumRequest")
+	proto.RegisterType((*SumResponse)(nil), "calculator.SumResponse")
+	proto.RegisterType((*PrimeNumberDecompositionRequest)(nil), "calculator.PrimeNumberDecompositionRequest")
+	proto.RegisterType((*PrimeNumberDecompositionResponse)(nil), "calculator.PrimeNumberDecompositionResponse")
+	proto.RegisterType((*ComputAverageRequest)(nil), "calculator.ComputAverageRequest")
+	proto.RegisterType((*ComputeAverageResponse)(nil), "calculator.ComputeAverageResponse")
+	proto.RegisterType((*FindMaximumRequest)(nil), "calculator.FindMaximumRequest")
+	proto.RegisterType((*FindMaximumResponse)(nil), "calculator.FindMaximumResponse")
+	proto.RegisterType((*SquareRootRequest)(nil), "calculator.SquareRootRequest")
+	proto.RegisterType((*SquareRootResponse)(nil), "calculator.SquareRootResponse")
+	proto.RegisterType((*EvaluateRequest)(nil), "calculator.EvaluateRequest")
+	proto.RegisterType((*EvaluateResponse)(nil), "calculator.EvaluateResponse")
+	proto.RegisterType((*Number)(nil), "calculator.Number")
+	proto.RegisterType((*BigArithmeticRequest)(nil), "calculator.BigArithmeticRequest")
+	proto.RegisterType((*BigArithmeticResponse)(nil), "calculator.BigArithmeticResponse")
+	proto.RegisterType((*Statistics)(nil), "calculator.Statistics")
+	proto.RegisterType((*ComputeStatisticsRequest)(nil), "calculator.ComputeStatisticsRequest")
+	proto.RegisterType((*ComputeStatisticsResponse)(nil), "calculator.ComputeStatisticsResponse")
+	proto.RegisterType((*StreamStatisticsRequest)(nil), "calculator.StreamStatisticsRequest")
+	proto.RegisterType((*StreamStatisticsResponse)(nil), "calculator.StreamStatisticsResponse")
+	proto.RegisterType((*CountWindow)(nil), "calculator.CountWindow")
+	proto.RegisterType((*TimeWindow)(nil), "calculator.TimeWindow")
+	proto.RegisterType((*WindowedAggregateRequest)(nil), "calculator.WindowedAggregateRequest")
+	proto.RegisterType((*WindowedAggregateResponse)(nil), "calculator.WindowedAggregateResponse")
+}
+
+func init() {
+	proto.RegisterFile("calculatorpb/calculator.proto", fileDescriptor_87e717c78a24322a)
+}
+
+var fileDescriptor_87e717c78a24322a = []byte{
+	// 1248 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x57, 0xdb, 0x6e, 0xdb, 0x46,
+	0x13, 0x36, 0xad, 0x83, 0xad, 0xa1, 0x92, 0x9f, 0xde, 0x3f, 0x71, 0x68, 0x36, 0xb1, 0x1d, 0x26,
+	0x01, 0x82, 0x24, 0x95, 0x5d, 0x17, 0x69, 0x2b, 0xa0, 0xbd, 0x90, 0x2d, 0xc5, 0x11, 0x10, 0x1f,
+	0x40, 0xc9, 0x49, 0x93, 0x1b, 0x61, 0x45, 0xad, 0x55, 0x02, 0xe2, 0x21, 0xe4, 0xd2, 0x71, 0xfa,
+	0x04, 0x7d, 0x89, 0xde, 0xf6, 0x91, 0xfa, 0x0c, 0x7d, 0x8c, 0x62, 0x0f, 0x3c, 0xe9, 0x60, 0x19,
+	0xe8, 0xdd, 0xce, 0xc7, 0xef, 0x9b, 0xd9, 0x1d, 0xcd, 0xce, 0x8e, 0xe0, 0x91, 0x8d, 0x27, 0x76,
+	0x3c, 0xc1, 0xd4, 0x0f, 0x83, 0xe1, 0x5e, 0x66, 0x34, 0x82, 0xd0, 0xa7, 0x3e, 0x82, 0x0c, 0x31,
+	0xb6, 0xc7, 0xbe, 0x3f, 0x9e, 0x90, 0x3d, 0xfe, 0x65, 0x18, 0x5f, 0xee, 0x8d, 0xe2, 0x10, 0x53,
+	0xc7, 0xf7, 0x04, 0xd7, 0xd8, 0x99, 0xfe, 0x4e, 0x1d, 0x97, 0x44, 0x14, 0xbb, 0x81, 0x20, 0x98,
+	0x16, 0x40, 0x2f, 0x76, 0x2d, 0xf2, 0x39, 0x26, 0x11, 0x45, 0xbb, 0xa0, 0x5e, 0x3a, 0x61, 0x44,
+	0x4f, 0x63, 0x77, 0x48, 0x42, 0x5d, 0xd9, 0x55, 0x9e, 0x57, 0xac, 0x3c, 0x84, 0x4c, 0xa8, 0x47,
+	0xc4, 0xf6, 0xbd, 0x91, 0xa4, 0xac, 0x72, 0x4a, 0x01, 0x33, 0x5f, 0x82, 0xca, 0x7d, 0x46, 0x81,
+	0xef, 0x45, 0x04, 0x3d, 0x84, 0x5a, 0xc4, 0xcd, 0x78, 0x42, 0xa5, 0xcb, 0x0c, 0x30, 0x9b, 0xb0,
+	0x73, 0x1e, 0x3a, 0x2e, 0x11, 0xda, 0x36, 0xb1, 0x7d, 0x37, 0xf0, 0x23, 0x87, 0x9d, 0x21, 0xd9,
+	0xd5, 0x26, 0x54, 0xbd, 0x6c, 0x43, 0x25, 0x4b, 0x5a, 0x66, 0x07, 0x76, 0x17, 0x4b, 0x65, 0xf0,
+	0xc7, 0x50, 0x0f, 0x18, 0x67, 0x70, 0x89, 0x6d, 0xea, 0x27, 0x1e, 0x54, 0x8e, 0xbd, 0xe1, 0x90,
+	0xd9, 0x80, 0x7b, 0x47, 0xbe, 0x1b, 0xc4, 0xb4, 0x75, 0x45, 0x42, 0x3c, 0x26, 0xf3, 0xc3, 0x56,
+	0xd2, 0xb0, 0x07, 0xb0, 0x29, 0xf8, 0x24, 0x15, 0xc8, 0x60, 0x3a, 0xac, 0x61, 0x01, 0x71, 0x89,
+	0x62, 0x25, 0xa6, 0xf9, 0x0a, 0xd0, 0x1b, 0xc7, 0x1b, 0x9d, 0xe0, 0x6b, 0xc7, 0x8d, 0xdd, 0x65,
+	0x11, 0xf6, 0xe0, 0xff, 0x05, 0x76, 0xe6, 0xde, 0x15, 0x90, 0xe4, 0x27, 0xa6, 0xf9, 0x12, 0x36,
+	0x7a, 0x9f, 0x63, 0x1c, 0x12, 0xcb, 0xf7, 0xe9, 0x32, 0xef, 0xaf, 0x01, 0xe5, 0xc9, 0xd2, 0xf9,
+	0x0e, 0xa8, 0xe2, 0xfb, 0x20, 0xf4, 0x7d, 0x2a, 0xf7, 0x0f, 0x02, 0x62, 0x44, 0xf3, 0x3b, 0xf8,
+	0x5f, 0xe7, 0x0a, 0x4f, 0x62, 0x4c, 0xd3, 0x0c, 0x6d, 0x03, 0x90, 0xeb, 0x20, 0x24, 0x51, 0xe4,
+	0xf8, 0x1e, 0x97, 0xd4, 0xac, 0x1c, 0x62, 0xbe, 0x00, 0x2d, 0x93, 0xc8, 0x38, 0x9b, 0x50, 0x0d,
+	0xb3, 0x52, 0x50, 0x2c, 0x69, 0x99, 0xdb, 0x50, 0x95, 0x25, 0x76, 0x0f, 0x2a, 0x4c, 0x44, 0xa4,
+	0x43, 0x61, 0x98, 0x7f, 0xad, 0xc2, 0xbd, 0x43, 0x67, 0xdc, 0x0a, 0x1d, 0xfa, 0x9b, 0x4b, 0xa8,
+	0x63, 0x27, 0x9b, 0x78, 0x0b, 0x35, 0x3f, 0x20, 0xa2, 0xea, 0xb9, 0xe4, 0xee, 0xc1, 0x8b, 0x46,
+	0xee, 0xd2, 0xcc, 0x13, 0x35, 0xce, 0x12, 0x85, 0x95, 0x89, 0xd1, 0x2e, 0x28, 0x98, 0x17, 0xb4,
+	0x7a, 0x80, 0xf2, 0x1e, 0xc4, 0xbe, 0x2c, 0x05, 0x33, 0xc6, 0x50, 0x2f, 0x2d, 0x66, 0x0c, 0x59,
+	0xb1, 0x07, 0x21, 0xb1, 0x1d, 0x9e, 0x91, 0xb2, 0x28, 0xf6, 0x14, 0x30, 0x87, 0x50, 0x4b, 0x23,
+	0xa3, 0x2d, 0xb8, 0x7f, 0x76, 0xde, 0xb1, 0x5a, 0xfd, 0xee, 0xd9, 0xe9, 0xe0, 0xe2, 0xb4, 0x77,
+	0xde, 0x39, 0xea, 0xbe, 0xe9, 0x76, 0xda, 0xda, 0x0a, 0x5a, 0x83, 0x52, 0xab, 0xdd, 0xd6, 0x14,
+	0x54, 0x87, 0xf5, 0xde, 0xc5, 0x61, 0xdf, 0x6a, 0x1d, 0xf5, 0xb5, 0x55, 0x66, 0x9d, 0x5c, 0xbc,
+	0xeb, 0x77, 0xcf, 0xdf, 0x7d, 0xd4, 0x4a, 0x08, 0xa0, 0xda, 0xee, 0xbe, 0xef, 0xb6, 0x3b, 0x5a,
+	0x19, 0xd5, 0xa0, 0x72, 0x7e, 0xf6, 0xa1, 0x63, 0x69, 0x15, 0xf3, 0x23, 0xdc, 0x9f, 0x3a, 0xb2,
+	0xcc, 0xfc, 0x8b, 0x42, 0xe6, 0xe7, 0x9f, 0x40, 0x32, 0xd8, 0x6f, 0x40, 0xae, 0xb1, 0x4d, 0x79,
+	0x3a, 0xd6, 0x2d, 0x61, 0x98, 0x7f, 0x2b, 0x00, 0x3d, 0x8a, 0xa9, 0x13, 0x51, 0xc7, 0x8e, 0x18,
+	0xc9, 0xf6, 0x63, 0x8f, 0xca, 0x4b, 0x25, 0x0c, 0xa4, 0x41, 0x29, 0x8a, 0x5d, 0x2e, 0x54, 0x2c,
+	0xb6, 0x64, 0x88, 0xeb, 0x78, 0x3c, 0x6f, 0x8a, 0xc5, 0x96, 0x1c, 0xc1, 0xd7, 0x7a, 0x59, 0x22,
+	0xf8, 0x1a, 0x21, 0x28, 0xbb, 0x04, 0x7b, 0x7a, 0x85, 0x43, 0x7c, 0x8d, 0x0c, 0x58, 0xbf, 0xc2,
+	0xa1, 0x83, 0x3d, 0x9b, 0xe8, 0x55, 0x8e, 0xa7, 0x36, 0x2b, 0xa3, 0x88, 0x8e, 0x46, 0xe4, 0x4a,
+	0x5f, 0x13, 0x65, 0x24, 0x2c, 0xe6, 0x39, 0x78, 0xbd, 0xaf, 0xaf, 0x0b, 0xcf, 0xc1, 0xeb, 0x7d,
+	0x8e, 0x34, 0xf7, 0xf5, 0x9a, 0x44, 0x9a, 0x12, 0x69, 0xea, 0x90, 0x20, 0x4d, 0x73, 0x1f, 0x74,
+	0x79, 0xa5, 0xb3, 0xe3, 0x25, 0xf5, 0x55, 0x28, 0x47, 0x25, 0x29, 0xc7, 0x1e, 0x6c, 0xcd, 0x51,
+	0xc8, 0x4c, 0xff, 0x00, 0x10, 0xa5, 0xa8, 0xcc, 0xf6, 0x66, 0x3e, 0xdb, 0x39, 0x4d, 0x8e, 0x69,
+	0x5a, 0xf0, 0xa0, 0x47, 0x43, 0x82, 0xdd, 0x5b, 0xee, 0x82, 0x75, 0xb7, 0x90, 0x04, 0x7e, 0x48,
+	0x07, 0xe4, 0x8a, 0x84, 0x5f, 0x65, 0x37, 0x56, 0x05, 0xd6, 0x61, 0x90, 0x69, 0x81, 0x3e, 0xeb,
+	0xf3, 0x3f, 0xee, 0xf3, 0x47, 0x50, 0x8f, 0xd8, 0x6f, 0xfd, 0xc1, 0xf1, 0x46, 0xfe, 0x17, 0xf6,
+	0xdb, 0x45, 0xce, 0xef, 0x44, 0xb6, 0x19, 0xbe, 0x66, 0xfb, 0x8d, 0x26, 0xce, 0x88, 0xc8, 0x2d,
+	0x09, 0xc3, 0x9c, 0x00, 0xf4, 0x1d, 0x97, 0x48, 0xdd, 0xb7, 0x39, 0x9d, 0x7a, 0xb0, 0xd5, 0x10,
+	0x6f, 0x55, 0x23, 0x79, 0xab, 0x1a, 0x6d, 0xf9, 0x96, 0x49, 0x97, 0x7b, 0x79, 0x97, 0x37, 0xf2,
+	0x65, 0xb4, 0x3f, 0x4a, 0xa0, 0x8b, 0x50, 0x64, 0xd4, 0x1a, 0x8f, 0x43, 0x32, 0xce, 0xf5, 0xae,
+	0x77, 0x50, 0xc3, 0x09, 0x26, 0xdb, 0x46, 0x23, 0x7f, 0xf4, 0x45, 0xc2, 0x46, 0x06, 0x64, 0x0e,
+	0xd0, 0xcf, 0x50, 0xe7, 0xd5, 0x3f, 0xf8, 0xc2, 0x65, 0x72, 0x8b, 0x0f, 0xf2, 0x0e, 0x73, 0x19,
+	0x7b, 0xbb, 0x62, 0xa9, 0x76, 0x66, 0xa2, 0x26, 0xa8, 0xec, 0x5d, 0x4e, 0xc4, 0xa5, 0xd9, 0x1f,
+	0x22, 0xcb, 0xda, 0xdb, 0x15, 0x0b, 0x68, 0x6a, 0x65, 0x75, 0x51, 0xce, 0xd7, 0x45, 0x03, 0xca,
+	0x8c, 0xc3, 0x6f, 0x93, 0x7a, 0x60, 0xcc, 0x64, 0xaa, 0x9f, 0x4c, 0x01, 0x16, 0xe7, 0x99, 0x17,
+	0x50, 0x4b, 0x8f, 0xc5, 0xfa, 0x52, 0xeb, 0xf8, 0xd8, 0xea, 0x1c, 0xb7, 0xfa, 0x9d, 0xd9, 0xbe,
+	0x74, 0xd2, 0x3d, 0xd5, 0x14, 0xbe, 0x68, 0xfd, 0xaa, 0xad, 0xb2, 0x45, 0xef, 0xe2, 0x44, 0x2b,
+	0xb1, 0x45, 0xeb, 0xfd, 0xb1, 0x68, 0x45, 0x47, 0x67, 0x17, 0xa7, 0x7d, 0xad, 0x72, 0xb8, 0x0e,
+	0x55, 0x71, 0x24, 0xf3, 0x1f, 0x05, 0xb6, 0xe6, 0x64, 0xf4, 0xe6, 0x37, 0x21, 0x6b, 0x30, 0xab,
+	0xf9, 0x06, 0xf3, 0x04, 0xee, 0x4c, 0x70, 0x44, 0x07, 0xc9, 0x5b, 0xcf, 0xf3, 0x55, 0xb2, 0xea,
+	0x0c, 0x3c, 0x97, 0x18, 0xfa, 0x05, 0xea, 0x22, 0xf4, 0x20, 0xa2, 0x38, 0xa4, 0x7a, 0x79, 0x69,
+	0x26, 0x54, 0xc1, 0xef, 0x31, 0x3a, 0x6a, 0x02, 0x48, 0x39, 0xf1, 0x46, 0xb7, 0x48, 0x63, 0x4d,
+	0xb0, 0x3b, 0xde, 0xe8, 0xe0, 0xcf, 0x35, 0xd8, 0x38, 0x4a, 0x7f, 0xb9, 0x1e, 0x09, 0xaf, 0x1c,
+	0x9b, 0xa0, 0x9f, 0xa0, 0xd4, 0x8b, 0x5d, 0x54, 0xbc, 0x5d, 0xe9, 0x24, 0x60, 0x3c, 0x98, 0xc1,
+	0x45, 0x6a, 0xcc, 0x15, 0xf4, 0x15, 0xf4, 0x45, 0x53, 0x0e, 0x7a, 0x99, 0x97, 0x2d, 0x19, 0xa3,
+	0x8c, 0x57, 0xb7, 0x23, 0x27, 0x81, 0xf7, 0x15, 0xf4, 0x09, 0xee, 0x16, 0x27, 0x1d, 0xb4, 0x5b,
+	0xac, 0xe8, 0xd9, 0xa9, 0xc9, 0x30, 0x67, 0x19, 0xd3, 0x73, 0x92, 0xb9, 0xf2, 0x5c, 0x41, 0x23,
+	0xd8, 0x98, 0x69, 0xa0, 0xe8, 0xe9, 0x1c, 0xf1, 0x4c, 0x2f, 0x34, 0x9e, 0x2d, 0x61, 0xe5, 0xa2,
+	0xd8, 0xa0, 0x4d, 0x77, 0x3f, 0xf4, 0xa4, 0xd8, 0xe1, 0xe6, 0xf6, 0x5b, 0xe3, 0xe9, 0xcd, 0xa4,
+	0x2c, 0xc4, 0xbe, 0x82, 0xfa, 0xa0, 0xe6, 0xc6, 0x35, 0xb4, 0x9d, 0x97, 0xce, 0x4e, 0x7d, 0xc6,
+	0xce, 0xc2, 0xef, 0x05, 0xaf, 0x97, 0xb0, 0x31, 0x73, 0x63, 0x8a, 0x09, 0x5a, 0xd4, 0xa2, 0x8c,
+	0x67, 0x4b, 0x58, 0x85, 0x38, 0x27, 0x00, 0xd9, 0x38, 0x88, 0x1e, 0x15, 0xce, 0x3d, 0x3d, 0x53,
+	0x1a, 0xdb, 0x8b, 0x3e, 0xa7, 0xe5, 0x7a, 0x0c, 0xeb, 0xc9, 0xcc, 0x87, 0xbe, 0xc9, 0xb3, 0xa7,
+	0x86, 0x47, 0xe3, 0xe1, 0xfc, 0x8f, 0xa9, 0xa3, 0xf7, 0x70, 0xa7, 0x30, 0xc7, 0x14, 0x6b, 0x6f,
+	0xde, 0x54, 0x67, 0x3c, 0xbe, 0x81, 0x91, 0xf8, 0x3d, 0xbc, 0xfb, 0xa9, 0x9e, 0xff, 0x7f, 0x35,
+	0xac, 0xf2, 0xeb, 0xfc, 0xfd, 0xbf, 0x03, 0x00, 0xba, 0x86, 0x18, 0xef, 0x76, 0x0d, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// CalculatorServiceClient is the client API for CalculatorService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type CalculatorServiceClient interface {
+	// a sum that does not fit in an int32 is an OUT_OF_RANGE error, use
+	// BigArithmetic for larger numbers
+	Sum(ctx context.Context, in *SumRequest, opts ...grpc.CallOption) (*SumResponse, error)
+	// streams the prime factors of a positive number in ascending order
+	// a call that computes for longer than the budget of the server is a
+	// RESOURCE_EXHAUSTED error
+	PrimeNumberDecomposition(ctx context.Context, in *PrimeNumberDecompositionRequest, opts ...grpc.CallOption) (CalculatorService_PrimeNumberDecompositionClient, error)
+	// an empty stream is an INVALID_ARGUMENT error
+	ComputeAverage(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_ComputeAverageClient, error)
+	// computes the statistics of the values sent
+	// a value that is NaN or infinite is an INVALID_ARGUMENT error
+	ComputeStatistics(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_ComputeStatisticsClient, error)
+	// sends the statistics of the values received so far every few values,
+	// and once more when the client ends a stream with values not reported yet
+	StreamStatistics(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_StreamStatisticsClient, error)
+	// sends the maximum so far whenever it changes, starting with the first
+	// number
+	FindMaximum(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_FindMaximumClient, error)
+	// aggregates the values sent over count or time windows and sends the
+	// aggregate of every window as it closes, skipping windows without values
+	// when the client ends the stream, the next window is sent if it holds
+	// values that were not reported yet
+	WindowedAggregate(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_WindowedAggregateClient, error)
+	// error handling
+	// this RPC will throw an expection if the sent number is negative
+	// the error being sent is of type INVALID_ARGUMENT
+	SquareRoot(ctx context.Context, in *SquareRootRequest, opts ...grpc.CallOption) (*SquareRootResponse, error)
+	// evaluates an arithmetic expression
+	// a syntax error, or a result that is not defined such as a division by
+	// zero, is an INVALID_ARGUMENT error giving the column of the problem
+	Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error)
+	// exact arithmetic on numbers of any size
+	BigArithmetic(ctx context.Context, in *BigArithmeticRequest, opts ...grpc.CallOption) (*BigArithmeticResponse, error)
+}
+
+type calculatorServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCalculatorServiceClient(cc grpc.ClientConnInterface) CalculatorServiceClient {
+	return &calculatorServiceClient{cc}
+}
+
+func (c *calculatorServiceClient) Sum(ctx context.Context, in *SumRequest, opts ...grpc.CallOption) (*SumResponse, error) {
+	out := new(SumResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/Sum", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) PrimeNumberDecomposition(ctx context.Context, in *PrimeNumberDecompositionRequest, opts ...grpc.CallOption) (CalculatorService_PrimeNumberDecompositionClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[0], "/calculator.CalculatorService/PrimeNumberDecomposition", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &calculatorServicePrimeNumberDecompositionClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CalculatorService_PrimeNumberDecompositionClient interface {
+	Recv() (*PrimeNumberDecompositionResponse, error)
+	grpc.ClientStream
+}
+
+type calculatorServicePrimeNumberDecompositionClient struct {
+	grpc.ClientStream
+}
+
+func (x *calculatorServicePrimeNumberDecompositionClient) Recv() (*PrimeNumberDecompositionResponse, error) {
+	m := new(PrimeNumberDecompositionResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *calculatorServiceClient) ComputeAverage(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_ComputeAverageClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[1], "/calculator.CalculatorService/ComputeAverage", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &calculatorServiceComputeAverageClient{stream}
+	return x, nil
+}
+
+type CalculatorService_ComputeAverageClient interface {
+	Send(*ComputAverageRequest) error
+	CloseAndRecv() (*ComputeAverageResponse, error)
+	grpc.ClientStream
+}
+
+type calculatorServiceComputeAverageClient struct {
+	grpc.ClientStream
+}
+
+func (x *calculatorServiceComputeAverageClient) Send(m *ComputAverageRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *calculatorServiceComputeAverageClient) CloseAndRecv() (*ComputeAverageResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ComputeAverageResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *calculatorServiceClient) ComputeStatistics(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_ComputeStatisticsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[2], "/calculator.CalculatorService/ComputeStatistics", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &calculatorServiceComputeStatisticsClient{stream}
+	return x, nil
+}
+
+type CalculatorService_ComputeStatisticsClient interface {
+	Send(*ComputeStatisticsRequest) error
+	CloseAndRecv() (*ComputeStatisticsResponse, error)
+	grpc.ClientStream
+}
+
+type calculatorServiceComputeStatisticsClient struct {
+	grpc.ClientStream
+}
+
+func (x *calculatorServiceComputeStatisticsClient) Send(m *ComputeStatisticsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *calculatorServiceComputeStatisticsClient) CloseAndRecv() (*ComputeStatisticsResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ComputeStatisticsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *calculatorServiceClient) StreamStatistics(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_StreamStatisticsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[3], "/calculator.CalculatorService/StreamStatistics", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &calculatorServiceStreamStatisticsClient{stream}
+	return x, nil
+}
+
+type CalculatorService_StreamStatisticsClient interface {
+	Send(*StreamStatisticsRequest) error
+	Recv() (*StreamStatisticsResponse, error)
+	grpc.ClientStream
+}
+
+type calculatorServiceStreamStatisticsClient struct {
+	grpc.ClientStream
+}
+
+func (x *calculatorServiceStreamStatisticsClient) Send(m *StreamStatisticsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *calculatorServiceStreamStatisticsClient) Recv() (*StreamStatisticsResponse, error) {
+	m := new(StreamStatisticsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *calculatorServiceClient) FindMaximum(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_FindMaximumClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[4], "/calculator.CalculatorService/FindMaximum", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &calculatorServiceFindMaximumClient{stream}
+	return x, nil
+}
+
+type CalculatorService_FindMaximumClient interface {
+	Send(*FindMaximumRequest) error
+	Recv() (*FindMaximumResponse, error)
+	grpc.ClientStream
+}
+
+type calculatorServiceFindMaximumClient struct {
+	grpc.ClientStream
+}
+
+func (x *calculatorServiceFindMaximumClient) Send(m *FindMaximumRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *calculatorServiceFindMaximumClient) Recv() (*FindMaximumResponse, error) {
+	m := new(FindMaximumResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *calculatorServiceClient) WindowedAggregate(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_WindowedAggregateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[5], "/calculator.CalculatorService/WindowedAggregate", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &calculatorServiceWindowedAggregateClient{stream}
+	return x, nil
+}
+
+type CalculatorService_WindowedAggregateClient interface {
+	Send(*WindowedAggregateRequest) error
+	Recv() (*WindowedAggregateResponse, error)
+	grpc.ClientStream
+}
+
+type calculatorServiceWindowedAggregateClient struct {
+	grpc.ClientStream
+}
+
+func (x *calculatorServiceWindowedAggregateClient) Send(m *WindowedAggregateRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *calculatorServiceWindowedAggregateClient) Recv() (*WindowedAggregateResponse, error) {
+	m := new(WindowedAggregateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *calculatorServiceClient) SquareRoot(ctx context.Context, in *SquareRootRequest, opts ...grpc.CallOption) (*SquareRootResponse, error) {
+	out := new(SquareRootResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/SquareRoot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error) {
+	out := new(EvaluateResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/Evaluate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) BigArithmetic(ctx context.Context, in *BigArithmeticRequest, opts ...grpc.CallOption) (*BigArithmeticResponse, error) {
+	out := new(BigArithmeticResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/BigArithmetic", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CalculatorServiceServer is the server API for CalculatorService service.
+type CalculatorServiceServer interface {
+	// a sum that does not fit in an int32 is an OUT_OF_RANGE error, use
+	// BigArithmetic for larger numbers
+	Sum(context.Context, *SumRequest) (*SumResponse, error)
+	// streams the prime factors of a positive number in ascending order
+	// a call that computes for longer than the budget of the server is a
+	// RESOURCE_EXHAUSTED error
+	PrimeNumberDecomposition(*PrimeNumberDecompositionRequest, CalculatorService_PrimeNumberDecompositionServer) error
+	// an empty stream is an INVALID_ARGUMENT error
+	ComputeAverage(CalculatorService_ComputeAverageServer) error
+	// computes the statistics of the values sent
+	// a value that is NaN or infinite is an INVALID_ARGUMENT error
+	ComputeStatistics(CalculatorService_ComputeStatisticsServer) error
+	// sends the statistics of the values received so far every few values,
+	// and once more when the client ends a stream with values not reported yet
+	StreamStatistics(CalculatorService_StreamStatisticsServer) error
+	// sends the maximum so far whenever it changes, starting with the first
+	// number
+	FindMaximum(CalculatorService_FindMaximumServer) error
+	// aggregates the values sent over count or time windows and sends the
+	// aggregate of every window as it closes, skipping windows without values
+	// when the client ends the stream, the next window is sent if it holds
+	// values that were not reported yet
+	WindowedAggregate(CalculatorService_WindowedAggregateServer) error
+	// error handling
+	// this RPC will throw an expection if the sent number is negative
+	// the error being sent is of type INVALID_ARGUMENT
+	SquareRoot(context.Context, *SquareRootRequest) (*SquareRootResponse, error)
+	// evaluates an arithmetic expression
+	// a syntax error, or a result that is not defined such as a division by
+	// zero, is an INVALID_ARGUMENT error giving the column of the problem
+	Evaluate(context.Context, *EvaluateRequest) (*EvaluateResponse, error)
+	// exact arithmetic on numbers of any size
+	BigArithmetic(context.Context, *BigArithmeticRequest) (*BigArithmeticResponse, error)
+}
+
+// UnimplementedCalculatorServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedCalculatorServiceServer struct {
+}
+
+func (*UnimplementedCalculatorServiceServer) Sum(ctx context.Context, req *SumRequest) (*SumResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Sum not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) PrimeNumberDecomposition(req *PrimeNumberDecompositionRequest, srv CalculatorService_PrimeNumberDecompositionServer) error {
+	return status.Errorf(codes.Unimplemented, "method PrimeNumberDecomposition not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) ComputeAverage(srv CalculatorService_ComputeAverageServer) error {
+	return status.Errorf(codes.Unimplemented, "method ComputeAverage not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) ComputeStatistics(srv CalculatorService_ComputeStatisticsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ComputeStatistics not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) StreamStatistics(srv CalculatorService_StreamStatisticsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamStatistics not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) FindMaximum(srv CalculatorService_FindMaximumServer) error {
+	return status.Errorf(codes.Unimplemented, "method FindMaximum not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) WindowedAggregate(srv CalculatorService_WindowedAggregateServer) error {
+	return status.Errorf(codes.Unimplemented, "method WindowedAggregate not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) SquareRoot(ctx context.Context, req *SquareRootRequest) (*SquareRootResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SquareRoot not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) Evaluate(ctx context.Context, req *EvaluateRequest) (*EvaluateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Evaluate not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) BigArithmetic(ctx context.Context, req *BigArithmeticRequest) (*BigArithmeticResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BigArithmetic not implemented")
+}
+
+func RegisterCalculatorServiceServer(s *grpc.Server, srv CalculatorServiceServer) {
+	s.RegisterService(&_CalculatorService_serviceDesc, srv)
+}
+
+func _CalculatorService_Sum_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SumRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).Sum(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/Sum",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).Sum(ctx, req.(*SumRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_PrimeNumberDecomposition_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PrimeNumberDecompositionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CalculatorServiceServer).PrimeNumberDecomposition(m, &calculatorServicePrimeNumberDecompositionServer{stream})
+}
+
+type CalculatorService_PrimeNumberDecompositionServer interface {
+	Send(*PrimeNumberDecompositionResponse) error
+	grpc.ServerStream
+}
+
+type calculatorServicePrimeNumberDecompositionServer struct {
+	grpc.ServerStream
 }
 
 func (x *calculatorServicePrimeNumberDecompositionServer) Send(m *PrimeNumberDecompositionResponse) error {
@@ -716,6 +1841,58 @@ func (x *calculatorServiceComputeAverageServer) Recv() (*ComputAverageRequest, e
 	return m, nil
 }
 
+func _CalculatorService_ComputeStatistics_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CalculatorServiceServer).ComputeStatistics(&calculatorServiceComputeStatisticsServer{stream})
+}
+
+type CalculatorService_ComputeStatisticsServer interface {
+	SendAndClose(*ComputeStatisticsResponse) error
+	Recv() (*ComputeStatisticsRequest, error)
+	grpc.ServerStream
+}
+
+type calculatorServiceComputeStatisticsServer struct {
+	grpc.ServerStream
+}
+
+func (x *calculatorServiceComputeStatisticsServer) SendAndClose(m *ComputeStatisticsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *calculatorServiceComputeStatisticsServer) Recv() (*ComputeStatisticsRequest, error) {
+	m := new(ComputeStatisticsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _CalculatorService_StreamStatistics_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CalculatorServiceServer).StreamStatistics(&calculatorServiceStreamStatisticsServer{stream})
+}
+
+type CalculatorService_StreamStatisticsServer interface {
+	Send(*StreamStatisticsResponse) error
+	Recv() (*StreamStatisticsRequest, error)
+	grpc.ServerStream
+}
+
+type calculatorServiceStreamStatisticsServer struct {
+	grpc.ServerStream
+}
+
+func (x *calculatorServiceStreamStatisticsServer) Send(m *StreamStatisticsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *calculatorServiceStreamStatisticsServer) Recv() (*StreamStatisticsRequest, error) {
+	m := new(StreamStatisticsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _CalculatorService_FindMaximum_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(CalculatorServiceServer).FindMaximum(&calculatorServiceFindMaximumServer{stream})
 }
@@ -742,6 +1919,32 @@ func (x *calculatorServiceFindMaximumServer) Recv() (*FindMaximumRequest, error)
 	return m, nil
 }
 
+func _CalculatorService_WindowedAggregate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CalculatorServiceServer).WindowedAggregate(&calculatorServiceWindowedAggregateServer{stream})
+}
+
+type CalculatorService_WindowedAggregateServer interface {
+	Send(*WindowedAggregateResponse) error
+	Recv() (*WindowedAggregateRequest, error)
+	grpc.ServerStream
+}
+
+type calculatorServiceWindowedAggregateServer struct {
+	grpc.ServerStream
+}
+
+func (x *calculatorServiceWindowedAggregateServer) Send(m *WindowedAggregateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *calculatorServiceWindowedAggregateServer) Recv() (*WindowedAggregateRequest, error) {
+	m := new(WindowedAggregateRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _CalculatorService_SquareRoot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SquareRootRequest)
 	if err := dec(in); err != nil {
@@ -760,6 +1963,42 @@ func _CalculatorService_SquareRoot_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CalculatorService_Evaluate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).Evaluate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/Evaluate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).Evaluate(ctx, req.(*EvaluateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_BigArithmetic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BigArithmeticRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).BigArithmetic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/BigArithmetic",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).BigArithmetic(ctx, req.(*BigArithmeticRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _CalculatorService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "calculator.CalculatorService",
 	HandlerType: (*CalculatorServiceServer)(nil),
@@ -772,6 +2011,14 @@ var _CalculatorService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SquareRoot",
 			Handler:    _CalculatorService_SquareRoot_Handler,
 		},
+		{
+			MethodName: "Evaluate",
+			Handler:    _CalculatorService_Evaluate_Handler,
+		},
+		{
+			MethodName: "BigArithmetic",
+			Handler:    _CalculatorService_BigArithmetic_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -784,12 +2031,29 @@ var _CalculatorService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _CalculatorService_ComputeAverage_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "ComputeStatistics",
+			Handler:       _CalculatorService_ComputeStatistics_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "StreamStatistics",
+			Handler:       _CalculatorService_StreamStatistics_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "FindMaximum",
 			Handler:       _CalculatorService_FindMaximum_Handler,
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "WindowedAggregate",
+			Handler:       _CalculatorService_WindowedAggregate_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "calculatorpb/calculator.proto",
 }
@@ -30,7 +30,9 @@ func main() {
 	// doServerStreaming(c)
 	// doClientStreaming(c)
 	// doBiDiStreaming(c)
-	doErrorUnary(c)
+	// doErrorUnary(c)
+	// doEvaluate(c)
+	doBigArithmetic(c)
 }
 
 func sum(c calculatorpb.CalculatorServiceClient) {
@@ -167,3 +169,52 @@ func doErrorCall(c calculatorpb.CalculatorServiceClient, n int32) {
 	}
 	fmt.Printf("Result of square root of %v: %v\n", n, res1.GetNumberRoot())
 }
+
+func doEvaluate(c calculatorpb.CalculatorServiceClient) {
+	fmt.Println("Starting to do an Evaluate Unary RPC")
+
+	for _, expression := range []string{"2 * (3 + 4) ^ 2 - sqrt(16)", "max(1, 2) / (3 - 3)", "(1 + 2"} {
+		res, err := c.Evaluate(context.Background(), &calculatorpb.EvaluateRequest{
+			Expression: expression,
+		})
+		if err != nil {
+			respErr, ok := status.FromError(err)
+			if !ok {
+				log.Fatalf("big error calling evaluate: %v", err)
+			}
+			fmt.Printf("cannot evaluate %q: %v %v\n", expression, respErr.Code(), respErr.Message())
+			continue
+		}
+		fmt.Printf("%v = %v\n", expression, res.GetResult())
+	}
+}
+
+func doBigArithmetic(c calculatorpb.CalculatorServiceClient) {
+	fmt.Println("Starting to do a BigArithmetic Unary RPC")
+
+	requests := []*calculatorpb.BigArithmeticRequest{
+		{
+			Operation: calculatorpb.BigArithmeticRequest_ADD,
+			A:         &calculatorpb.Number{Value: "2147483647"},
+			B:         &calculatorpb.Number{Value: "1"},
+		},
+		{
+			Operation: calculatorpb.BigArithmeticRequest_DIVIDE,
+			A:         &calculatorpb.Number{Value: "1"},
+			B:         &calculatorpb.Number{Value: "3"},
+			Precision: 20,
+		},
+		{
+			Operation: calculatorpb.BigArithmeticRequest_POWER,
+			A:         &calculatorpb.Number{Value: "2"},
+			B:         &calculatorpb.Number{Value: "100"},
+		},
+	}
+	for _, req := range requests {
+		res, err := c.BigArithmetic(context.Background(), req)
+		if err != nil {
+			log.Fatalf("error while calling BigArithmetic RPC: %v", err)
+		}
+		fmt.Printf("%v %v %v = %v (exact: %v)\n", req.GetA().GetValue(), req.GetOperation(), req.GetB().GetValue(), res.GetResult().GetValue(), res.GetExact())
+	}
+}
@@ -0,0 +1,159 @@
+package expr
+
+import (
+	"fmt"
+	"math"
+)
+
+// node is a part of a parsed expression.
+type node interface {
+	eval() (float64, error)
+}
+
+type number struct {
+	v float64
+}
+
+func (n *number) eval() (float64, error) {
+	return n.v, nil
+}
+
+type negate struct {
+	x node
+}
+
+func (n *negate) eval() (float64, error) {
+	x, err := n.x.eval()
+	if err != nil {
+		return 0, err
+	}
+	return -x, nil
+}
+
+type binary struct {
+	op   token
+	x, y node
+}
+
+func (n *binary) eval() (float64, error) {
+	x, err := n.x.eval()
+	if err != nil {
+		return 0, err
+	}
+	y, err := n.y.eval()
+	if err != nil {
+		return 0, err
+	}
+
+	var v float64
+	switch n.op.text {
+	case "+":
+		v = x + y
+	case "-":
+		v = x - y
+	case "*":
+		v = x * y
+	case "/":
+		if y == 0 {
+			return 0, errorf(n.op.column, "division by zero")
+		}
+		v = x / y
+	case "%":
+		if y == 0 {
+			return 0, errorf(n.op.column, "modulo by zero")
+		}
+		v = math.Mod(x, y)
+	case "^":
+		v = math.Pow(x, y)
+	}
+	return checked(v, n.op.column)
+}
+
+type call struct {
+	name token
+	fn   *function
+	args []node
+}
+
+func (n *call) eval() (float64, error) {
+	args := make([]float64, len(n.args))
+	for i, arg := range n.args {
+		v, err := arg.eval()
+		if err != nil {
+			return 0, err
+		}
+		args[i] = v
+	}
+
+	v, msg := n.fn.call(args)
+	if msg != "" {
+		return 0, errorf(n.name.column, "%s: %s", n.name.text, msg)
+	}
+	return checked(v, n.name.column)
+}
+
+// function is a function expressions can call.
+type function struct {
+	minArgs int
+	// maxArgs is 0 for functions that take any number of arguments
+	maxArgs int
+	// call returns the result, or a message saying why the arguments are
+	// outside the domain of the function
+	call func(args []float64) (float64, string)
+}
+
+// arity describes the number of arguments fn takes.
+func (fn *function) arity() string {
+	plural := func(n int) string {
+		if n == 1 {
+			return "1 argument"
+		}
+		return fmt.Sprintf("%d arguments", n)
+	}
+	switch {
+	case fn.maxArgs == 0:
+		return "at least " + plural(fn.minArgs)
+	case fn.minArgs == fn.maxArgs:
+		return plural(fn.minArgs)
+	}
+	return fmt.Sprintf("%d to %s", fn.minArgs, plural(fn.maxArgs))
+}
+
+var functions = map[string]*function{
+	"sqrt": {minArgs: 1, maxArgs: 1, call: func(args []float64) (float64, string) {
+		if args[0] < 0 {
+			return 0, "square root of a negative number"
+		}
+		return math.Sqrt(args[0]), ""
+	}},
+	"abs": {minArgs: 1, maxArgs: 1, call: func(args []float64) (float64, string) {
+		return math.Abs(args[0]), ""
+	}},
+	"min": {minArgs: 1, call: func(args []float64) (float64, string) {
+		v := args[0]
+		for _, a := range args[1:] {
+			v = math.Min(v, a)
+		}
+		return v, ""
+	}},
+	"max": {minArgs: 1, call: func(args []float64) (float64, string) {
+		v := args[0]
+		for _, a := range args[1:] {
+			v = math.Max(v, a)
+		}
+		return v, ""
+	}},
+	// log(x) is the natural logarithm, log(x, b) the logarithm in base b
+	"log": {minArgs: 1, maxArgs: 2, call: func(args []float64) (float64, string) {
+		if args[0] <= 0 {
+			return 0, "logarithm of a number that is not positive"
+		}
+		if len(args) == 1 {
+			return math.Log(args[0]), ""
+		}
+		if args[1] <= 0 || args[1] == 1 {
+			return 0, "logarithm base must be positive and not 1"
+		}
+		return math.Log(args[0]) / math.Log(args[1]), ""
+	}},
+}
@@ -0,0 +1,77 @@
+// Package expr parses and evaluates arithmetic expressions such as
+// "2 * (3 + 4) ^ 2 - sqrt(16)".
+//
+// Expressions are made of numbers, the binary operators + - * / % and ^,
+// unary minus and plus, parentheses and calls of the functions sqrt, abs,
+// min, max and log. ^ binds tightest and groups to the right, so -2^2 is -4
+// and 2^3^2 is 512. % is the remainder of a truncated division, as in Go.
+package expr
+
+import (
+	"fmt"
+	"math"
+)
+
+// Error is a syntax or evaluation error in an expression.
+type Error struct {
+	// Column is the 1-based position of the character the error is about,
+	// counted in characters rather than bytes.
+	Column int
+	Msg    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Column, e.Msg)
+}
+
+func errorf(column int, format string, args ...interface{}) *Error {
+	return &Error{Column: column, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Expr is a parsed expression.
+type Expr struct {
+	root node
+}
+
+// Parse parses src. The error is an *Error holding the column of the first
+// syntax error.
+func Parse(src string) (*Expr, error) {
+	p := &parser{lex: newLexer(src)}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	root, err := p.parseExpr(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, p.unexpected()
+	}
+	return &Expr{root: root}, nil
+}
+
+// Eval evaluates e. The error is an *Error for results that are not defined,
+// such as a division by zero, or too large for a float64.
+func (e *Expr) Eval() (float64, error) {
+	return e.root.eval()
+}
+
+// Evaluate parses and evaluates src.
+func Evaluate(src string) (float64, error) {
+	e, err := Parse(src)
+	if err != nil {
+		return 0, err
+	}
+	return e.Eval()
+}
+
+// checked returns v, or an error at column if v is not a finite number.
+func checked(v float64, column int) (float64, error) {
+	if math.IsNaN(v) {
+		return 0, errorf(column, "result is not a number")
+	}
+	if math.IsInf(v, 0) {
+		return 0, errorf(column, "result is out of range")
+	}
+	return v, nil
+}
@@ -0,0 +1,158 @@
+package expr
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		src  string
+		want float64
+	}{
+		// precedence
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"10 - 4 - 3", 3},
+		{"48 / 4 / 2", 6},
+		{"2 * 3 ^ 2", 18},
+		{"1 + 7 % 4 * 2", 7},
+		{"2 * (3 + 4) ^ 2 - sqrt(16)", 94},
+
+		// ^ groups to the right
+		{"2 ^ 3 ^ 2", 512},
+		{"(2 ^ 3) ^ 2", 64},
+		{"2 ^ -1", 0.5},
+
+		// unary minus and plus
+		{"-3", -3},
+		{"--3", 3},
+		{"+3", 3},
+		{"-2 ^ 2", -4},
+		{"(-2) ^ 2", 4},
+		{"2 * -3", -6},
+		{"1 - -1", 2},
+
+		// % truncates, as in Go
+		{"7 % 3", 1},
+		{"-7 % 3", -1},
+		{"7 % -3", 1},
+		{"5.5 % 2", 1.5},
+
+		// numbers
+		{"0.5", 0.5},
+		{".5", 0.5},
+		{"1.", 1},
+		{"1e3", 1000},
+		{"2.5E-1", 0.25},
+		{" \t1 +\n1 ", 2},
+
+		// functions
+		{"sqrt(16)", 4},
+		{"sqrt(0)", 0},
+		{"abs(-2.5)", 2.5},
+		{"abs(3)", 3},
+		{"min(3)", 3},
+		{"min(3, -1, 2)", -1},
+		{"max(3, -1, 2)", 3},
+		{"max(min(1, 2), abs(-5))", 5},
+		{"log(1)", 0},
+		{"log(8, 2)", 3},
+		{"log(100, 10)", 2},
+	}
+	for _, tt := range tests {
+		got, err := Evaluate(tt.src)
+		if err != nil {
+			t.Errorf("Evaluate(%q) failed: %v", tt.src, err)
+			continue
+		}
+		if math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("Evaluate(%q) = %v, want %v", tt.src, got, tt.want)
+		}
+	}
+
+	if got, _ := Evaluate("log(" + "2.718281828459045" + ")"); math.Abs(got-1) > 1e-12 {
+		t.Errorf("log(e) = %v, want 1", got)
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	tests := []struct {
+		src    string
+		column int
+		msg    string
+	}{
+		// syntax errors
+		{"", 1, "unexpected end of expression"},
+		{"(1", 3, "expected ) to close the ( at column 1"},
+		{"2 * (1 + 2", 11, "expected ) to close the ( at column 5"},
+		{"1+", 3, "unexpected end of expression"},
+		{"1 + * 2", 5, "unexpected *"},
+		{"2 3", 3, "unexpected 3"},
+		{"1)", 2, "unexpected )"},
+		{"min(1,)", 7, "unexpected )"},
+		{"min(,1)", 5, "unexpected ,"},
+		{"abs()", 5, "unexpected )"},
+		{"1 @ 2", 3, "unexpected character '@'"},
+		{"1 + ½", 5, "unexpected character '½'"},
+		{"1e", 3, "malformed exponent"},
+		{"1e999", 1, "number 1e999 is out of range"},
+		{"foo(1)", 1, `unknown function "foo"`},
+		{"2 * é", 5, `unknown function "é"`},
+		{"sqrt 4", 6, "expected ( after sqrt"},
+		{"sqrt(1, 2)", 1, "sqrt takes 1 argument, got 2"},
+		{"log(1, 2, 3)", 1, "log takes 1 to 2 arguments, got 3"},
+
+		// evaluation errors
+		{"1 / 0", 3, "division by zero"},
+		{"1 % 0", 3, "modulo by zero"},
+		{"sqrt(-1)", 1, "sqrt: square root of a negative number"},
+		{"log(0)", 1, "log: logarithm of a number that is not positive"},
+		{"log(8, 1)", 1, "log: logarithm base must be positive and not 1"},
+		{"10 ^ 400", 4, "result is out of range"},
+		{"(-8) ^ 0.5", 6, "result is not a number"},
+	}
+	for _, tt := range tests {
+		_, err := Evaluate(tt.src)
+		e, ok := err.(*Error)
+		if !ok {
+			t.Errorf("Evaluate(%q) error = %v, want an *Error", tt.src, err)
+			continue
+		}
+		if e.Column != tt.column || e.Msg != tt.msg {
+			t.Errorf("Evaluate(%q) error = %v, want column %d: %s", tt.src, err, tt.column, tt.msg)
+		}
+	}
+}
+
+func TestNestingLimit(t *testing.T) {
+	tests := []struct {
+		name   string
+		nested func(depth int) string
+	}{
+		{"parentheses", func(depth int) string {
+			return strings.Repeat("(", depth) + "1" + strings.Repeat(")", depth)
+		}},
+		{"unary minus", func(depth int) string {
+			return strings.Repeat("-", depth) + "1"
+		}},
+		{"calls", func(depth int) string {
+			return strings.Repeat("abs(", depth) + "1" + strings.Repeat(")", depth)
+		}},
+		{"powers", func(depth int) string {
+			return strings.Repeat("1^", depth) + "1"
+		}},
+	}
+	for _, tt := range tests {
+		if _, err := Parse(tt.nested(maxDepth - 1)); err != nil {
+			t.Errorf("%s nested %d deep: %v", tt.name, maxDepth-1, err)
+		}
+
+		_, err := Parse(tt.nested(maxDepth + 1))
+		e, ok := err.(*Error)
+		if !ok || e.Msg != "expression is nested too deeply" {
+			t.Errorf("%s nested %d deep: error = %v, want expression is nested too deeply", tt.name, maxDepth+1, err)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package expr
+
+import (
+	"strconv"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokIdent
+	tokOp
+	tokLParen
+	tokRParen
+	tokComma
+)
+
+type token struct {
+	kind tokenKind
+	// text is the source of the token, and the operator for tokOp
+	text   string
+	number float64
+	column int
+}
+
+// lexer splits an expression into tokens.
+type lexer struct {
+	src []rune
+	pos int
+}
+
+func newLexer(src string) *lexer {
+	return &lexer{src: []rune(src)}
+}
+
+// next returns the token at the current position and moves past it.
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.src) && unicode.IsSpace(l.src[l.pos]) {
+		l.pos++
+	}
+	start := l.pos
+	column := start + 1
+	if l.pos == len(l.src) {
+		return token{kind: tokEOF, column: column}, nil
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case isDigit(c) || c == '.':
+		return l.number()
+	case unicode.IsLetter(c):
+		for l.pos < len(l.src) && (unicode.IsLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.pos++
+		}
+		return token{kind: tokIdent, text: string(l.src[start:l.pos]), column: column}, nil
+	}
+
+	l.pos++
+	switch c {
+	case '+', '-', '*', '/', '%', '^':
+		return token{kind: tokOp, text: string(c), column: column}, nil
+	case '(':
+		return token{kind: tokLParen, text: "(", column: column}, nil
+	case ')':
+		return token{kind: tokRParen, text: ")", column: column}, nil
+	case ',':
+		return token{kind: tokComma, text: ",", column: column}, nil
+	}
+	return token{}, errorf(column, "unexpected character %q", c)
+}
+
+// number scans a decimal number with an optional fraction and exponent, such
+// as 12, 0.5, .5 or 1.5e-3.
+func (l *lexer) number() (token, error) {
+	start := l.pos
+	column := start + 1
+	digits := l.digits()
+	if l.pos < len(l.src) && l.src[l.pos] == '.' {
+		l.pos++
+		digits += l.digits()
+	}
+	if digits == 0 {
+		return token{}, errorf(column, "malformed number")
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		l.pos++
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.pos++
+		}
+		if l.digits() == 0 {
+			return token{}, errorf(l.pos+1, "malformed exponent")
+		}
+	}
+
+	text := string(l.src[start:l.pos])
+	v, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		// the syntax was checked above, so the number is out of range
+		return token{}, errorf(column, "number %s is out of range", text)
+	}
+	return token{kind: tokNumber, text: text, number: v, column: column}, nil
+}
+
+// digits moves past a run of digits and returns its length.
+func (l *lexer) digits() int {
+	start := l.pos
+	for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+		l.pos++
+	}
+	return l.pos - start
+}
+
+func isDigit(c rune) bool {
+	return c >= '0' && c <= '9'
+}
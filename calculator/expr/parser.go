@@ -0,0 +1,217 @@
+package expr
+
+// maxDepth limits the nesting of an expression, so deeply nested input
+// cannot exhaust the stack.
+const maxDepth = 200
+
+// parser is a recursive descent parser. The grammar, from the loosest binding
+// to the tightest, is:
+//
+//	expr    = term { ("+" | "-") term }
+//	term    = unary { ("*" | "/" | "%") unary }
+//	unary   = ("-" | "+") unary | power
+//	power   = primary [ "^" unary ]
+//	primary = number | name "(" expr { "," expr } ")" | "(" expr ")"
+type parser struct {
+	lex   *lexer
+	tok   token
+	depth int
+}
+
+func (p *parser) next() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+// precedence returns how tightly the binary operator op binds, or 0 if it is
+// not one that parseExpr handles.
+func precedence(op string) int {
+	switch op {
+	case "+", "-":
+		return 1
+	case "*", "/", "%":
+		return 2
+	}
+	return 0
+}
+
+// parseExpr parses a chain of binary operators that bind at least as tightly
+// as minPrec.
+func (p *parser) parseExpr(minPrec int) (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == tokOp {
+		op := p.tok
+		prec := precedence(op.text)
+		if prec == 0 || prec < minPrec {
+			break
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseExpr(prec + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &binary{op: op, x: left, y: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.tok.kind != tokOp || (p.tok.text != "-" && p.tok.text != "+") {
+		return p.parsePower()
+	}
+
+	op := p.tok
+	if err := p.enter(op); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	x, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	if op.text == "+" {
+		return x, nil
+	}
+	return &negate{x: x}, nil
+}
+
+func (p *parser) parsePower() (node, error) {
+	base, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokOp || p.tok.text != "^" {
+		return base, nil
+	}
+
+	op := p.tok
+	if err := p.enter(op); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	// the exponent is parsed as a unary, which makes ^ group to the right
+	exp, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return &binary{op: op, x: base, y: exp}, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokNumber:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		return &number{v: tok.number}, nil
+	case tokIdent:
+		return p.parseCall()
+	case tokLParen:
+		if err := p.enter(tok); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		x, err := p.parseExpr(0)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectClose(tok); err != nil {
+			return nil, err
+		}
+		return x, nil
+	}
+	return nil, p.unexpected()
+}
+
+func (p *parser) parseCall() (node, error) {
+	name := p.tok
+	fn, ok := functions[name.text]
+	if !ok {
+		return nil, errorf(name.column, "unknown function %q", name.text)
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	open := p.tok
+	if open.kind != tokLParen {
+		return nil, errorf(open.column, "expected ( after %s", name.text)
+	}
+	if err := p.enter(open); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	var args []node
+	for {
+		arg, err := p.parseExpr(0)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if p.tok.kind != tokComma {
+			break
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expectClose(open); err != nil {
+		return nil, err
+	}
+
+	if len(args) < fn.minArgs || (fn.maxArgs > 0 && len(args) > fn.maxArgs) {
+		return nil, errorf(name.column, "%s takes %s, got %d", name.text, fn.arity(), len(args))
+	}
+	return &call{name: name, fn: fn, args: args}, nil
+}
+
+// expectClose moves past the ) that closes open.
+func (p *parser) expectClose(open token) error {
+	if p.tok.kind != tokRParen {
+		return errorf(p.tok.column, "expected ) to close the ( at column %d", open.column)
+	}
+	return p.next()
+}
+
+// enter goes one level deeper into the expression at tok.
+func (p *parser) enter(tok token) error {
+	p.depth++
+	if p.depth > maxDepth {
+		return errorf(tok.column, "expression is nested too deeply")
+	}
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+// unexpected returns the error for a token that cannot appear where it is.
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokEOF {
+		return errorf(p.tok.column, "unexpected end of expression")
+	}
+	return errorf(p.tok.column, "unexpected %s", p.tok.text)
+}
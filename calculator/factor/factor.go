@@ -0,0 +1,232 @@
+// Package factor finds the prime factors of 64-bit integers.
+//
+// Small factors are found by trial division over a wheel that skips the
+// multiples of 2, 3 and 5. What remains is tested with a deterministic
+// Miller-Rabin test and split with Pollard's rho algorithm, so even a product
+// of two large primes is factored in well under a millisecond.
+package factor
+
+import (
+	"context"
+	"math/bits"
+	"sort"
+)
+
+// trialLimit is the largest divisor tried by trial division.
+const trialLimit = 1 << 14
+
+// checkEvery is how many steps run between checks for cancellation.
+const checkEvery = 1 << 10
+
+// wheel holds the gaps between the numbers from 7 up that are not multiples
+// of 2, 3 or 5. It repeats every 30.
+var wheel = [...]uint64{4, 2, 4, 2, 4, 6, 2, 6}
+
+// Factors calls fn with the prime factors of n in ascending order, each as
+// many times as it divides n. 0 and 1 have no prime factors.
+//
+// It returns ctx.Err() if ctx is done before every factor is found, and stops
+// at the first error fn returns.
+func Factors(ctx context.Context, n uint64, fn func(p uint64) error) error {
+	if n < 2 {
+		return nil
+	}
+
+	emit := func(p uint64) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return fn(p)
+	}
+
+	for _, p := range []uint64{2, 3, 5} {
+		for n%p == 0 {
+			if err := emit(p); err != nil {
+				return err
+			}
+			n /= p
+		}
+	}
+
+	p := uint64(7)
+	for i := 0; p <= trialLimit && p*p <= n; i++ {
+		if i%checkEvery == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		for n%p == 0 {
+			if err := emit(p); err != nil {
+				return err
+			}
+			n /= p
+		}
+		p += wheel[i%len(wheel)]
+	}
+	if n == 1 {
+		return nil
+	}
+	if p*p > n {
+		// no divisor up to the square root is left, so n is prime
+		return emit(n)
+	}
+
+	// the factors split off by rho come in no particular order
+	var large []uint64
+	if err := split(ctx, n, &large); err != nil {
+		return err
+	}
+	sort.Slice(large, func(i, j int) bool { return large[i] < large[j] })
+	for _, p := range large {
+		if err := emit(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// split appends the prime factors of n to factors. n has no factors up to
+// trialLimit.
+func split(ctx context.Context, n uint64, factors *[]uint64) error {
+	if n == 1 {
+		return nil
+	}
+	if IsPrime(n) {
+		*factors = append(*factors, n)
+		return nil
+	}
+	d, err := rho(ctx, n)
+	if err != nil {
+		return err
+	}
+	if err := split(ctx, d, factors); err != nil {
+		return err
+	}
+	return split(ctx, n/d, factors)
+}
+
+// rho returns a nontrivial divisor of n, which must be odd and composite. It
+// uses Brent's variant of Pollard's rho with the gcds batched.
+func rho(ctx context.Context, n uint64) (uint64, error) {
+	const batch = 128
+
+	steps := 0
+	for c := uint64(1); ; c++ {
+		f := func(y uint64) uint64 {
+			return addMod(mulMod(y, y, n), c, n)
+		}
+
+		y, q, g := uint64(2), uint64(1), uint64(1)
+		var x, ys uint64
+		for r := 1; g == 1; r *= 2 {
+			x = y
+			for i := 0; i < r; i++ {
+				y = f(y)
+			}
+			for k := 0; k < r && g == 1; k += batch {
+				ys = y
+				for i := 0; i < batch && i < r-k; i++ {
+					y = f(y)
+					q = mulMod(q, absDiff(x, y), n)
+				}
+				g = gcd(q, n)
+
+				steps += batch
+				if steps >= checkEvery {
+					steps = 0
+					if err := ctx.Err(); err != nil {
+						return 0, err
+					}
+				}
+			}
+		}
+
+		if g == n {
+			// the batch overshot, so step through it one at a time
+			for g = 1; g == 1; {
+				ys = f(ys)
+				g = gcd(absDiff(x, ys), n)
+			}
+		}
+		if g != n {
+			return g, nil
+		}
+		// the sequence cycled without finding a divisor, try another one
+	}
+}
+
+// IsPrime reports whether n is prime. The Miller-Rabin test it uses is exact
+// for every 64-bit n.
+func IsPrime(n uint64) bool {
+	if n < 2 {
+		return false
+	}
+	// these bases are witnesses for every composite below 2^64
+	bases := []uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37}
+	for _, p := range bases {
+		if n%p == 0 {
+			return n == p
+		}
+	}
+
+	d := n - 1
+	s := bits.TrailingZeros64(d)
+	d >>= uint(s)
+	for _, a := range bases {
+		x := powMod(a, d, n)
+		if x == 1 || x == n-1 {
+			continue
+		}
+		composite := true
+		for i := 1; i < s; i++ {
+			x = mulMod(x, x, n)
+			if x == n-1 {
+				composite = false
+				break
+			}
+		}
+		if composite {
+			return false
+		}
+	}
+	return true
+}
+
+func mulMod(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi, lo, m)
+}
+
+func addMod(a, b, m uint64) uint64 {
+	sum, carry := bits.Add64(a, b, 0)
+	if carry != 0 || sum >= m {
+		sum -= m
+	}
+	return sum
+}
+
+func powMod(a, e, m uint64) uint64 {
+	result := uint64(1)
+	a %= m
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			result = mulMod(result, a, m)
+		}
+		a = mulMod(a, a, m)
+	}
+	return result
+}
+
+func absDiff(a, b uint64) uint64 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+func gcd(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
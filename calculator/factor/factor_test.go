@@ -0,0 +1,190 @@
+package factor
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/bits"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// factors collects the prime factors of n.
+func factors(t testing.TB, n uint64) []uint64 {
+	t.Helper()
+	var got []uint64
+	err := Factors(context.Background(), n, func(p uint64) error {
+		got = append(got, p)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Factors(%d): %v", n, err)
+	}
+	return got
+}
+
+// checkFactors checks that got are primes in ascending order whose product
+// is n.
+func checkFactors(t *testing.T, n uint64, got []uint64) {
+	t.Helper()
+	product := uint64(1)
+	for i, p := range got {
+		if !IsPrime(p) {
+			t.Errorf("Factors(%d) emitted %d, which is not prime", n, p)
+		}
+		if i > 0 && p < got[i-1] {
+			t.Errorf("Factors(%d) = %v, not in ascending order", n, got)
+		}
+		hi, lo := bits.Mul64(product, p)
+		if hi != 0 {
+			t.Fatalf("Factors(%d) = %v, whose product overflows", n, got)
+		}
+		product = lo
+	}
+	if n >= 2 && product != n {
+		t.Errorf("Factors(%d) = %v, whose product is %d", n, got, product)
+	}
+}
+
+// nextPrime returns the smallest prime not less than n.
+func nextPrime(n uint64) uint64 {
+	for !IsPrime(n) {
+		n++
+	}
+	return n
+}
+
+func TestFactors(t *testing.T) {
+	tests := []struct {
+		n    uint64
+		want []uint64
+	}{
+		{0, nil},
+		{1, nil},
+		{2, []uint64{2}},
+		{12, []uint64{2, 2, 3}},
+		{120, []uint64{2, 2, 2, 3, 5}},
+		{561, []uint64{3, 11, 17}},
+		{49, []uint64{7, 7}},
+		{1 << 20, []uint64{2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2}},
+		{16411 * 16411, []uint64{16411, 16411}},
+		{math.MaxInt64, []uint64{7, 7, 73, 127, 337, 92737, 649657}},
+		{math.MaxUint64, []uint64{3, 5, 17, 257, 641, 65537, 6700417}},
+		{18446744073709551557, []uint64{18446744073709551557}},
+		{4294967291 * 4294967291, []uint64{4294967291, 4294967291}},
+		{4294967279 * 4294967291, []uint64{4294967279, 4294967291}},
+		{3 * 3 * 1000000007 * 1000000009, []uint64{3, 3, 1000000007, 1000000009}},
+	}
+	for _, tt := range tests {
+		if got := factors(t, tt.n); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Factors(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestFactorsRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		n := r.Uint64()
+		checkFactors(t, n, factors(t, n))
+	}
+	// products of two large primes are the hardest to split
+	for i := 0; i < 50; i++ {
+		p := nextPrime(r.Uint64()>>33 | 1<<30)
+		q := nextPrime(r.Uint64()>>33 | 1<<30)
+		checkFactors(t, p*q, factors(t, p*q))
+	}
+}
+
+func TestIsPrime(t *testing.T) {
+	const limit = 100000
+	composite := make([]bool, limit)
+	for i := 2; i < limit; i++ {
+		if composite[i] {
+			continue
+		}
+		for j := i * i; j < limit; j += i {
+			composite[j] = true
+		}
+	}
+	for n := 0; n < limit; n++ {
+		want := n >= 2 && !composite[n]
+		if got := IsPrime(uint64(n)); got != want {
+			t.Errorf("IsPrime(%d) = %v, want %v", n, got, want)
+		}
+	}
+
+	// strong pseudoprimes to several small bases
+	for _, n := range []uint64{3215031751, 341550071728321, 3825123056546413051} {
+		if IsPrime(n) {
+			t.Errorf("IsPrime(%d) = true, want false", n)
+		}
+	}
+}
+
+func TestFactorsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := Factors(ctx, 4294967279*4294967291, func(uint64) error { return nil })
+	if err != context.Canceled {
+		t.Errorf("Factors with a cancelled context = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestFactorsStopsOnError(t *testing.T) {
+	stop := errors.New("stop")
+	calls := 0
+	err := Factors(context.Background(), 120, func(uint64) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("Factors = %v after %d calls, want %v after 1", err, calls, stop)
+	}
+}
+
+// trialDivision is the loop PrimeNumberDecomposition used before Factors, for
+// comparison.
+func trialDivision(n uint64, fn func(p uint64)) {
+	divisor := uint64(2)
+	for n > 1 {
+		if n%divisor == 0 {
+			fn(divisor)
+			n /= divisor
+		} else {
+			divisor++
+		}
+	}
+}
+
+// benchmarks are the numbers both ways of factoring are timed on.
+var benchmarks = []struct {
+	name string
+	n    uint64
+}{
+	{"small", 2 * 3 * 5 * 7 * 11 * 13 * 97},
+	{"2^63-1", math.MaxInt64},
+	{"semiprime62", nextPrime(3<<29) * nextPrime(5<<29)},
+}
+
+func BenchmarkFactors(b *testing.B) {
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				factors(b, bm.n)
+			}
+		})
+	}
+}
+
+// BenchmarkTrialDivision takes seconds per factoring of the semiprime.
+func BenchmarkTrialDivision(b *testing.B) {
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				trialDivision(bm.n, func(uint64) {})
+			}
+		})
+	}
+}
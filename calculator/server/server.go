@@ -2,77 +2,214 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"io"
 	"log"
 	"math"
+	"math/big"
 	"net"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 
+	"calculator/bignum"
 	"calculator/calculatorpb"
+	"calculator/expr"
+	"calculator/factor"
+	"calculator/stats"
+	"calculator/window"
+	"lifecycle"
 )
 
-type server struct{}
+type server struct {
+	// factorBudget is the longest PrimeNumberDecomposition may compute for.
+	factorBudget time.Duration
+}
 
 func (*server) Sum(ctx context.Context, req *calculatorpb.SumRequest) (*calculatorpb.SumResponse, error) {
 	firstNumber := req.FirstNumber
 	secondNumber := req.SecondNumber
 
+	sum := int64(firstNumber) + int64(secondNumber)
+	if sum > math.MaxInt32 || sum < math.MinInt32 {
+		return nil, status.Errorf(
+			codes.OutOfRange,
+			fmt.Sprintf("sum of %v and %v does not fit in an int32, use BigArithmetic", firstNumber, secondNumber))
+	}
+
 	return &calculatorpb.SumResponse{
-		SumResult: firstNumber + secondNumber,
+		SumResult: int32(sum),
 	}, nil
 }
 
-func (*server) PrimeNumberDecomposition(req *calculatorpb.PrimeNumberDecompositionRequest, stream calculatorpb.CalculatorService_PrimeNumberDecompositionServer) error {
+func (s *server) PrimeNumberDecomposition(req *calculatorpb.PrimeNumberDecompositionRequest, stream calculatorpb.CalculatorService_PrimeNumberDecompositionServer) error {
 	fmt.Printf("received PrimeNumberDecomposition RPC: %v\n", req)
 
 	number := req.GetNumber()
-	divisor := int64(2)
+	if number < 1 {
+		return status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("received a number that is not positive: %v", number))
+	}
 
-	for number > 1 {
-		if number%divisor == 0 {
-			stream.Send(&calculatorpb.PrimeNumberDecompositionResponse{
-				PrimeFactor: divisor,
-			})
-			number = number / divisor
-		} else {
-			divisor++
-			fmt.Println("Divisor has incrated to: ", divisor)
-		}
+	ctx, cancel := context.WithTimeout(stream.Context(), s.factorBudget)
+	defer cancel()
+
+	err := factor.Factors(ctx, uint64(number), func(p uint64) error {
+		return stream.Send(&calculatorpb.PrimeNumberDecompositionResponse{
+			PrimeFactor: int64(p),
+		})
+	})
+	switch {
+	case err == nil:
+		return nil
+	case stream.Context().Err() != nil:
+		return status.Errorf(
+			codes.Canceled,
+			fmt.Sprintf("client went away: %v", stream.Context().Err()))
+	case ctx.Err() == context.DeadlineExceeded:
+		return status.Errorf(
+			codes.ResourceExhausted,
+			fmt.Sprintf("factoring %v took longer than %v", number, s.factorBudget))
 	}
-	return nil
+	return err
 }
 
 func (*server) ComputeAverage(stream calculatorpb.CalculatorService_ComputeAverageServer) error {
 	fmt.Println("Received ComputeAverage RPC")
 
-	sum := int32(0)
+	// an int64 cannot overflow with int32 numbers in any stream that ends
+	sum := int64(0)
 	count := 0
 
 	for {
 		req, err := stream.Recv()
 		if err == io.EOF {
+			if count == 0 {
+				return status.Errorf(
+					codes.InvalidArgument,
+					fmt.Sprintf("cannot compute the average of no numbers"))
+			}
 			average := float64(sum) / float64(count)
 			return stream.SendAndClose(&calculatorpb.ComputeAverageResponse{
 				Average: average,
 			})
 		}
 		if err != nil {
-			log.Fatalf("error while reading client stream: %v", err)
+			log.Printf("error while reading client stream: %v", err)
+			return err
 		}
-		sum += req.GetNumber()
+		sum += int64(req.GetNumber())
 		count++
 	}
 }
 
+func (*server) ComputeStatistics(stream calculatorpb.CalculatorService_ComputeStatisticsServer) error {
+	fmt.Println("Received ComputeStatistics RPC")
+
+	acc := stats.New()
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&calculatorpb.ComputeStatisticsResponse{
+				Statistics: statisticsToPb(acc.Summary()),
+			})
+		}
+		if err != nil {
+			log.Printf("error while reading client stream: %v", err)
+			return err
+		}
+		if err := addValue(acc, req.GetValue()); err != nil {
+			return err
+		}
+	}
+}
+
+func (*server) StreamStatistics(stream calculatorpb.CalculatorService_StreamStatisticsServer) error {
+	fmt.Println("Received StreamStatistics RPC")
+
+	acc := stats.New()
+	reportEvery := int64(0)
+	reported := int64(0)
+	send := func() error {
+		reported = acc.Count()
+		return stream.Send(&calculatorpb.StreamStatisticsResponse{
+			Statistics: statisticsToPb(acc.Summary()),
+		})
+	}
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			if acc.Count() > reported {
+				return send()
+			}
+			return nil
+		}
+		if err != nil {
+			log.Printf("error while reading client stream: %v", err)
+			return err
+		}
+
+		if acc.Count() == 0 {
+			if req.GetReportEvery() < 0 {
+				return status.Errorf(
+					codes.InvalidArgument,
+					fmt.Sprintf("received a negative report_every: %v", req.GetReportEvery()))
+			}
+			reportEvery = int64(req.GetReportEvery())
+			if reportEvery == 0 {
+				reportEvery = 1
+			}
+		}
+		if err := addValue(acc, req.GetValue()); err != nil {
+			return err
+		}
+		if acc.Count()%reportEvery == 0 {
+			if err := send(); err != nil {
+				log.Printf("error while sending data to client: %v", err)
+				return err
+			}
+		}
+	}
+}
+
+// addValue adds v to acc, unless it is not a finite number.
+func addValue(acc *stats.Accumulator, v float64) error {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("received a value that is not a finite number: %v", v))
+	}
+	acc.Add(v)
+	return nil
+}
+
+func statisticsToPb(s stats.Summary) *calculatorpb.Statistics {
+	return &calculatorpb.Statistics{
+		Count:    s.Count,
+		Sum:      s.Sum,
+		Min:      s.Min,
+		Max:      s.Max,
+		Mean:     s.Mean,
+		Variance: s.Variance,
+		Stddev:   s.StdDev,
+		P50:      s.P50,
+		P90:      s.P90,
+		P99:      s.P99,
+	}
+}
+
 func (*server) FindMaximum(stream calculatorpb.CalculatorService_FindMaximumServer) error {
 	fmt.Println("Received FindMaximum RPC")
 
 	maximum := int32(0)
+	first := true
 
 	for {
 		req, err := stream.Recv()
@@ -80,23 +217,143 @@ func (*server) FindMaximum(stream calculatorpb.CalculatorService_FindMaximumServ
 			return nil
 		}
 		if err != nil {
-			log.Fatalf("error while reading client stream: %v", err)
+			log.Printf("error while reading client stream: %v", err)
 			return err
 		}
 		number := req.GetNumber()
-		if number > maximum {
+		// the first number is the maximum so far, whatever its sign
+		if first || number > maximum {
+			first = false
 			maximum = number
 			er := stream.Send(&calculatorpb.FindMaximumResponse{
 				Maximum: maximum,
 			})
 			if er != nil {
-				log.Fatalf("error while sending data to client: %v", err)
+				log.Printf("error while sending data to client: %v", er)
 				return er
 			}
 		}
 	}
 }
 
+func (*server) WindowedAggregate(stream calculatorpb.CalculatorService_WindowedAggregateServer) error {
+	fmt.Println("Received WindowedAggregate RPC")
+
+	send := func(r window.Result) error {
+		res := &calculatorpb.WindowedAggregateResponse{
+			Result:       r.Value,
+			Count:        int64(r.Count),
+			LastPosition: r.Last,
+		}
+		if !r.End.IsZero() {
+			res.WindowStart, _ = ptypes.TimestampProto(r.Start)
+			res.WindowEnd, _ = ptypes.TimestampProto(r.End)
+		}
+		if err := stream.Send(res); err != nil {
+			log.Printf("error while sending data to client: %v", err)
+			return err
+		}
+		return nil
+	}
+
+	var w window.Window
+	timed := false
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			if w == nil {
+				return nil
+			}
+			return w.Flush(send)
+		}
+		if err != nil {
+			log.Printf("error while reading client stream: %v", err)
+			return err
+		}
+
+		if w == nil {
+			w, err = newWindow(req)
+			if err != nil {
+				return status.Errorf(
+					codes.InvalidArgument,
+					fmt.Sprintf("invalid window: %v", err))
+			}
+			timed = req.GetTimeWindow() != nil
+		}
+
+		value := req.GetValue()
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return status.Errorf(
+				codes.InvalidArgument,
+				fmt.Sprintf("received a value that is not a finite number: %v", value))
+		}
+		var t time.Time
+		if timed {
+			t, err = ptypes.Timestamp(req.GetTime())
+			if err != nil {
+				return status.Errorf(
+					codes.InvalidArgument,
+					fmt.Sprintf("invalid time: %v", err))
+			}
+		}
+
+		err = w.Add(value, t, send)
+		if err == window.ErrOutOfOrder || err == window.ErrTimeOutOfRange {
+			return status.Errorf(
+				codes.InvalidArgument,
+				fmt.Sprintf("received a value at %v: %v", t, err))
+		}
+		if err == window.ErrTooManyValues {
+			return status.Errorf(
+				codes.ResourceExhausted,
+				fmt.Sprintf("%v, use a shorter window", err))
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// aggregates maps the aggregates of WindowedAggregate to those of the window
+// package.
+var aggregates = map[calculatorpb.WindowedAggregateRequest_Aggregate]window.Aggregate{
+	calculatorpb.WindowedAggregateRequest_MIN:   window.Min,
+	calculatorpb.WindowedAggregateRequest_MAX:   window.Max,
+	calculatorpb.WindowedAggregateRequest_SUM:   window.Sum,
+	calculatorpb.WindowedAggregateRequest_AVG:   window.Avg,
+	calculatorpb.WindowedAggregateRequest_COUNT: window.Count,
+}
+
+// newWindow returns the window the first request of a WindowedAggregate call
+// asks for.
+func newWindow(req *calculatorpb.WindowedAggregateRequest) (window.Window, error) {
+	agg, ok := aggregates[req.GetAggregate()]
+	if !ok {
+		return nil, fmt.Errorf("unknown aggregate %v", req.GetAggregate())
+	}
+
+	switch {
+	case req.GetCountWindow() != nil:
+		cw := req.GetCountWindow()
+		return window.NewCount(agg, int(cw.GetSize()), int(cw.GetSlide()))
+	case req.GetTimeWindow() != nil:
+		tw := req.GetTimeWindow()
+		size, err := ptypes.Duration(tw.GetSize())
+		if err != nil {
+			return nil, fmt.Errorf("invalid size: %v", err)
+		}
+		slide := time.Duration(0)
+		if tw.GetSlide() != nil {
+			slide, err = ptypes.Duration(tw.GetSlide())
+			if err != nil {
+				return nil, fmt.Errorf("invalid slide: %v", err)
+			}
+		}
+		return window.NewTime(agg, size, slide)
+	}
+	return nil, fmt.Errorf("a count_window or time_window is required")
+}
+
 func (s *server) SquareRoot(ctx context.Context, req *calculatorpb.SquareRootRequest) (*calculatorpb.SquareRootResponse, error) {
 	fmt.Println("Received SquareRoot RPC")
 
@@ -111,7 +368,103 @@ func (s *server) SquareRoot(ctx context.Context, req *calculatorpb.SquareRootReq
 	}, nil
 }
 
+// maxExpressionLength is the longest expression Evaluate accepts.
+const maxExpressionLength = 4096
+
+func (*server) Evaluate(ctx context.Context, req *calculatorpb.EvaluateRequest) (*calculatorpb.EvaluateResponse, error) {
+	fmt.Println("Received Evaluate RPC")
+
+	expression := req.GetExpression()
+	if len(expression) > maxExpressionLength {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("expression is longer than %d bytes", maxExpressionLength))
+	}
+
+	result, err := expr.Evaluate(expression)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("cannot evaluate expression: %v", err))
+	}
+	return &calculatorpb.EvaluateResponse{
+		Result: result,
+	}, nil
+}
+
+const (
+	// defaultPrecision is the number of digits BigArithmetic keeps after the
+	// decimal point of a result that is not exact.
+	defaultPrecision = 50
+	maxPrecision     = 10000
+)
+
+func (*server) BigArithmetic(ctx context.Context, req *calculatorpb.BigArithmeticRequest) (*calculatorpb.BigArithmeticResponse, error) {
+	fmt.Println("Received BigArithmetic RPC")
+
+	precision := int(req.GetPrecision())
+	if precision < 0 || precision > maxPrecision {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("precision must be between 0 and %d", maxPrecision))
+	}
+	if precision == 0 {
+		precision = defaultPrecision
+	}
+
+	a, err := bignum.Parse(req.GetA().GetValue())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("invalid a: %v", err))
+	}
+	b, err := bignum.Parse(req.GetB().GetValue())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("invalid b: %v", err))
+	}
+
+	var result *big.Rat
+	switch req.GetOperation() {
+	case calculatorpb.BigArithmeticRequest_ADD:
+		result = new(big.Rat).Add(a, b)
+	case calculatorpb.BigArithmeticRequest_SUBTRACT:
+		result = new(big.Rat).Sub(a, b)
+	case calculatorpb.BigArithmeticRequest_MULTIPLY:
+		result = new(big.Rat).Mul(a, b)
+	case calculatorpb.BigArithmeticRequest_DIVIDE:
+		result, err = bignum.Quo(a, b)
+	case calculatorpb.BigArithmeticRequest_POWER:
+		result, err = bignum.Pow(a, b)
+	default:
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("unknown operation %v", req.GetOperation()))
+	}
+	if err == bignum.ErrTooLarge {
+		return nil, status.Errorf(
+			codes.OutOfRange,
+			fmt.Sprintf("cannot compute result: %v", err))
+	}
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("cannot compute result: %v", err))
+	}
+
+	value, exact := bignum.Format(result, precision)
+	return &calculatorpb.BigArithmeticResponse{
+		Result: &calculatorpb.Number{Value: value},
+		Exact:  exact,
+	}, nil
+}
+
 func main() {
+	factorBudget := flag.Duration("factor-budget", 5*time.Second, "longest a PrimeNumberDecomposition call may compute for")
+	drainTimeout := flag.Duration("drain-timeout", lifecycle.DefaultDrainTimeout, "how long in-flight rpcs get to finish when the server stops")
+	flag.Parse()
+
 	log.Print("Start Calculator Server....")
 
 	lis, err := net.Listen("tcp", "0.0.0.0:50051")
@@ -120,11 +473,11 @@ func main() {
 	}
 
 	s := grpc.NewServer()
-	calculatorpb.RegisterCalculatorServiceServer(s, &server{})
+	calculatorpb.RegisterCalculatorServiceServer(s, &server{factorBudget: *factorBudget})
 
 	reflection.Register(s)
 
-	if err := s.Serve(lis); err != nil {
+	if err := lifecycle.New(s, lis, *drainTimeout).Run(); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
 }
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"io"
+	"math"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"calculator/calculatorpb"
+)
+
+func TestSum(t *testing.T) {
+	tests := []struct {
+		a, b int32
+		want int32
+		code codes.Code
+	}{
+		{3, 10, 13, codes.OK},
+		{-3, 10, 7, codes.OK},
+		{math.MaxInt32, 0, math.MaxInt32, codes.OK},
+		{math.MaxInt32, math.MinInt32, -1, codes.OK},
+		{math.MinInt32, 0, math.MinInt32, codes.OK},
+		{math.MaxInt32, 1, 0, codes.OutOfRange},
+		{math.MaxInt32, math.MaxInt32, 0, codes.OutOfRange},
+		{math.MinInt32, -1, 0, codes.OutOfRange},
+	}
+	for _, tt := range tests {
+		res, err := (&server{}).Sum(context.Background(), &calculatorpb.SumRequest{FirstNumber: tt.a, SecondNumber: tt.b})
+		if code := status.Code(err); code != tt.code {
+			t.Errorf("Sum(%d, %d) failed with %v, want %v: %v", tt.a, tt.b, code, tt.code, err)
+			continue
+		}
+		if got := res.GetSumResult(); got != tt.want {
+			t.Errorf("Sum(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+// averageStream is a ComputeAverage stream that sends numbers to the server
+// and keeps its response.
+type averageStream struct {
+	grpc.ServerStream
+	numbers []int32
+	res     *calculatorpb.ComputeAverageResponse
+}
+
+func (s *averageStream) Recv() (*calculatorpb.ComputAverageRequest, error) {
+	if len(s.numbers) == 0 {
+		return nil, io.EOF
+	}
+	n := s.numbers[0]
+	s.numbers = s.numbers[1:]
+	return &calculatorpb.ComputAverageRequest{Number: n}, nil
+}
+
+func (s *averageStream) SendAndClose(res *calculatorpb.ComputeAverageResponse) error {
+	s.res = res
+	return nil
+}
+
+func TestComputeAverage(t *testing.T) {
+	tests := []struct {
+		numbers []int32
+		want    float64
+	}{
+		{[]int32{1, 2, 3, 4}, 2.5},
+		{[]int32{-7}, -7},
+		// the sum overflows an int32
+		{[]int32{math.MaxInt32, math.MaxInt32, math.MaxInt32}, math.MaxInt32},
+		{[]int32{math.MinInt32, math.MinInt32}, math.MinInt32},
+	}
+	for _, tt := range tests {
+		stream := &averageStream{numbers: tt.numbers}
+		if err := (&server{}).ComputeAverage(stream); err != nil {
+			t.Errorf("ComputeAverage(%v): %v", tt.numbers, err)
+			continue
+		}
+		if got := stream.res.GetAverage(); got != tt.want {
+			t.Errorf("ComputeAverage(%v) = %v, want %v", tt.numbers, got, tt.want)
+		}
+	}
+
+	// no numbers have no average
+	stream := &averageStream{}
+	err := (&server{}).ComputeAverage(stream)
+	if status.Code(err) != codes.InvalidArgument || stream.res != nil {
+		t.Errorf("ComputeAverage of no numbers = %v, %v, want %v", stream.res, err, codes.InvalidArgument)
+	}
+}
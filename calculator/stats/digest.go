@@ -0,0 +1,118 @@
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+// defaultCompression bounds the number of centroids of a digest to a small
+// multiple of it. Higher values give more accurate quantiles.
+const defaultCompression = 100
+
+// centroid stands for weight values whose mean is mean.
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+// digest is a merging t-digest, a sketch of a distribution that estimates
+// its quantiles. Values are buffered and merged into centroids in batches.
+// Centroids near the ends of the distribution are kept small, which makes
+// extreme quantiles such as p99 accurate.
+type digest struct {
+	compression float64
+	centroids   []centroid
+	buffer      []float64
+	// weight is the total weight of the centroids
+	weight float64
+}
+
+func newDigest(compression float64) *digest {
+	return &digest{
+		compression: compression,
+		buffer:      make([]float64, 0, 5*int(compression)),
+	}
+}
+
+func (d *digest) add(x float64) {
+	d.buffer = append(d.buffer, x)
+	if len(d.buffer) == cap(d.buffer) {
+		d.merge()
+	}
+}
+
+// scale is the k1 scale function of the t-digest paper. A centroid may only
+// cover the quantiles from q0 to q1 if scale(q1)-scale(q0) <= 1.
+func (d *digest) scale(q float64) float64 {
+	return d.compression / (2 * math.Pi) * math.Asin(2*q-1)
+}
+
+// merge merges the buffered values into the centroids.
+func (d *digest) merge() {
+	if len(d.buffer) == 0 {
+		return
+	}
+	all := make([]centroid, 0, len(d.centroids)+len(d.buffer))
+	all = append(all, d.centroids...)
+	for _, x := range d.buffer {
+		all = append(all, centroid{mean: x, weight: 1})
+	}
+	total := d.weight + float64(len(d.buffer))
+	d.buffer = d.buffer[:0]
+	sort.Slice(all, func(i, j int) bool { return all[i].mean < all[j].mean })
+
+	merged := all[:0]
+	cur := all[0]
+	before := 0.0
+	for _, c := range all[1:] {
+		if d.scale((before+cur.weight+c.weight)/total)-d.scale(before/total) <= 1 {
+			cur.weight += c.weight
+			cur.mean += (c.mean - cur.mean) * c.weight / cur.weight
+			continue
+		}
+		before += cur.weight
+		merged = append(merged, cur)
+		cur = c
+	}
+	merged = append(merged, cur)
+
+	d.centroids = merged
+	d.weight = total
+}
+
+// quantile estimates the q-quantile. min and max are the smallest and largest
+// values added, which bound the first and last centroids.
+func (d *digest) quantile(q, min, max float64) float64 {
+	d.merge()
+	cs := d.centroids
+	switch {
+	case len(cs) == 0:
+		return 0
+	case q <= 0:
+		return min
+	case q >= 1:
+		return max
+	case len(cs) == 1:
+		return cs[0].mean
+	}
+
+	// every centroid sits at the middle of the weight it covers, values in
+	// between are interpolated
+	index := q * d.weight
+	if index < cs[0].weight/2 {
+		return min + (cs[0].mean-min)*index/(cs[0].weight/2)
+	}
+	at := cs[0].weight / 2
+	for i := 0; i < len(cs)-1; i++ {
+		next := at + (cs[i].weight+cs[i+1].weight)/2
+		if index < next {
+			return cs[i].mean + (cs[i+1].mean-cs[i].mean)*(index-at)/(next-at)
+		}
+		at = next
+	}
+	last := cs[len(cs)-1]
+	if d.weight == at {
+		return last.mean
+	}
+	return last.mean + (max-last.mean)*(index-at)/(d.weight-at)
+}
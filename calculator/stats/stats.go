@@ -0,0 +1,101 @@
+// Package stats computes running statistics over a stream of values, such as
+// latency samples, in constant memory.
+package stats
+
+import "math"
+
+// Accumulator collects the statistics of the values added to it. The zero
+// value is not ready for use, create one with New.
+type Accumulator struct {
+	count int64
+	// sum and comp are a compensated sum, so adding many small values to a
+	// large total loses no precision
+	sum, comp float64
+	min, max  float64
+	// mean and m2 are the running mean and sum of squared differences from
+	// it, updated with Welford's algorithm
+	mean, m2 float64
+	digest   *digest
+}
+
+// New returns an empty Accumulator.
+func New() *Accumulator {
+	return &Accumulator{digest: newDigest(defaultCompression)}
+}
+
+// Add adds x, which must be a finite number.
+func (a *Accumulator) Add(x float64) {
+	a.count++
+	if a.count == 1 {
+		a.min, a.max = x, x
+	} else {
+		a.min = math.Min(a.min, x)
+		a.max = math.Max(a.max, x)
+	}
+
+	// Neumaier's variant of Kahan summation
+	t := a.sum + x
+	if math.Abs(a.sum) >= math.Abs(x) {
+		a.comp += (a.sum - t) + x
+	} else {
+		a.comp += (x - t) + a.sum
+	}
+	a.sum = t
+
+	delta := x - a.mean
+	a.mean += delta / float64(a.count)
+	a.m2 += delta * (x - a.mean)
+
+	a.digest.add(x)
+}
+
+// Count returns the number of values added.
+func (a *Accumulator) Count() int64 {
+	return a.count
+}
+
+// Quantile returns an estimate of the q-quantile of the values, for q between
+// 0 and 1. The estimate is most accurate near the ends, where the error is a
+// small fraction of a percent.
+func (a *Accumulator) Quantile(q float64) float64 {
+	if a.count == 0 {
+		return 0
+	}
+	return a.digest.quantile(q, a.min, a.max)
+}
+
+// Summary is a snapshot of the statistics of an Accumulator. Every field is 0
+// when no values were added.
+type Summary struct {
+	Count int64
+	Sum   float64
+	Min   float64
+	Max   float64
+	Mean  float64
+	// Variance is the population variance, and StdDev its square root.
+	Variance float64
+	StdDev   float64
+	P50      float64
+	P90      float64
+	P99      float64
+}
+
+// Summary returns the current statistics.
+func (a *Accumulator) Summary() Summary {
+	if a.count == 0 {
+		return Summary{}
+	}
+	variance := a.m2 / float64(a.count)
+	return Summary{
+		Count:    a.count,
+		Sum:      a.sum + a.comp,
+		Min:      a.min,
+		Max:      a.max,
+		Mean:     a.mean,
+		Variance: variance,
+		StdDev:   math.Sqrt(variance),
+		P50:      a.Quantile(0.5),
+		P90:      a.Quantile(0.9),
+		P99:      a.Quantile(0.99),
+	}
+}
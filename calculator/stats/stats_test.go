@@ -0,0 +1,205 @@
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// skewed returns n values of a log-normal distribution, whose long right tail
+// is what latency samples look like.
+func skewed(n int) []float64 {
+	r := rand.New(rand.NewSource(1))
+	xs := make([]float64, n)
+	for i := range xs {
+		xs[i] = math.Exp(1.5 * r.NormFloat64())
+	}
+	return xs
+}
+
+// closeTo reports whether got is within a relative error of want.
+func closeTo(got, want, relErr float64) bool {
+	return math.Abs(got-want) <= relErr*math.Abs(want)
+}
+
+func TestMeanAndVariance(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tests := []struct {
+		name string
+		xs   []float64
+	}{
+		{"small", []float64{2, 4, 4, 4, 5, 5, 7, 9}},
+		{"skewed", skewed(10000)},
+		// a large mean next to a small spread is where the textbook
+		// sum of squares formula loses most of its digits
+		{"offset", func() []float64 {
+			xs := make([]float64, 10000)
+			for i := range xs {
+				xs[i] = 1e6 + r.Float64()
+			}
+			return xs
+		}()},
+	}
+	for _, tt := range tests {
+		// two passes: the mean first, then the squared differences from it
+		mean := 0.0
+		for _, x := range tt.xs {
+			mean += x
+		}
+		mean /= float64(len(tt.xs))
+		variance := 0.0
+		for _, x := range tt.xs {
+			variance += (x - mean) * (x - mean)
+		}
+		variance /= float64(len(tt.xs))
+
+		a := New()
+		for _, x := range tt.xs {
+			a.Add(x)
+		}
+		s := a.Summary()
+		if s.Count != int64(len(tt.xs)) {
+			t.Errorf("%s: Count = %d, want %d", tt.name, s.Count, len(tt.xs))
+		}
+		if !closeTo(s.Mean, mean, 1e-12) {
+			t.Errorf("%s: Mean = %v, want %v", tt.name, s.Mean, mean)
+		}
+		if !closeTo(s.Variance, variance, 1e-9) {
+			t.Errorf("%s: Variance = %v, want %v", tt.name, s.Variance, variance)
+		}
+		if !closeTo(s.StdDev, math.Sqrt(variance), 1e-9) {
+			t.Errorf("%s: StdDev = %v, want %v", tt.name, s.StdDev, math.Sqrt(variance))
+		}
+	}
+
+	s := summarize([]float64{2, 4, 4, 4, 5, 5, 7, 9})
+	if s.Mean != 5 || s.Variance != 4 || s.StdDev != 2 {
+		t.Errorf("mean, variance and stddev = %v, %v, %v, want 5, 4, 2", s.Mean, s.Variance, s.StdDev)
+	}
+}
+
+// summarize adds xs to a new Accumulator and returns its Summary.
+func summarize(xs []float64) Summary {
+	a := New()
+	for _, x := range xs {
+		a.Add(x)
+	}
+	return a.Summary()
+}
+
+func TestCompensatedSum(t *testing.T) {
+	tests := []struct {
+		xs   []float64
+		want float64
+	}{
+		// 1 is lost when added to 1e16
+		{[]float64{1e16, 1, -1e16}, 1},
+		// Kahan summation gets this one wrong too
+		{[]float64{1, 1e100, 1, -1e100}, 2},
+		{[]float64{0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1}, 1},
+	}
+	for _, tt := range tests {
+		plain := 0.0
+		for _, x := range tt.xs {
+			plain += x
+		}
+		if plain == tt.want {
+			t.Fatalf("plain sum of %v is right, the test shows nothing", tt.xs)
+		}
+		if got := summarize(tt.xs).Sum; got != tt.want {
+			t.Errorf("Sum of %v = %v, want %v", tt.xs, got, tt.want)
+		}
+	}
+}
+
+// checkQuantiles checks that p50, p90 and p99 are close to the exact
+// quantiles of xs: the fraction of xs below each estimate is within maxErr of
+// the quantile it estimates.
+func checkQuantiles(t *testing.T, name string, s Summary, xs []float64) {
+	t.Helper()
+	sorted := append([]float64(nil), xs...)
+	sort.Float64s(sorted)
+	for _, tt := range []struct {
+		name   string
+		q      float64
+		got    float64
+		maxErr float64
+	}{
+		{"P50", 0.5, s.P50, 0.005},
+		{"P90", 0.9, s.P90, 0.005},
+		{"P99", 0.99, s.P99, 0.001},
+	} {
+		rank := float64(sort.SearchFloat64s(sorted, tt.got)) / float64(len(sorted))
+		if math.Abs(rank-tt.q) > tt.maxErr {
+			exact := sorted[int(tt.q*float64(len(sorted)))]
+			t.Errorf("%s: %s = %v, the %v quantile, want within %v of the %v quantile %v",
+				name, tt.name, tt.got, rank, tt.maxErr, tt.q, exact)
+		}
+	}
+}
+
+func TestQuantiles(t *testing.T) {
+	xs := skewed(100000)
+	s := summarize(xs)
+	checkQuantiles(t, "skewed", s, xs)
+
+	sort.Float64s(xs)
+	if s.Min != xs[0] || s.Max != xs[len(xs)-1] {
+		t.Errorf("Min, Max = %v, %v, want %v, %v", s.Min, s.Max, xs[0], xs[len(xs)-1])
+	}
+}
+
+// TestQuantilesMergedEveryValue reads the Summary after every value, as
+// StreamStatistics does when reporting every value, which merges a single
+// buffered value into the digest each time.
+func TestQuantilesMergedEveryValue(t *testing.T) {
+	xs := skewed(20000)
+	a := New()
+	var s Summary
+	for i, x := range xs {
+		a.Add(x)
+		s = a.Summary()
+		if s.Count != int64(i+1) {
+			t.Fatalf("Count = %d after %d values", s.Count, i+1)
+		}
+		if !(s.Min <= s.P50 && s.P50 <= s.P90 && s.P90 <= s.P99 && s.P99 <= s.Max) {
+			t.Fatalf("after %d values, min %v, p50 %v, p90 %v, p99 %v and max %v are out of order",
+				i+1, s.Min, s.P50, s.P90, s.P99, s.Max)
+		}
+	}
+	checkQuantiles(t, "merged every value", s, xs)
+
+	// merging one value at a time keeps the digest as small as merging in
+	// batches
+	if n := len(a.digest.centroids); n > defaultCompression {
+		t.Errorf("digest has %d centroids, want at most %d", n, defaultCompression)
+	}
+}
+
+func TestSummaryEmpty(t *testing.T) {
+	a := New()
+	if s := a.Summary(); s != (Summary{}) {
+		t.Errorf("Summary of no values = %+v, want all zero", s)
+	}
+	if q := a.Quantile(0.5); q != 0 {
+		t.Errorf("Quantile(0.5) of no values = %v, want 0", q)
+	}
+}
+
+func TestSummarySingleValue(t *testing.T) {
+	const x = -2.5
+	want := Summary{
+		Count: 1,
+		Sum:   x,
+		Min:   x,
+		Max:   x,
+		Mean:  x,
+		P50:   x,
+		P90:   x,
+		P99:   x,
+	}
+	if s := summarize([]float64{x}); s != want {
+		t.Errorf("Summary of %v = %+v, want %+v", x, s, want)
+	}
+}
@@ -0,0 +1,418 @@
+// Package window aggregates a stream of values over count-based or
+// time-based windows.
+//
+// A window of size N that slides by S holds N values, or the values of a
+// span N of time, and a new one closes every S values or S of time. Windows
+// overlap when S is less than N, and are tumbling, one after the other, when
+// S equals N. When S is greater than N, the values between windows are not
+// in any window.
+package window
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Aggregate is the function a window computes over its values.
+type Aggregate int
+
+const (
+	Min Aggregate = iota + 1
+	Max
+	Sum
+	Avg
+	Count
+)
+
+func (a Aggregate) String() string {
+	switch a {
+	case Min:
+		return "min"
+	case Max:
+		return "max"
+	case Sum:
+		return "sum"
+	case Avg:
+		return "avg"
+	case Count:
+		return "count"
+	}
+	return fmt.Sprintf("Aggregate(%d)", int(a))
+}
+
+const (
+	// MaxValues is the most values a window may hold at once.
+	MaxValues = 100000
+	// MaxCountSize is the most values a count window may hold.
+	MaxCountSize = MaxValues
+	// MaxOverlap is the most windows a value may be in, so a time window
+	// may be at most MaxOverlap times longer than its slide.
+	MaxOverlap = 10000
+	// MaxTimeSpan is the longest size or slide of a time window.
+	MaxTimeSpan = 100 * 365 * 24 * time.Hour
+)
+
+var (
+	// ErrOutOfOrder is returned when the values of a time window come with
+	// a time earlier than the value before them.
+	ErrOutOfOrder = errors.New("values must not go back in time")
+	// ErrTimeOutOfRange is returned when a value of a time window comes
+	// with a time the window cannot place, as time windows work in Unix
+	// nanoseconds.
+	ErrTimeOutOfRange = errors.New("time is out of the range of time windows, about the years 1678 to 2262")
+	// ErrTooManyValues is returned when a time window would hold more than
+	// MaxValues values.
+	ErrTooManyValues = fmt.Errorf("a time window must not hold more than %d values", MaxValues)
+)
+
+// Result is the aggregate of a closed window. Windows without values are
+// skipped.
+type Result struct {
+	Value float64
+	// Count is the number of values in the window.
+	Count int
+	// Last is the position, counting from 1, of the last value received
+	// before the window closed.
+	Last int64
+	// Start and End bound a time window, which holds the values with a time
+	// from Start up to but not including End. They are zero for count
+	// windows.
+	Start, End time.Time
+}
+
+// Window aggregates the values added to it over windows.
+type Window interface {
+	// Add adds a value and calls emit for every window it closes. t is the
+	// time of the value, count windows ignore it.
+	Add(v float64, t time.Time, emit func(Result) error) error
+	// Flush calls emit for the window that would close next, if it holds
+	// values that were not reported yet. Call it when the stream ends.
+	Flush(emit func(Result) error) error
+}
+
+func checkAggregate(agg Aggregate) error {
+	if agg < Min || agg > Count {
+		return fmt.Errorf("unknown aggregate %v", agg)
+	}
+	return nil
+}
+
+// countWindow is a window over the last size values.
+type countWindow struct {
+	agg         Aggregate
+	size, slide int64
+	values      *values
+	seen        int64
+	// nextClose is the value of seen at which the next window closes, and
+	// lastClose the one at which the last one closed
+	nextClose, lastClose int64
+}
+
+// NewCount returns a window over the last size values that slides by slide
+// values. A slide of 0 makes the windows tumbling.
+func NewCount(agg Aggregate, size, slide int) (Window, error) {
+	if err := checkAggregate(agg); err != nil {
+		return nil, err
+	}
+	if size < 1 || size > MaxCountSize {
+		return nil, fmt.Errorf("window size must be between 1 and %d values", MaxCountSize)
+	}
+	if slide < 0 {
+		return nil, errors.New("window slide must not be negative")
+	}
+	if slide == 0 {
+		slide = size
+	}
+	return &countWindow{
+		agg:       agg,
+		size:      int64(size),
+		slide:     int64(slide),
+		values:    newValues(),
+		nextClose: int64(size),
+	}, nil
+}
+
+func (w *countWindow) Add(v float64, t time.Time, emit func(Result) error) error {
+	w.seen++
+	w.values.push(v, w.seen)
+	w.values.evictBefore(w.seen - w.size + 1)
+	if w.seen < w.nextClose {
+		return nil
+	}
+	w.lastClose = w.seen
+	w.nextClose += w.slide
+	return emit(w.result())
+}
+
+func (w *countWindow) Flush(emit func(Result) error) error {
+	// keep what the next window would hold
+	w.values.evictBefore(w.nextClose - w.size + 1)
+	if w.values.len() == 0 || w.seen == w.lastClose {
+		return nil
+	}
+	w.lastClose = w.seen
+	return emit(w.result())
+}
+
+func (w *countWindow) result() Result {
+	return Result{
+		Value: w.values.aggregate(w.agg),
+		Count: w.values.len(),
+		Last:  w.seen,
+	}
+}
+
+// timeWindow is a window over a span of time. Windows end at multiples of the
+// slide since the Unix epoch.
+type timeWindow struct {
+	agg         Aggregate
+	size, slide int64
+	values      *values
+	seen        int64
+	started     bool
+	last        int64
+	// nextEnd is the end of the next window to close, in Unix nanoseconds
+	nextEnd int64
+}
+
+// NewTime returns a window over a span size of time that slides by slide. A
+// slide of 0 makes the windows tumbling. The values must be added in the
+// order of their times.
+func NewTime(agg Aggregate, size, slide time.Duration) (Window, error) {
+	if err := checkAggregate(agg); err != nil {
+		return nil, err
+	}
+	if size <= 0 || size > MaxTimeSpan {
+		return nil, fmt.Errorf("window size must be positive and at most %v", MaxTimeSpan)
+	}
+	if slide < 0 || slide > MaxTimeSpan {
+		return nil, fmt.Errorf("window slide must not be negative nor more than %v", MaxTimeSpan)
+	}
+	if slide == 0 {
+		slide = size
+	}
+	if size/slide > MaxOverlap {
+		return nil, fmt.Errorf("window size must be at most %d times its slide", MaxOverlap)
+	}
+	return &timeWindow{
+		agg:    agg,
+		size:   int64(size),
+		slide:  int64(slide),
+		values: newValues(),
+	}, nil
+}
+
+// minTime and maxTime bound the times whose Unix nanoseconds fit in an int64.
+var (
+	minTime = time.Unix(0, math.MinInt64)
+	maxTime = time.Unix(0, math.MaxInt64)
+)
+
+func (w *timeWindow) Add(v float64, t time.Time, emit func(Result) error) error {
+	if t.Before(minTime) || t.After(maxTime) {
+		return ErrTimeOutOfRange
+	}
+	at := t.UnixNano()
+	// the window ending after at, and the one before it, must be in range
+	// too, which size and slide being at most MaxTimeSpan keeps from
+	// overflowing here
+	if at > math.MaxInt64-w.slide || at < math.MinInt64+w.size+w.slide {
+		return ErrTimeOutOfRange
+	}
+	if w.started && at < w.last {
+		return ErrOutOfOrder
+	}
+	if !w.started {
+		w.started = true
+		w.nextEnd = w.endAfter(at)
+	}
+	w.last = at
+
+	// the windows that end by the time of v hold every value so far
+	for w.nextEnd <= at {
+		w.values.evictBefore(w.nextEnd - w.size)
+		if w.values.len() == 0 {
+			// skip the empty windows up to v
+			w.nextEnd = w.endAfter(at)
+			break
+		}
+		if err := w.close(emit); err != nil {
+			return err
+		}
+	}
+
+	w.seen++
+	// a value in the gap before the next window is in no window
+	if at < w.nextEnd-w.size {
+		return nil
+	}
+	if w.values.len() >= MaxValues {
+		return ErrTooManyValues
+	}
+	w.values.push(v, at)
+	return nil
+}
+
+func (w *timeWindow) Flush(emit func(Result) error) error {
+	// the windows after the last value have no new values, so only the
+	// next one is reported
+	w.values.evictBefore(w.nextEnd - w.size)
+	if w.values.len() == 0 {
+		return nil
+	}
+	err := w.close(emit)
+	w.values = newValues()
+	return err
+}
+
+// close emits the window ending at nextEnd and moves on to the next one.
+func (w *timeWindow) close(emit func(Result) error) error {
+	r := Result{
+		Value: w.values.aggregate(w.agg),
+		Count: w.values.len(),
+		Last:  w.seen,
+		Start: time.Unix(0, w.nextEnd-w.size).UTC(),
+		End:   time.Unix(0, w.nextEnd).UTC(),
+	}
+	w.nextEnd += w.slide
+	return emit(r)
+}
+
+// endAfter returns the end of the first window that ends after at.
+func (w *timeWindow) endAfter(at int64) int64 {
+	end := at - at%w.slide
+	if at%w.slide < 0 {
+		end -= w.slide
+	}
+	return end + w.slide
+}
+
+// entry is a value in a window. key orders the entries: it is the position of
+// the value in count windows and its time in time windows. seq tells apart
+// entries with the same key.
+type entry struct {
+	v   float64
+	key int64
+	seq int64
+}
+
+// values holds the values of a window, oldest first, and keeps the running
+// aggregates of them.
+type values struct {
+	entries deque
+	// mins and maxs hold the entries that can still become the minimum or
+	// maximum once older entries are evicted
+	mins, maxs deque
+	sum        float64
+	seq        int64
+	// evicted counts the evictions since sum was last recomputed
+	evicted int
+}
+
+func newValues() *values {
+	return &values{}
+}
+
+func (vs *values) len() int {
+	return vs.entries.len()
+}
+
+func (vs *values) push(v float64, key int64) {
+	vs.seq++
+	e := entry{v: v, key: key, seq: vs.seq}
+	vs.entries.pushBack(e)
+	vs.sum += v
+	for vs.mins.len() > 0 && vs.mins.back().v >= v {
+		vs.mins.popBack()
+	}
+	vs.mins.pushBack(e)
+	for vs.maxs.len() > 0 && vs.maxs.back().v <= v {
+		vs.maxs.popBack()
+	}
+	vs.maxs.pushBack(e)
+}
+
+// evictBefore removes the entries with a key less than key.
+func (vs *values) evictBefore(key int64) {
+	for vs.entries.len() > 0 && vs.entries.front().key < key {
+		e := vs.entries.popFront()
+		vs.sum -= e.v
+		vs.evicted++
+		if vs.mins.len() > 0 && vs.mins.front().seq == e.seq {
+			vs.mins.popFront()
+		}
+		if vs.maxs.len() > 0 && vs.maxs.front().seq == e.seq {
+			vs.maxs.popFront()
+		}
+	}
+
+	// subtracting evicted values loses precision over time, so the sum is
+	// recomputed once as many values were evicted as the window holds
+	if vs.evicted > vs.entries.len() {
+		vs.sum = 0
+		for i := 0; i < vs.entries.len(); i++ {
+			vs.sum += vs.entries.at(i).v
+		}
+		vs.evicted = 0
+	}
+}
+
+func (vs *values) aggregate(agg Aggregate) float64 {
+	switch agg {
+	case Min:
+		return vs.mins.front().v
+	case Max:
+		return vs.maxs.front().v
+	case Sum:
+		return vs.sum
+	case Avg:
+		return vs.sum / float64(vs.len())
+	case Count:
+		return float64(vs.len())
+	}
+	return math.NaN()
+}
+
+// deque is a double-ended queue of entries.
+type deque struct {
+	items []entry
+	head  int
+}
+
+func (d *deque) len() int {
+	return len(d.items) - d.head
+}
+
+func (d *deque) at(i int) entry {
+	return d.items[d.head+i]
+}
+
+func (d *deque) front() entry {
+	return d.items[d.head]
+}
+
+func (d *deque) back() entry {
+	return d.items[len(d.items)-1]
+}
+
+func (d *deque) pushBack(e entry) {
+	// reuse the space in front once it is most of the slice
+	if d.head > 0 && d.head >= len(d.items)/2 {
+		n := copy(d.items, d.items[d.head:])
+		d.items = d.items[:n]
+		d.head = 0
+	}
+	d.items = append(d.items, e)
+}
+
+func (d *deque) popFront() entry {
+	e := d.items[d.head]
+	d.head++
+	return e
+}
+
+func (d *deque) popBack() {
+	d.items = d.items[:len(d.items)-1]
+}
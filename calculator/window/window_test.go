@@ -0,0 +1,467 @@
+package window
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var epoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// at returns the time d after epoch.
+func at(d time.Duration) time.Time {
+	return epoch.Add(d)
+}
+
+// run adds values to w, at the given times if any, flushes it and returns the
+// results it emitted.
+func run(t *testing.T, w Window, values []float64, times []time.Time) []Result {
+	t.Helper()
+	var got []Result
+	emit := func(r Result) error {
+		got = append(got, r)
+		return nil
+	}
+	for i, v := range values {
+		var vt time.Time
+		if times != nil {
+			vt = times[i]
+		}
+		if err := w.Add(v, vt, emit); err != nil {
+			t.Fatalf("Add(%v, %v): %v", v, vt, err)
+		}
+	}
+	if err := w.Flush(emit); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	return got
+}
+
+func TestCountWindow(t *testing.T) {
+	tests := []struct {
+		name        string
+		agg         Aggregate
+		size, slide int
+		values      []float64
+		want        []Result
+	}{
+		{
+			name: "tumbling", agg: Sum, size: 3, slide: 3,
+			values: []float64{1, 2, 3, 4, 5, 6, 7},
+			want: []Result{
+				{Value: 6, Count: 3, Last: 3},
+				{Value: 15, Count: 3, Last: 6},
+				// flushed
+				{Value: 7, Count: 1, Last: 7},
+			},
+		},
+		{
+			name: "tumbling by default", agg: Count, size: 2, slide: 0,
+			values: []float64{1, 2, 3, 4},
+			want: []Result{
+				{Value: 2, Count: 2, Last: 2},
+				{Value: 2, Count: 2, Last: 4},
+			},
+		},
+		{
+			name: "sliding", agg: Max, size: 3, slide: 1,
+			values: []float64{5, 1, 2, 0, 4},
+			want: []Result{
+				{Value: 5, Count: 3, Last: 3},
+				{Value: 2, Count: 3, Last: 4},
+				{Value: 4, Count: 3, Last: 5},
+			},
+		},
+		{
+			name: "sliding by two", agg: Avg, size: 4, slide: 2,
+			values: []float64{1, 2, 3, 4, 5, 6, 7},
+			want: []Result{
+				{Value: 2.5, Count: 4, Last: 4},
+				{Value: 4.5, Count: 4, Last: 6},
+				// flushed, the window that would close at 8 holds 5 to 7
+				{Value: 6, Count: 3, Last: 7},
+			},
+		},
+		{
+			name: "gapped", agg: Sum, size: 2, slide: 4,
+			values: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			want: []Result{
+				{Value: 3, Count: 2, Last: 2},
+				// 3 and 4 are in no window
+				{Value: 11, Count: 2, Last: 6},
+				// flushed, the window that would close at 10 holds 9
+				{Value: 9, Count: 1, Last: 9},
+			},
+		},
+		{
+			name: "gapped flush in the gap", agg: Sum, size: 2, slide: 4,
+			values: []float64{1, 2, 3},
+			want: []Result{
+				{Value: 3, Count: 2, Last: 2},
+			},
+		},
+		{
+			name: "all negative max", agg: Max, size: 2, slide: 1,
+			values: []float64{-3, -1, -2, -5},
+			want: []Result{
+				{Value: -1, Count: 2, Last: 2},
+				{Value: -1, Count: 2, Last: 3},
+				{Value: -2, Count: 2, Last: 4},
+			},
+		},
+		{
+			name: "all negative min", agg: Min, size: 3, slide: 3,
+			values: []float64{-3, -1, -2, -5},
+			want: []Result{
+				{Value: -3, Count: 3, Last: 3},
+				{Value: -5, Count: 1, Last: 4},
+			},
+		},
+		{
+			name: "flush right after a close", agg: Sum, size: 2, slide: 2,
+			values: []float64{1, 2},
+			want: []Result{
+				{Value: 3, Count: 2, Last: 2},
+			},
+		},
+		{
+			name: "flush before the first close", agg: Count, size: 5, slide: 1,
+			values: []float64{1, 2},
+			want: []Result{
+				{Value: 2, Count: 2, Last: 2},
+			},
+		},
+		{
+			name: "empty", agg: Sum, size: 2, slide: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := NewCount(tt.agg, tt.size, tt.slide)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := run(t, w, tt.values, nil); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %v\nwant %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeWindow(t *testing.T) {
+	tests := []struct {
+		name        string
+		agg         Aggregate
+		size, slide time.Duration
+		times       []time.Duration
+		values      []float64
+		want        []Result
+	}{
+		{
+			name: "tumbling", agg: Sum, size: 10 * time.Second, slide: 10 * time.Second,
+			times:  []time.Duration{1 * time.Second, 5 * time.Second, 12 * time.Second, 35 * time.Second},
+			values: []float64{1, 2, 3, 4},
+			want: []Result{
+				{Value: 3, Count: 2, Last: 2, Start: at(0), End: at(10 * time.Second)},
+				// the empty window from 20s to 30s is skipped
+				{Value: 3, Count: 1, Last: 3, Start: at(10 * time.Second), End: at(20 * time.Second)},
+				// flushed
+				{Value: 4, Count: 1, Last: 4, Start: at(30 * time.Second), End: at(40 * time.Second)},
+			},
+		},
+		{
+			name: "tumbling by default", agg: Count, size: time.Minute,
+			times:  []time.Duration{0, 59 * time.Second, time.Minute},
+			values: []float64{1, 1, 1},
+			want: []Result{
+				{Value: 2, Count: 2, Last: 2, Start: at(0), End: at(time.Minute)},
+				{Value: 1, Count: 1, Last: 3, Start: at(time.Minute), End: at(2 * time.Minute)},
+			},
+		},
+		{
+			name: "sliding", agg: Avg, size: 10 * time.Second, slide: 5 * time.Second,
+			times:  []time.Duration{1 * time.Second, 6 * time.Second, 11 * time.Second},
+			values: []float64{2, 4, 6},
+			want: []Result{
+				{Value: 2, Count: 1, Last: 1, Start: at(-5 * time.Second), End: at(5 * time.Second)},
+				{Value: 3, Count: 2, Last: 2, Start: at(0), End: at(10 * time.Second)},
+				{Value: 5, Count: 2, Last: 3, Start: at(5 * time.Second), End: at(15 * time.Second)},
+			},
+		},
+		{
+			name: "gapped", agg: Sum, size: time.Second, slide: 3 * time.Second,
+			times:  []time.Duration{0, 1500 * time.Millisecond, 2 * time.Second, 2500 * time.Millisecond, 4 * time.Second, 5500 * time.Millisecond},
+			values: []float64{1, 2, 4, 8, 16, 32},
+			want: []Result{
+				// 1 and 2 are in no window
+				{Value: 12, Count: 2, Last: 4, Start: at(2 * time.Second), End: at(3 * time.Second)},
+				// flushed, 16 is in no window
+				{Value: 32, Count: 1, Last: 6, Start: at(5 * time.Second), End: at(6 * time.Second)},
+			},
+		},
+		{
+			name: "all negative max", agg: Max, size: 2 * time.Second, slide: time.Second,
+			times:  []time.Duration{0, time.Second, 2 * time.Second},
+			values: []float64{-4, -2, -3},
+			want: []Result{
+				{Value: -4, Count: 1, Last: 1, Start: at(-time.Second), End: at(time.Second)},
+				{Value: -2, Count: 2, Last: 2, Start: at(0), End: at(2 * time.Second)},
+				{Value: -2, Count: 2, Last: 3, Start: at(time.Second), End: at(3 * time.Second)},
+			},
+		},
+		{
+			name: "values at the same time", agg: Min, size: time.Second, slide: time.Second,
+			times:  []time.Duration{0, 0, 0},
+			values: []float64{3, 1, 2},
+			want: []Result{
+				{Value: 1, Count: 3, Last: 3, Start: at(0), End: at(time.Second)},
+			},
+		},
+		{
+			name: "empty", agg: Sum, size: time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := NewTime(tt.agg, tt.size, tt.slide)
+			if err != nil {
+				t.Fatal(err)
+			}
+			times := make([]time.Time, len(tt.times))
+			for i, d := range tt.times {
+				times[i] = at(d)
+			}
+			if got := run(t, w, tt.values, times); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %v\nwant %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// aggregate computes agg over values directly.
+func aggregate(agg Aggregate, values []float64) float64 {
+	r := values[0]
+	sum := 0.0
+	for _, v := range values {
+		switch agg {
+		case Min:
+			r = math.Min(r, v)
+		case Max:
+			r = math.Max(r, v)
+		}
+		sum += v
+	}
+	switch agg {
+	case Sum:
+		return sum
+	case Avg:
+		return sum / float64(len(values))
+	case Count:
+		return float64(len(values))
+	}
+	return r
+}
+
+// sameResults reports whether got and want hold the same windows, with values
+// equal up to rounding.
+func sameResults(got, want []Result) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		g, w := got[i], want[i]
+		if math.Abs(g.Value-w.Value) > 1e-9*math.Max(1, math.Abs(w.Value)) {
+			return false
+		}
+		g.Value, w.Value = 0, 0
+		if g != w {
+			return false
+		}
+	}
+	return true
+}
+
+// countReference returns the results of a count window computed window by
+// window.
+func countReference(agg Aggregate, size, slide int, values []float64) []Result {
+	var want []Result
+	window := func(end, last int) {
+		start := end - size
+		if start < 0 {
+			start = 0
+		}
+		if last < end {
+			end = last
+		}
+		if start < end {
+			want = append(want, Result{Value: aggregate(agg, values[start:end]), Count: end - start, Last: int64(last)})
+		}
+	}
+	end, lastClose := size, 0
+	for ; end <= len(values); end += slide {
+		window(end, end)
+		lastClose = end
+	}
+	if lastClose != len(values) {
+		window(end, len(values))
+	}
+	return want
+}
+
+// timeReference returns the results of a time window computed window by
+// window. times are in nanoseconds and ascending.
+func timeReference(agg Aggregate, size, slide int64, values []float64, times []int64) []Result {
+	if len(values) == 0 {
+		return nil
+	}
+	endAfter := func(t int64) int64 {
+		return (t/slide + 1) * slide
+	}
+	var want []Result
+	for end := endAfter(times[0]); end <= endAfter(times[len(times)-1]); end += slide {
+		var in []float64
+		last := 0
+		for i, t := range times {
+			if t < end {
+				last = i + 1
+			}
+			if t >= end-size && t < end {
+				in = append(in, values[i])
+			}
+		}
+		if len(in) > 0 {
+			want = append(want, Result{
+				Value: aggregate(agg, in),
+				Count: len(in),
+				Last:  int64(last),
+				Start: time.Unix(0, end-size).UTC(),
+				End:   time.Unix(0, end).UTC(),
+			})
+		}
+	}
+	return want
+}
+
+func TestCountWindowRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		agg := Aggregate(1 + r.Intn(5))
+		size, slide := 1+r.Intn(10), 1+r.Intn(10)
+		values := make([]float64, r.Intn(60))
+		for j := range values {
+			values[j] = float64(r.Intn(200) - 150)
+		}
+
+		w, err := NewCount(agg, size, slide)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := run(t, w, values, nil)
+		if want := countReference(agg, size, slide, values); !sameResults(got, want) {
+			t.Fatalf("%v over %d values sliding by %d of %v:\ngot  %v\nwant %v", agg, size, slide, values, got, want)
+		}
+	}
+}
+
+func TestTimeWindowRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		agg := Aggregate(1 + r.Intn(5))
+		size, slide := int64(1+r.Intn(10)), int64(1+r.Intn(10))
+		values := make([]float64, r.Intn(60))
+		times := make([]int64, len(values))
+		stamps := make([]time.Time, len(values))
+		now := int64(r.Intn(20))
+		for j := range values {
+			values[j] = float64(r.Intn(200) - 150)
+			now += int64(r.Intn(4))
+			times[j] = now
+			stamps[j] = time.Unix(0, now)
+		}
+
+		w, err := NewTime(agg, time.Duration(size), time.Duration(slide))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := run(t, w, values, stamps)
+		if want := timeReference(agg, size, slide, values, times); !sameResults(got, want) {
+			t.Fatalf("%v over %dns sliding by %dns of %v at %v:\ngot  %v\nwant %v", agg, size, slide, values, times, got, want)
+		}
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	counts := []struct {
+		agg         Aggregate
+		size, slide int
+	}{
+		{0, 1, 1},
+		{Count + 1, 1, 1},
+		{Sum, 0, 1},
+		{Sum, MaxCountSize + 1, 1},
+		{Sum, 1, -1},
+	}
+	for _, tt := range counts {
+		if _, err := NewCount(tt.agg, tt.size, tt.slide); err == nil {
+			t.Errorf("NewCount(%v, %d, %d) succeeded, want an error", tt.agg, tt.size, tt.slide)
+		}
+	}
+
+	times := []struct {
+		agg         Aggregate
+		size, slide time.Duration
+	}{
+		{0, time.Second, time.Second},
+		{Sum, 0, time.Second},
+		{Sum, MaxTimeSpan + 1, 0},
+		{Sum, time.Second, -1},
+		{Sum, time.Second, MaxTimeSpan + 1},
+		{Sum, (MaxOverlap + 1) * time.Second, time.Second},
+	}
+	for _, tt := range times {
+		if _, err := NewTime(tt.agg, tt.size, tt.slide); err == nil {
+			t.Errorf("NewTime(%v, %v, %v) succeeded, want an error", tt.agg, tt.size, tt.slide)
+		}
+	}
+}
+
+func TestTimeWindowErrors(t *testing.T) {
+	emit := func(Result) error { return nil }
+	newWindow := func() Window {
+		w, err := NewTime(Sum, time.Second, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return w
+	}
+
+	w := newWindow()
+	if err := w.Add(1, at(time.Second), emit); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Add(1, at(0), emit); err != ErrOutOfOrder {
+		t.Errorf("Add back in time = %v, want %v", err, ErrOutOfOrder)
+	}
+
+	for _, vt := range []time.Time{
+		time.Date(1600, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Unix(0, math.MaxInt64),
+		time.Unix(0, math.MinInt64),
+	} {
+		if err := newWindow().Add(1, vt, emit); err != ErrTimeOutOfRange {
+			t.Errorf("Add at %v = %v, want %v", vt, err, ErrTimeOutOfRange)
+		}
+	}
+
+	w = newWindow()
+	var err error
+	for i := 0; i <= MaxValues && err == nil; i++ {
+		err = w.Add(1, at(time.Duration(i)), emit)
+	}
+	if err != ErrTooManyValues {
+		t.Errorf("Add of %d values in one window = %v, want %v", MaxValues+1, err, ErrTooManyValues)
+	}
+}
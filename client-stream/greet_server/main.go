@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"io"
 	"log"
@@ -9,6 +10,7 @@ import (
 	grpc "google.golang.org/grpc"
 
 	"client-stream/greetpb"
+	"lifecycle"
 )
 
 type server struct{}
@@ -34,6 +36,9 @@ func (*server) LongGreet(stream greetpb.GreetService_LongGreetServer) error {
 }
 
 func main() {
+	drainTimeout := flag.Duration("drain-timeout", lifecycle.DefaultDrainTimeout, "how long in-flight rpcs get to finish when the server stops")
+	flag.Parse()
+
 	fmt.Println("hello")
 
 	lis, err := net.Listen("tcp", "0.0.0.0:50051")
@@ -44,7 +49,7 @@ func main() {
 	s := grpc.NewServer()
 	greetpb.RegisterGreetServiceServer(s, &server{})
 
-	if err := s.Serve(lis); err != nil {
+	if err := lifecycle.New(s, lis, *drainTimeout).Run(); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"net"
@@ -8,11 +9,15 @@ import (
 	grpc "google.golang.org/grpc"
 
 	"greet/greetpb"
+	"lifecycle"
 )
 
 type server struct{}
 
 func main() {
+	drainTimeout := flag.Duration("drain-timeout", lifecycle.DefaultDrainTimeout, "how long in-flight rpcs get to finish when the server stops")
+	flag.Parse()
+
 	fmt.Println("hello")
 
 	lis, err := net.Listen("tcp", "0.0.0.0:50051")
@@ -23,7 +28,7 @@ func main() {
 	s := grpc.NewServer()
 	greetpb.RegisterGreetServiceServer(s, &server{})
 
-	if err := s.Serve(lis); err != nil {
+	if err := lifecycle.New(s, lis, *drainTimeout).Run(); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
 }
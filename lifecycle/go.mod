@@ -0,0 +1,5 @@
+module lifecycle
+
+go 1.14
+
+require google.golang.org/grpc v1.28.1
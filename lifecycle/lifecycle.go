@@ -0,0 +1,147 @@
+// Package lifecycle runs a grpc server until the process is asked to stop, and
+// then shuts it down without cutting off the rpcs it is serving.
+package lifecycle
+
+import (
+	"context"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// DefaultDrainTimeout is how long servers usually give in-flight rpcs to
+// finish when they stop.
+const DefaultDrainTimeout = 10 * time.Second
+
+// Server serves a grpc server on a listener and shuts it down gracefully.
+type Server struct {
+	grpc         *grpc.Server
+	lis          net.Listener
+	drainTimeout time.Duration
+	onShutdown   []func()
+	hooks        []hook
+}
+
+// hook closes a resource the server used, such as a database connection.
+type hook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// New returns a Server that serves s on lis. When it stops, in-flight rpcs get
+// drainTimeout to finish before they are cancelled.
+func New(s *grpc.Server, lis net.Listener, drainTimeout time.Duration) *Server {
+	return &Server{grpc: s, lis: lis, drainTimeout: drainTimeout}
+}
+
+// OnShutdown registers fn to run as soon as the server starts shutting down,
+// before it waits for the in-flight rpcs. It is meant to end the rpcs that
+// never finish on their own, such as streams that watch for changes, so they
+// do not hold up the shutdown for the whole drain.
+func (s *Server) OnShutdown(fn func()) {
+	s.onShutdown = append(s.onShutdown, fn)
+}
+
+// OnStop registers fn to close a resource once the server has stopped, so no
+// rpc is using it anymore. Resources are closed in the order they were
+// registered, and together get as long as the drain to close.
+func (s *Server) OnStop(name string, fn func(ctx context.Context) error) {
+	s.hooks = append(s.hooks, hook{name: name, fn: fn})
+}
+
+// Run serves until the process gets SIGINT or SIGTERM, then shuts the server
+// down. A second signal cancels the rpcs still running right away.
+func (s *Server) Run() error {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sig)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case got := <-sig:
+			log.Printf("received %v, stopping the server", got)
+			cancel()
+		case <-done:
+			return
+		}
+		select {
+		case got := <-sig:
+			log.Printf("received %v again, cancelling in-flight rpcs", got)
+			s.grpc.Stop()
+		case <-done:
+		}
+	}()
+
+	return s.RunContext(ctx)
+}
+
+// RunContext serves until ctx is done, then shuts the server down and closes
+// the registered resources. It returns the error that made the server fail,
+// or nil if it was stopped.
+func (s *Server) RunContext(ctx context.Context) error {
+	errc := make(chan error, 1)
+	go func() {
+		errc <- s.grpc.Serve(s.lis)
+	}()
+
+	var err error
+	select {
+	case err = <-errc:
+	case <-ctx.Done():
+		for _, fn := range s.onShutdown {
+			fn()
+		}
+		if !Shutdown(s.grpc, s.drainTimeout) {
+			log.Printf("in-flight rpcs did not finish within %v, cancelled them", s.drainTimeout)
+		}
+		// Serve returns nil once the server is stopped
+		err = <-errc
+	}
+
+	s.closeResources()
+	return err
+}
+
+// closeResources runs the hooks in order. A resource that fails to close does
+// not keep the others open.
+func (s *Server) closeResources() {
+	ctx, cancel := context.WithTimeout(context.Background(), s.drainTimeout)
+	defer cancel()
+	for _, h := range s.hooks {
+		log.Printf("closing %s", h.name)
+		if err := h.fn(ctx); err != nil {
+			log.Printf("failed to close %s: %v", h.name, err)
+		}
+	}
+}
+
+// Shutdown stops s from taking new rpcs and waits for the in-flight ones to
+// finish. Those still running after timeout are cancelled. It reports whether
+// every rpc finished on its own.
+func Shutdown(s *grpc.Server, timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(done)
+	}()
+
+	t := time.NewTimer(timeout)
+	defer t.Stop()
+	select {
+	case <-done:
+		return true
+	case <-t.C:
+		s.Stop()
+		<-done
+		return false
+	}
+}
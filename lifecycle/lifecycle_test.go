@@ -0,0 +1,244 @@
+package lifecycle
+
+import (
+	"context"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// slowServer answers Check once it is released, or fails it once the rpc is
+// cancelled.
+type slowServer struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func newSlowServer() *slowServer {
+	return &slowServer{started: make(chan struct{}, 1), release: make(chan struct{})}
+}
+
+func (s *slowServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	s.started <- struct{}{}
+	select {
+	case <-s.release:
+		return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+func (s *slowServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "not implemented")
+}
+
+// event is something that happened while a server stopped, with its time.
+type event struct {
+	name string
+	at   time.Time
+}
+
+// recorder keeps the events of a test in order.
+type recorder struct {
+	mu     sync.Mutex
+	events []event
+}
+
+func (r *recorder) record(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event{name: name, at: time.Now()})
+}
+
+func (r *recorder) get(t *testing.T, name string) event {
+	t.Helper()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range r.events {
+		if e.name == name {
+			return e
+		}
+	}
+	t.Fatalf("%s did not happen, got %v", name, r.events)
+	return event{}
+}
+
+// harness serves a slowServer over an in-memory connection.
+type harness struct {
+	srv    *Server
+	slow   *slowServer
+	client healthpb.HealthClient
+	events *recorder
+}
+
+func newHarness(t *testing.T, drainTimeout time.Duration) *harness {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	slow := newSlowServer()
+	healthpb.RegisterHealthServer(s, slow)
+
+	cc, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithInsecure(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cc.Close() })
+
+	h := &harness{
+		srv:    New(s, lis, drainTimeout),
+		slow:   slow,
+		client: healthpb.NewHealthClient(cc),
+		events: &recorder{},
+	}
+	h.srv.OnStop("resource", func(context.Context) error {
+		h.events.record("resource closed")
+		return nil
+	})
+	return h
+}
+
+// startCheck starts a Check rpc and waits until the server is running it.
+// The returned channel gets its error once it ends.
+func (h *harness) startCheck(t *testing.T) <-chan error {
+	t.Helper()
+	errc := make(chan error, 1)
+	go func() {
+		_, err := h.client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		h.events.record("rpc ended")
+		errc <- err
+	}()
+	select {
+	case <-h.slow.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("rpc did not start")
+	}
+	return errc
+}
+
+func wait(t *testing.T, errc <-chan error, what string) error {
+	t.Helper()
+	select {
+	case err := <-errc:
+		return err
+	case <-time.After(10 * time.Second):
+		t.Fatalf("%s did not return", what)
+		return nil
+	}
+}
+
+func TestRunContextDrainsInFlightRPCs(t *testing.T) {
+	h := newHarness(t, 5*time.Second)
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() { runErr <- h.srv.RunContext(ctx) }()
+
+	rpcErr := h.startCheck(t)
+	cancel()
+
+	// the server no longer takes rpcs, but lets the running one finish
+	time.Sleep(50 * time.Millisecond)
+	select {
+	case err := <-runErr:
+		t.Fatalf("RunContext returned %v before the in-flight rpc finished", err)
+	default:
+	}
+	close(h.slow.release)
+
+	if err := wait(t, rpcErr, "rpc"); err != nil {
+		t.Errorf("in-flight rpc failed: %v", err)
+	}
+	if err := wait(t, runErr, "RunContext"); err != nil {
+		t.Errorf("RunContext: %v", err)
+	}
+	if ended, closed := h.events.get(t, "rpc ended"), h.events.get(t, "resource closed"); closed.at.Before(ended.at) {
+		t.Errorf("resource closed before the in-flight rpc ended")
+	}
+}
+
+func TestRunContextStopsAfterDrainTimeout(t *testing.T) {
+	const drainTimeout = 200 * time.Millisecond
+	h := newHarness(t, drainTimeout)
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() { runErr <- h.srv.RunContext(ctx) }()
+
+	rpcErr := h.startCheck(t)
+	cancel()
+	stopping := time.Now()
+
+	// the rpc never finishes on its own, so it is cancelled once the drain
+	// times out, and not before
+	err := wait(t, rpcErr, "rpc")
+	if code := status.Code(err); code != codes.Canceled && code != codes.Unavailable {
+		t.Errorf("cut off rpc failed with %v, want %v or %v: %v", code, codes.Canceled, codes.Unavailable, err)
+	}
+	if ended := h.events.get(t, "rpc ended"); ended.at.Sub(stopping) < drainTimeout {
+		t.Errorf("rpc was cut off %v after the server started stopping, want at least %v", ended.at.Sub(stopping), drainTimeout)
+	}
+	if err := wait(t, runErr, "RunContext"); err != nil {
+		t.Errorf("RunContext: %v", err)
+	}
+	h.events.get(t, "resource closed")
+}
+
+func TestOnShutdownRunsBeforeDrain(t *testing.T) {
+	h := newHarness(t, 5*time.Second)
+	// ending the rpc as soon as the shutdown starts keeps the drain short
+	h.srv.OnShutdown(func() {
+		h.events.record("shutdown started")
+		close(h.slow.release)
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() { runErr <- h.srv.RunContext(ctx) }()
+
+	rpcErr := h.startCheck(t)
+	cancel()
+
+	if err := wait(t, rpcErr, "rpc"); err != nil {
+		t.Errorf("in-flight rpc failed: %v", err)
+	}
+	if err := wait(t, runErr, "RunContext"); err != nil {
+		t.Errorf("RunContext: %v", err)
+	}
+	started, ended := h.events.get(t, "shutdown started"), h.events.get(t, "rpc ended")
+	if ended.at.Before(started.at) {
+		t.Errorf("rpc ended before the shutdown started")
+	}
+}
+
+func TestRunStopsOnSignal(t *testing.T) {
+	h := newHarness(t, 5*time.Second)
+	runErr := make(chan error, 1)
+	go func() { runErr <- h.srv.Run() }()
+
+	// the rpc only starts once Run listens for signals
+	rpcErr := h.startCheck(t)
+	if err := syscall.Kill(os.Getpid(), syscall.SIGTERM); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(h.slow.release)
+
+	if err := wait(t, rpcErr, "rpc"); err != nil {
+		t.Errorf("in-flight rpc failed: %v", err)
+	}
+	if err := wait(t, runErr, "Run"); err != nil {
+		t.Errorf("Run: %v", err)
+	}
+	h.events.get(t, "resource closed")
+}
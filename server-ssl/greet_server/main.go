@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net"
@@ -10,6 +11,7 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 
+	"lifecycle"
 	"server-ssl/greetpb"
 )
 
@@ -28,6 +30,9 @@ func (*server) Greet(ctx context.Context, req *greetpb.GreetRequest) (*greetpb.G
 }
 
 func main() {
+	drainTimeout := flag.Duration("drain-timeout", lifecycle.DefaultDrainTimeout, "how long in-flight rpcs get to finish when the server stops")
+	flag.Parse()
+
 	fmt.Println("hello")
 
 	lis, err := net.Listen("tcp", "0.0.0.0:50051")
@@ -53,7 +58,7 @@ func main() {
 
 	reflection.Register(s)
 
-	if err := s.Serve(lis); err != nil {
+	if err := lifecycle.New(s, lis, *drainTimeout).Run(); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
 }
@@ -5,4 +5,7 @@ go 1.14
 require (
 	github.com/golang/protobuf v1.4.0
 	google.golang.org/grpc v1.28.1
+	lifecycle v0.0.0
 )
+
+replace lifecycle => ../lifecycle